@@ -2,23 +2,61 @@ package bootstrap
 
 import (
 	"log"
+	"reflect"
 
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 )
 
 type Env struct {
-	AppEnv                 string `mapstructure:"APP_ENV"`
-	ServerAddress          string `mapstructure:"SERVER_ADDRESS"`
-	MongoDBURI             string `mapstructure:"MONGODB_URI"`
-	MongoDBName            string `mapstructure:"MONGODB_NAME"`
-	AccessTokenExpiryHour  int    `mapstructure:"ACCESS_TOKEN_EXPIRY_HOUR"`
-	RefreshTokenExpiryHour int    `mapstructure:"REFRESH_TOKEN_EXPIRY_HOUR"`
-	AccessTokenSecret      string `mapstructure:"ACCESS_TOKEN_SECRET"`
-	RefreshTokenSecret     string `mapstructure:"REFRESH_TOKEN_SECRET"`
+	AppEnv                   string   `mapstructure:"APP_ENV"`
+	ServerAddress            string   `mapstructure:"SERVER_ADDRESS"`
+	MongoDBURI               string   `mapstructure:"MONGODB_URI"`
+	MongoDBName              string   `mapstructure:"MONGODB_NAME"`
+	AccessTokenExpiryHour    int      `mapstructure:"ACCESS_TOKEN_EXPIRY_HOUR"`
+	RefreshTokenExpiryHour   int      `mapstructure:"REFRESH_TOKEN_EXPIRY_HOUR"`
+	AccessTokenSecret        string   `mapstructure:"ACCESS_TOKEN_SECRET"`
+	ExportDir                string   `mapstructure:"EXPORT_DIR"`
+	ExportExpiryHour         int      `mapstructure:"EXPORT_EXPIRY_HOUR"`
+	ExportMediaHosts         string   `mapstructure:"EXPORT_MEDIA_HOSTS"`
+	ViewWindowHour           int      `mapstructure:"VIEW_WINDOW_HOUR"`
+	ReportHideThreshold      int      `mapstructure:"REPORT_HIDE_THRESHOLD"`
+	XPCorrectNew             *int     `mapstructure:"XP_CORRECT_NEW"`
+	XPCorrectLearning        *int     `mapstructure:"XP_CORRECT_LEARNING"`
+	XPCorrectReview          *int     `mapstructure:"XP_CORRECT_REVIEW"`
+	XPWrong                  *int     `mapstructure:"XP_WRONG"`
+	XPFirstLearnBonus        *int     `mapstructure:"XP_FIRST_LEARN_BONUS"`
+	XPStreakBonusPercent     *int     `mapstructure:"XP_STREAK_BONUS_PERCENT"`
+	XPMaxStreakBonusPercent  *int     `mapstructure:"XP_MAX_STREAK_BONUS_PERCENT"`
+	XPPerfectSessionBonus    *int     `mapstructure:"XP_PERFECT_SESSION_BONUS"`
+	XPPerfectSessionMinCards *int     `mapstructure:"XP_PERFECT_SESSION_MIN_CARDS"`
+	ReviewMaxPerSecond       *float64 `mapstructure:"REVIEW_MAX_PER_SECOND"`
+	XPMaxPerSession          *int     `mapstructure:"XP_MAX_PER_SESSION"`
+	LevelCurve               string   `mapstructure:"LEVEL_CURVE"`
+	LevelCurveBase           int      `mapstructure:"LEVEL_CURVE_BASE"`
+	LevelCurveStep           int      `mapstructure:"LEVEL_CURVE_STEP"`
+	LevelCurveTable          string   `mapstructure:"LEVEL_CURVE_TABLE"`
+	ReminderIntervalMinute   int      `mapstructure:"REMINDER_INTERVAL_MINUTE"`
+	SMTPHost                 string   `mapstructure:"SMTP_HOST"`
+	SMTPPort                 int      `mapstructure:"SMTP_PORT"`
+	SMTPUsername             string   `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword             string   `mapstructure:"SMTP_PASSWORD"`
+	SMTPFrom                 string   `mapstructure:"SMTP_FROM"`
+	PushEndpoint             string   `mapstructure:"PUSH_ENDPOINT"`
+	PushServerKey            string   `mapstructure:"PUSH_SERVER_KEY"`
 }
 
 var E Env;
 
+// emptyToNil leaves pointer fields nil when their variable is empty, so that
+// an unset variable can be told apart from one set to 0.
+func emptyToNil(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if to.Kind() == reflect.Ptr && from.Kind() == reflect.String && data.(string) == "" {
+		return nil, nil
+	}
+	return data, nil
+}
+
 func NewEnv() {
 	E = Env{}
 	viper.SetConfigFile(".env")
@@ -28,7 +66,11 @@ func NewEnv() {
 		log.Fatal("Can't find the file .env : ", err)
 	}
 
-	err = viper.Unmarshal(&E)
+	err = viper.Unmarshal(&E, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		emptyToNil,
+	)))
 	if err != nil {
 		log.Fatal("Environment can't be loaded: ", err)
 	}
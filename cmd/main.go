@@ -1,6 +1,9 @@
 package main
 
 import (
+	// Reminders and activity days use IANA time zones, which must load even
+	// where the system has no zone database.
+	_ "time/tzdata"
 	"vietcard-backend/bootstrap"
 	"vietcard-backend/database/mongodb"
 	"vietcard-backend/internal/delivery/http/route"
@@ -13,6 +16,8 @@ func main() {
 	router := gin.Default()
 
 	db := mongodb.NewDBConnection(bootstrap.E.MongoDBURI)
+	mongodb.EnsureIndexes(db)
+	mongodb.RunMigrations(db)
 	route.Setup(db, router)
 	router.Run(bootstrap.E.ServerAddress)
 }
@@ -0,0 +1,257 @@
+package mongodb
+
+import (
+	"context"
+	"log"
+	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// indexes lists the secondary indexes each collection needs.
+var indexes = map[string][]mongo.IndexModel{
+	"decks": {
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		{Keys: bson.D{{Key: "is_public", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "is_public", Value: 1}, {Key: "views", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "is_public", Value: 1}, {Key: "bayesian_rating", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "is_public", Value: 1}, {Key: "copies", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "is_public", Value: 1}, {Key: "user_id", Value: 1}, {Key: "updated_at", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "tags", Value: 1}}},
+		{Keys: bson.D{{Key: "location", Value: "2dsphere"}}},
+		{Keys: bson.D{{Key: "region.province_key", Value: 1}}},
+		{
+			Keys:    bson.D{{Key: "search_text", Value: "text"}},
+			Options: options.Index().SetDefaultLanguage("none").SetName("search_text"),
+		},
+	},
+	"cards": {
+		{Keys: bson.D{{Key: "deck_id", Value: 1}, {Key: "created_at", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "next_review", Value: 1}}},
+		{Keys: bson.D{{Key: "location", Value: "2dsphere"}}},
+		{Keys: bson.D{{Key: "region.province_key", Value: 1}}},
+		{
+			Keys:    bson.D{{Key: "search_text", Value: "text"}},
+			Options: options.Index().SetDefaultLanguage("none").SetName("search_text"),
+		},
+	},
+	"comments": {
+		{Keys: bson.D{{Key: "deck_id", Value: 1}, {Key: "card_id", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "_id", Value: 1}}},
+	},
+	"deck_ratings": {
+		{
+			Keys:    bson.D{{Key: "deck_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	},
+	"deck_view_events": {
+		{
+			Keys:    bson.D{{Key: "deck_id", Value: 1}, {Key: "viewer_key", Value: 1}, {Key: "window_start", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "created_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(entity.MAX_VIEW_WINDOW_HOUR * 60 * 60),
+		},
+	},
+	"deck_view_daily": {
+		{
+			Keys:    bson.D{{Key: "deck_id", Value: 1}, {Key: "day", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	},
+	"follows": {
+		{
+			Keys:    bson.D{{Key: "follower_id", Value: 1}, {Key: "followee_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	},
+	"friendships": {
+		{
+			Keys:    bson.D{{Key: "pair_key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "requester_id", Value: 1}, {Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "addressee_id", Value: 1}, {Key: "status", Value: 1}}},
+	},
+	"leagues": {
+		{Keys: bson.D{{Key: "week", Value: 1}, {Key: "tier", Value: 1}, {Key: "closed_at", Value: 1}, {Key: "size", Value: 1}}},
+	},
+	"weekly_xp": {
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "week", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "week", Value: 1}, {Key: "xp", Value: -1}}},
+		{Keys: bson.D{{Key: "league_id", Value: 1}, {Key: "xp", Value: -1}}},
+	},
+	"deck_members": {
+		{
+			Keys:    bson.D{{Key: "deck_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+	},
+	"card_progress": {
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "card_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "deck_id", Value: 1}, {Key: "user_id", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "next_review", Value: 1}}},
+		{Keys: bson.D{{Key: "card_id", Value: 1}}},
+	},
+	"deck_progress": {
+		{
+			Keys:    bson.D{{Key: "deck_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	},
+	"share_links": {
+		{
+			Keys:    bson.D{{Key: "token", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "deck_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	},
+	"share_link_attempts": {
+		{
+			Keys:    bson.D{{Key: "key", Value: 1}, {Key: "window_start", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "window_start", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(entity.SHARE_ATTEMPT_WINDOW_MINUTE * 60),
+		},
+	},
+	"reports": {
+		{
+			Keys:    bson.D{{Key: "target_type", Value: 1}, {Key: "target_id", Value: 1}, {Key: "reporter_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "target_type", Value: 1}}},
+	},
+	"moderation_actions": {
+		{Keys: bson.D{{Key: "target_id", Value: 1}, {Key: "_id", Value: -1}}},
+	},
+	"review_logs": {
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "is_flagged", Value: 1}, {Key: "_id", Value: -1}}},
+	},
+	"user_badges": {
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	},
+	"streak_days": {
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "day", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	},
+	"daily_progress": {
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "day", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	},
+	"user_quests": {
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "period_key", Value: 1}, {Key: "template_key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	},
+	"wallets": {
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	},
+	"wallet_ledger": {
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "idempotency_key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "_id", Value: -1}}},
+	},
+	"shop_items": {
+		{Keys: bson.D{{Key: "is_active", Value: 1}, {Key: "price", Value: 1}}},
+	},
+	"purchases": {
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "idempotency_key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	},
+	"user_items": {
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "item_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	},
+	"xp_ledger": {
+		{
+			Keys: bson.D{{Key: "source", Value: 1}, {Key: "ref_id", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.D{{Key: "ref_id", Value: bson.D{{Key: "$exists", Value: true}}}}),
+		},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "_id", Value: -1}}},
+	},
+	"fun_facts": {
+		{
+			Keys: bson.D{{Key: "content_hash", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.D{{Key: "content_hash", Value: bson.D{{Key: "$type", Value: "string"}}}}),
+		},
+		{Keys: bson.D{{Key: "locale", Value: 1}, {Key: "category", Value: 1}, {Key: "_id", Value: 1}}},
+	},
+	"fact_views": {
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "fact_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "seen_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(entity.FACT_RECENT_DAYS * 24 * 60 * 60),
+		},
+	},
+	"users": {
+		{Keys: bson.D{{Key: "reminder.enabled", Value: 1}, {Key: "reminder.last_sent_at", Value: 1}}},
+	},
+	"sessions": {
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "last_seen_at", Value: -1}}},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	},
+	"refresh_tokens": {
+		{
+			Keys:    bson.D{{Key: "token_hash", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "session_id", Value: 1}}},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	},
+	"export_jobs": {
+		{Keys: bson.D{{Key: "token", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	},
+}
+
+func EnsureIndexes(db *mongo.Database) {
+	for colName, models := range indexes {
+		_, err := db.Collection(colName).Indexes().CreateMany(context.TODO(), models)
+		if err != nil {
+			log.Printf("Failed to create indexes on %s: %v", colName, err)
+		}
+	}
+}
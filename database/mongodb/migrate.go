@@ -0,0 +1,252 @@
+package mongodb
+
+import (
+	"context"
+	"log"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// migrations are data fixes run once each. The names of the finished ones are
+// kept in the migrations collection, so a start only runs the new ones.
+var migrations = []struct {
+	name string
+	run  func(db *mongo.Database) error
+}{
+	{"backfill deck search text", backfillDeckSearchText},
+	{"backfill card search text", backfillCardSearchText},
+	{"reset owner-set deck ratings", resetUnaggregatedDeckRatings},
+	{"backfill deck updated_at", backfillDeckUpdatedAt},
+	{"backfill lifetime XP", backfillLifetimeXP},
+	{"backfill fact locale and content hash", backfillFactContentHash},
+	{"backfill deck copies", backfillDeckCopies},
+	{"recount deck total cards", recountDeckTotalCards},
+}
+
+func RunMigrations(db *mongo.Database) {
+	col := db.Collection("migrations")
+	for _, m := range migrations {
+		err := col.FindOne(context.TODO(), bson.D{{Key: "_id", Value: m.name}}).Err()
+		if err == nil {
+			continue
+		}
+		if err != mongo.ErrNoDocuments {
+			log.Printf("Migration %q skipped: %v", m.name, err)
+			continue
+		}
+		if err = m.run(db); err != nil {
+			log.Printf("Migration %q failed: %v", m.name, err)
+			continue
+		}
+		_, err = col.InsertOne(context.TODO(), bson.D{{Key: "_id", Value: m.name}, {Key: "applied_at", Value: time.Now()}})
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			log.Printf("Migration %q could not be recorded: %v", m.name, err)
+		}
+	}
+}
+
+var missingSearchText = bson.D{{Key: "search_text", Value: bson.D{{Key: "$exists", Value: false}}}}
+
+func backfillDeckSearchText(db *mongo.Database) error {
+	col := db.Collection("decks")
+	cursor, err := col.Find(context.TODO(), missingSearchText)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.TODO())
+	for cursor.Next(context.TODO()) {
+		var deck entity.Deck
+		if err = cursor.Decode(&deck); err != nil {
+			return err
+		}
+		deck.SetSearchText()
+		update := bson.D{{Key: "$set", Value: bson.D{{Key: "search_text", Value: deck.SearchText}}}}
+		if _, err = col.UpdateByID(context.TODO(), deck.ID, update); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func backfillCardSearchText(db *mongo.Database) error {
+	col := db.Collection("cards")
+	cursor, err := col.Find(context.TODO(), missingSearchText)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.TODO())
+	for cursor.Next(context.TODO()) {
+		var card entity.Card
+		if err = cursor.Decode(&card); err != nil {
+			return err
+		}
+		card.SetSearchText()
+		update := bson.D{{Key: "$set", Value: bson.D{{Key: "search_text", Value: card.SearchText}}}}
+		if _, err = col.UpdateByID(context.TODO(), card.ID, update); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// resetUnaggregatedDeckRatings clears the owner-set ratings of decks that
+// predate per-user ratings.
+func resetUnaggregatedDeckRatings(db *mongo.Database) error {
+	filter := bson.D{{Key: "rating_count", Value: bson.D{{Key: "$exists", Value: false}}}}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "rating", Value: 0},
+		{Key: "rating_count", Value: 0},
+		{Key: "rating_sum", Value: 0},
+		{Key: "bayesian_rating", Value: entity.BayesianRating(0, 0)},
+	}}}
+	_, err := db.Collection("decks").UpdateMany(context.TODO(), filter, update)
+	return err
+}
+
+func backfillDeckUpdatedAt(db *mongo.Database) error {
+	filter := bson.D{{Key: "updated_at", Value: bson.D{{Key: "$exists", Value: false}}}}
+	update := bson.A{bson.D{{Key: "$set", Value: bson.D{{Key: "updated_at", Value: "$created_at"}}}}}
+	_, err := db.Collection("decks").UpdateMany(context.TODO(), filter, update)
+	return err
+}
+
+// backfillLifetimeXP gives users that predate the XP ledger the lifetime XP of
+// their level under the curve levels followed back then, recorded as a single
+// migration entry.
+func backfillLifetimeXP(db *mongo.Database) error {
+	col := db.Collection("users")
+	filter := bson.D{{Key: "lifetime_xp", Value: bson.D{{Key: "$exists", Value: false}}}}
+	cursor, err := col.Find(context.TODO(), filter)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.TODO())
+	for cursor.Next(context.TODO()) {
+		var user entity.User
+		if err = cursor.Decode(&user); err != nil {
+			return err
+		}
+		if user.Level < 1 {
+			user.Level = 1
+		}
+		lifetimeXP := entity.DefaultLevelCurve.LifetimeXP(user.Level, user.XP)
+		if lifetimeXP > 0 {
+			entryFilter := bson.D{{Key: "user_id", Value: user.ID}, {Key: "source", Value: entity.XP_SOURCE_MIGRATION}}
+			entryUpdate := bson.D{{Key: "$setOnInsert", Value: bson.D{
+				{Key: "created_at", Value: time.Now()},
+				{Key: "amount", Value: lifetimeXP},
+			}}}
+			_, err = db.Collection("xp_ledger").UpdateOne(context.TODO(), entryFilter, entryUpdate, options.Update().SetUpsert(true))
+			if err != nil {
+				return err
+			}
+		}
+		update := bson.D{{Key: "$set", Value: bson.D{{Key: "lifetime_xp", Value: lifetimeXP}}}}
+		if _, err = col.UpdateByID(context.TODO(), user.ID, update); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// backfillFactContentHash normalizes the facts created before they had a
+// locale and a content hash. Duplicates of an earlier fact are left without a
+// hash for an admin to merge.
+func backfillFactContentHash(db *mongo.Database) error {
+	col := db.Collection("fun_facts")
+	filter := bson.D{{Key: "content_hash", Value: bson.D{{Key: "$exists", Value: false}}}}
+	cursor, err := col.Find(context.TODO(), filter)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.TODO())
+	for cursor.Next(context.TODO()) {
+		var fact entity.Fact
+		if err = cursor.Decode(&fact); err != nil {
+			return err
+		}
+		fact.Normalize()
+		if fact.CreatedAt.IsZero() {
+			fact.CreatedAt = fact.ID.Timestamp()
+			fact.UpdatedAt = fact.CreatedAt
+		}
+		update := bson.D{{Key: "$set", Value: bson.D{
+			{Key: "created_at", Value: fact.CreatedAt},
+			{Key: "updated_at", Value: fact.UpdatedAt},
+			{Key: "content", Value: fact.Content},
+			{Key: "category", Value: fact.Category},
+			{Key: "source", Value: fact.Source},
+			{Key: "source_url", Value: fact.SourceURL},
+			{Key: "locale", Value: fact.Locale},
+			{Key: "content_hash", Value: fact.ContentHash},
+		}}}
+		_, err = col.UpdateByID(context.TODO(), fact.ID, update)
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func backfillDeckCopies(db *mongo.Database) error {
+	filter := bson.D{{Key: "copies", Value: bson.D{{Key: "$exists", Value: false}}}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "copies", Value: 0}}}}
+	_, err := db.Collection("decks").UpdateMany(context.TODO(), filter, update)
+	return err
+}
+
+// recountDeckTotalCards fixes the total_cards of decks that drifted from the
+// number of their cards.
+func recountDeckTotalCards(db *mongo.Database) error {
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$deck_id"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	}
+	cursor, err := db.Collection("cards").Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return err
+	}
+	var counts []struct {
+		DeckID primitive.ObjectID `bson:"_id"`
+		Count  int                `bson:"count"`
+	}
+	if err = cursor.All(context.TODO(), &counts); err != nil {
+		return err
+	}
+	totalCards := make(map[primitive.ObjectID]int, len(counts))
+	for _, count := range counts {
+		totalCards[count.DeckID] = count.Count
+	}
+
+	col := db.Collection("decks")
+	option := options.Find().SetProjection(bson.D{{Key: "total_cards", Value: 1}})
+	deckCursor, err := col.Find(context.TODO(), bson.D{}, option)
+	if err != nil {
+		return err
+	}
+	defer deckCursor.Close(context.TODO())
+	for deckCursor.Next(context.TODO()) {
+		var deck struct {
+			ID         primitive.ObjectID `bson:"_id"`
+			TotalCards int                `bson:"total_cards"`
+		}
+		if err = deckCursor.Decode(&deck); err != nil {
+			return err
+		}
+		if deck.TotalCards == totalCards[deck.ID] {
+			continue
+		}
+		update := bson.D{{Key: "$set", Value: bson.D{{Key: "total_cards", Value: totalCards[deck.ID]}}}}
+		if _, err = col.UpdateByID(context.TODO(), deck.ID, update); err != nil {
+			return err
+		}
+	}
+	return deckCursor.Err()
+}
@@ -24,14 +24,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/card/copy": {
+        "/api/admin/fact/create": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Copy Card To Deck",
+                "description": "Create a fun fact with an optional category, source citation, related deck and locale, Vietnamese by default. A fact with the same content in the locale, ignoring case, diacritics and punctuation, is rejected as a duplicate. Admin only",
                 "consumes": [
                     "application/json"
                 ],
@@ -39,17 +39,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "card"
+                    "fact"
                 ],
-                "summary": "Copy Card To Deck",
+                "summary": "Create New Fact",
                 "parameters": [
                     {
-                        "description": "Copy Card To Deck Request",
-                        "name": "copy_card_to_deck_request",
+                        "description": "Create Fact Request",
+                        "name": "create_fact_request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CopyCardToDeckRequest"
+                            "$ref": "#/definitions/request.CreateFactRequest"
                         }
                     }
                 ],
@@ -57,7 +57,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.CopyCardToDeckResponse"
+                            "$ref": "#/definitions/response.CreateFactResponse"
                         }
                     },
                     "400": {
@@ -66,8 +66,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -75,14 +81,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/card/create": {
-            "post": {
+        "/api/admin/fact/delete": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create New Card",
+                "description": "Delete a fun fact. Admin only",
                 "consumes": [
                     "application/json"
                 ],
@@ -90,17 +96,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "card"
+                    "fact"
                 ],
-                "summary": "Create New Card",
+                "summary": "Delete Fact",
                 "parameters": [
                     {
-                        "description": "Create Card Request",
-                        "name": "create_card_request",
+                        "description": "Delete Fact Request",
+                        "name": "delete_fact_request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CreateCardRequest"
+                            "$ref": "#/definitions/request.DeleteFactRequest"
                         }
                     }
                 ],
@@ -108,7 +114,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.CreateCardResponse"
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "500": {
@@ -120,40 +144,52 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/card/delete": {
-            "delete": {
+        "/api/admin/fact/list": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete Card",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the fun facts, newest first, optionally of a locale and a category. Admin only",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "card"
+                    "fact"
                 ],
-                "summary": "Delete Card",
+                "summary": "Get Facts",
                 "parameters": [
                     {
-                        "description": "Delete Card Request",
-                        "name": "delete_card_request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.DeleteCardRequest"
-                        }
+                        "type": "string",
+                        "description": "Locale",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.SuccessResponse"
+                            "$ref": "#/definitions/response.GetFactsResponse"
                         }
                     },
                     "400": {
@@ -162,8 +198,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -171,14 +207,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/card/review": {
+        "/api/admin/fact/update": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update Review Cards",
+                "description": "Update a fun fact. Content that duplicates another fact of the locale is rejected. Admin only",
                 "consumes": [
                     "application/json"
                 ],
@@ -186,17 +222,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "card"
+                    "fact"
                 ],
-                "summary": "Update Review Cards",
+                "summary": "Update Fact",
                 "parameters": [
                     {
-                        "description": "Update Review Cards Request",
-                        "name": "update_review_cards_request",
+                        "description": "Update Fact Request",
+                        "name": "update_fact_request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdateReviewCardsRequest"
+                            "$ref": "#/definitions/request.UpdateFactRequest"
                         }
                     }
                 ],
@@ -204,7 +240,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.UpdateReviewCardsResponse"
+                            "$ref": "#/definitions/response.FactResponse"
                         }
                     },
                     "400": {
@@ -213,8 +249,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -222,44 +270,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/card/update": {
-            "put": {
+        "/api/admin/level/recompute": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update Card Details",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Recompute every user's lifetime XP from the XP ledger and their level on the configured level curve. Run it after changing the curve. Admin only",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "card"
-                ],
-                "summary": "Update Card Details",
-                "parameters": [
-                    {
-                        "description": "Update Card Request",
-                        "name": "update_card_request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.UpdateCardRequest"
-                        }
-                    }
+                    "user"
                 ],
+                "summary": "Recompute Levels",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.UpdateCardResponse"
+                            "$ref": "#/definitions/response.RecomputeLevelsResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -273,14 +307,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/deck/copy": {
+        "/api/admin/moderation/action": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Copy Deck",
+                "description": "Hide, restore, unpublish, warn the author of, ban or unban the author of, or dismiss the reports of a deck, card or comment. A ban signs the author out everywhere and hides their public decks and comments until unbanned. Closes its open reports. Admin only",
                 "consumes": [
                     "application/json"
                 ],
@@ -288,17 +322,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "deck"
+                    "moderation"
                 ],
-                "summary": "Copy Deck",
+                "summary": "Moderate Content",
                 "parameters": [
                     {
-                        "description": "Copy Deck Request",
-                        "name": "copy_deck_request",
+                        "description": "Moderate Content Request",
+                        "name": "moderate_content_request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CopyDeckRequest"
+                            "$ref": "#/definitions/request.ModerateContentRequest"
                         }
                     }
                 ],
@@ -306,7 +340,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.CopyDeckResponse"
+                            "$ref": "#/definitions/response.ModerationActionResponse"
                         }
                     },
                     "400": {
@@ -315,8 +349,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -330,40 +364,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/deck/create": {
-            "post": {
+        "/api/admin/moderation/log": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create New Deck",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the recorded moderation decisions, newest first, optionally of a single target. Admin only",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "deck"
+                    "moderation"
                 ],
-                "summary": "Create New Deck",
+                "summary": "Get Moderation Log",
                 "parameters": [
                     {
-                        "description": "Create Deck Request",
-                        "name": "create_deck_request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreateDeckRequest"
-                        }
+                        "type": "string",
+                        "description": "Target ID",
+                        "name": "target_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.CreateDeckResponse"
+                            "$ref": "#/definitions/response.GetModerationLogResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "500": {
@@ -375,40 +427,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/deck/delete": {
-            "delete": {
+        "/api/admin/moderation/queue": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete Deck",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the reported content with open reports, most reported first. Admin only",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "deck"
+                    "moderation"
                 ],
-                "summary": "Delete Deck",
+                "summary": "Get Moderation Queue",
                 "parameters": [
                     {
-                        "description": "Delete Deck Request",
-                        "name": "delete_deck_request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.DeleteDeckRequest"
-                        }
+                        "type": "string",
+                        "description": "deck, card or comment",
+                        "name": "target_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.DeleteDeckResponse"
+                            "$ref": "#/definitions/response.GetModerationQueueResponse"
                         }
                     },
                     "400": {
@@ -417,6 +475,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -426,29 +490,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/deck/review-cards": {
+        "/api/admin/review/flagged": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get Deck With Review Cards Of Logged In User",
+                "description": "Get the review sessions flagged as implausible, newest first, optionally of a single user. Admin only",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "deck"
+                    "moderation"
+                ],
+                "summary": "Get Flagged Reviews",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get Deck With Review Cards Of Logged In User",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entity.DeckWithReviewCards"
-                            }
+                            "$ref": "#/definitions/response.GetFlaggedReviewsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "500": {
@@ -460,14 +553,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/deck/update": {
-            "put": {
+        "/api/admin/shop/item/create": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update Deck Details",
+                "description": "Add an item to the shop. Admin only",
                 "consumes": [
                     "application/json"
                 ],
@@ -475,17 +568,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "deck"
+                    "shop"
                 ],
-                "summary": "Update Deck Details",
+                "summary": "Create Shop Item",
                 "parameters": [
                     {
-                        "description": "Update Deck Request",
-                        "name": "update_deck_request",
+                        "description": "Create Shop Item Request",
+                        "name": "create_shop_item_request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdateDeckRequest"
+                            "$ref": "#/definitions/request.CreateShopItemRequest"
                         }
                     }
                 ],
@@ -493,7 +586,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.UpdateDeckResponse"
+                            "$ref": "#/definitions/response.ShopItemResponse"
                         }
                     },
                     "400": {
@@ -502,8 +595,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -511,9 +604,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/deck/view": {
+        "/api/admin/shop/item/update": {
             "put": {
-                "description": "Update View Deck",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update a shop item, or take it off sale. Admin only",
                 "consumes": [
                     "application/json"
                 ],
@@ -521,17 +619,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "deck"
+                    "shop"
                 ],
-                "summary": "Update View Deck",
+                "summary": "Update Shop Item",
                 "parameters": [
                     {
-                        "description": "View Deck Request",
-                        "name": "view_deck_request",
+                        "description": "Update Shop Item Request",
+                        "name": "update_shop_item_request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdateViewDeckRequest"
+                            "$ref": "#/definitions/request.UpdateShopItemRequest"
                         }
                     }
                 ],
@@ -539,7 +637,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.SuccessResponse"
+                            "$ref": "#/definitions/response.ShopItemResponse"
                         }
                     },
                     "400": {
@@ -548,8 +646,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -557,40 +655,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/fact": {
+        "/api/admin/shop/items": {
             "get": {
-                "description": "Get Fact",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get every shop item, including the ones not for sale. Admin only",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "fact"
+                    "shop"
                 ],
-                "summary": "Get Fact",
+                "summary": "Get All Shop Items",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.GetFactResponse"
+                            "$ref": "#/definitions/response.GetShopItemsResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
-                    }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "/api/fact/create": {
+        "/api/admin/wallet/grant": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create New Fact",
+                "description": "Give coins to a user, or take them away with a negative amount. Retrying with the same idempotency key grants only once. Admin only",
                 "consumes": [
                     "application/json"
                 ],
@@ -598,17 +707,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "fact"
+                    "wallet"
                 ],
-                "summary": "Create New Fact",
+                "summary": "Grant Coins",
                 "parameters": [
                     {
-                        "description": "Create Fact Request",
-                        "name": "create_fact_request",
+                        "description": "Grant Coins Request",
+                        "name": "grant_coins_request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CreateFactRequest"
+                            "$ref": "#/definitions/request.GrantCoinsRequest"
                         }
                     }
                 ],
@@ -616,47 +725,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.CreateFactResponse"
+                            "$ref": "#/definitions/response.GrantCoinsResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/api/get-all": {
-            "post": {
-                "description": "Get All Data",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "mobile"
-                ],
-                "summary": "Get All Data",
-                "parameters": [
-                    {
-                        "description": "Refresh Token Request",
-                        "name": "refresh_token_request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.RefreshTokenRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/response.GetAllDataResponse"
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "500": {
@@ -668,38 +749,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/login": {
-            "post": {
-                "description": "Log In",
-                "consumes": [
-                    "multipart/form-data"
+        "/api/admin/wallet/ledger": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Get the ledger of earned and spent coins of a user, newest first. Admin only",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "wallet"
                 ],
-                "summary": "Log In",
+                "summary": "Get User Ledger",
                 "parameters": [
                     {
                         "type": "string",
-                        "name": "email",
-                        "in": "formData",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query",
                         "required": true
                     },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "name": "password",
-                        "in": "formData",
-                        "required": true
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.LoginResponse"
+                            "$ref": "#/definitions/response.GetLedgerResponse"
                         }
                     },
                     "400": {
@@ -708,20 +798,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -729,38 +807,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/login-get-all": {
+        "/api/card/copy": {
             "post": {
-                "description": "Log In And Get All Data",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Copy Card To Deck",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "mobile"
+                    "card"
                 ],
-                "summary": "Log In And Get All Data",
+                "summary": "Copy Card To Deck",
                 "parameters": [
                     {
-                        "type": "string",
-                        "name": "email",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "name": "password",
-                        "in": "formData",
-                        "required": true
+                        "description": "Copy Card To Deck Request",
+                        "name": "copy_card_to_deck_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CopyCardToDeckRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.LoginGetAllDataResponse"
+                            "$ref": "#/definitions/response.CopyCardToDeckResponse"
                         }
                     },
                     "400": {
@@ -769,18 +849,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -790,9 +858,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/refresh": {
+        "/api/card/create": {
             "post": {
-                "description": "Refresh Token",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create New Card",
                 "consumes": [
                     "application/json"
                 ],
@@ -800,17 +873,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "card"
                 ],
-                "summary": "Refresh Token",
+                "summary": "Create New Card",
                 "parameters": [
                     {
-                        "description": "Refresh Token Request",
-                        "name": "refresh_token_request",
+                        "description": "Create Card Request",
+                        "name": "create_card_request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.RefreshTokenRequest"
+                            "$ref": "#/definitions/request.CreateCardRequest"
                         }
                     }
                 ],
@@ -818,13 +891,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.RefreshTokenResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/response.CreateCardResponse"
                         }
                     },
                     "500": {
@@ -836,44 +903,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/signup": {
-            "post": {
-                "description": "Sign Up",
+        "/api/card/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete Card",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "card"
                 ],
-                "summary": "Sign Up",
+                "summary": "Delete Card",
                 "parameters": [
                     {
-                        "type": "string",
-                        "name": "email",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "name": "name",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "name": "password",
-                        "in": "formData",
-                        "required": true
+                        "description": "Delete Card Request",
+                        "name": "delete_card_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.DeleteCardRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.SignupResponse"
+                            "$ref": "#/definitions/response.SuccessResponse"
                         }
                     },
                     "400": {
@@ -882,12 +945,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -897,44 +954,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/signup-get-all": {
-            "post": {
-                "description": "Sign Up And Get All Data",
+        "/api/card/review": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Grade reviewed cards of a deck and earn the XP computed on the server. Only the cards the deck serves today, due and within its daily new and review limits, can be reviewed",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "mobile"
+                    "card"
                 ],
-                "summary": "Sign Up And Get All Data",
+                "summary": "Update Review Cards",
                 "parameters": [
                     {
-                        "type": "string",
-                        "name": "email",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "name": "name",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "name": "password",
-                        "in": "formData",
-                        "required": true
+                        "description": "Update Review Cards Request",
+                        "name": "update_review_cards_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateReviewCardsRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.LoginGetAllDataResponse"
+                            "$ref": "#/definitions/response.UpdateReviewCardsResponse"
                         }
                     },
                     "400": {
@@ -943,18 +996,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -964,14 +1005,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/user/update": {
+        "/api/card/update": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update User Details",
+                "description": "Update Card Details. Set clear_location or clear_region to remove the location or the region",
                 "consumes": [
                     "application/json"
                 ],
@@ -979,17 +1020,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "card"
                 ],
-                "summary": "Update User Details",
+                "summary": "Update Card Details",
                 "parameters": [
                     {
-                        "description": "Update User Request",
-                        "name": "update_user_request",
+                        "description": "Update Card Request",
+                        "name": "update_card_request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdateUserRequest"
+                            "$ref": "#/definitions/request.UpdateCardRequest"
                         }
                     }
                 ],
@@ -997,7 +1038,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.UpdateUserResponse"
+                            "$ref": "#/definitions/response.UpdateCardResponse"
                         }
                     },
                     "400": {
@@ -1014,38 +1055,5679 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "entity.Card": {
-            "type": "object",
-            "properties": {
-                "answer": {
-                    "type": "string"
-                },
-                "card_type": {
-                    "type": "integer"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "deck_id": {
+        },
+        "/api/comment/create": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Comment on a deck or one of its cards, or reply to another comment by setting parent_id. The deck must be public, owned by the user or shared with them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "Create Comment",
+                "parameters": [
+                    {
+                        "description": "Create Comment Request",
+                        "name": "create_comment_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comment/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a comment. The author, the deck owner and admins can delete. Replies are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "Delete Comment",
+                "parameters": [
+                    {
+                        "description": "Delete Comment Request",
+                        "name": "delete_comment_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.DeleteCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comment/list": {
+            "get": {
+                "description": "Get comments of a deck, oldest first. Without card_id only deck level comments are returned, without parent_id only top level comments",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "Get Comments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "card_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "deck_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "parent_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetCommentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comment/update": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Edit the content of a comment. Only the author can edit",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "Update Comment",
+                "parameters": [
+                    {
+                        "description": "Update Comment Request",
+                        "name": "update_comment_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deck/catalog": {
+            "get": {
+                "description": "Get a page of public deck summaries (without cards), sorted and filtered",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deck"
+                ],
+                "summary": "Get Public Deck Catalog",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "max_cards",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "min_cards",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "views",
+                            "rating",
+                            "newest",
+                            "copies"
+                        ],
+                        "type": "string",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.DeckCatalogResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deck/copy": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Copy Deck",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deck"
+                ],
+                "summary": "Copy Deck",
+                "parameters": [
+                    {
+                        "description": "Copy Deck Request",
+                        "name": "copy_deck_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CopyDeckRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CopyDeckResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deck/create": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create New Deck",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deck"
+                ],
+                "summary": "Create New Deck",
+                "parameters": [
+                    {
+                        "description": "Create Deck Request",
+                        "name": "create_deck_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateDeckRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CreateDeckResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deck/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete Deck",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deck"
+                ],
+                "summary": "Delete Deck",
+                "parameters": [
+                    {
+                        "description": "Delete Deck Request",
+                        "name": "delete_deck_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.DeleteDeckRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.DeleteDeckResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deck/member/invite": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Give a user, found by user_id or email, the editor or viewer role on a deck. Inviting an existing member changes their role. Only the owner can invite",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deck"
+                ],
+                "summary": "Invite Deck Member",
+                "parameters": [
+                    {
+                        "description": "Invite Member Request",
+                        "name": "invite_member_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.InviteMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.DeckMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deck/member/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the owner and members of a deck with their roles",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deck"
+                ],
+                "summary": "Get Deck Members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deck ID",
+                        "name": "deck_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetMembersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deck/member/remove": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a member from a deck along with their review progress. The owner can remove anyone, members can remove themselves",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deck"
+                ],
+                "summary": "Remove Deck Member",
+                "parameters": [
+                    {
+                        "description": "Remove Member Request",
+                        "name": "remove_member_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RemoveMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deck/rate": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Rate a public deck from 1 to 5. Rating again replaces the previous rating",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deck"
+                ],
+                "summary": "Rate Deck",
+                "parameters": [
+                    {
+                        "description": "Rate Deck Request",
+                        "name": "rate_deck_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RateDeckRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.DeckRatingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deck/rating": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the aggregated rating of a deck and the logged in user's own rating (0 if not rated)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deck"
+                ],
+                "summary": "Get Deck Rating",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deck ID",
+                        "name": "deck_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.DeckRatingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deck/review-cards": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get Deck With Review Cards Of Logged In User",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deck"
+                ],
+                "summary": "Get Deck With Review Cards Of Logged In User",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.DeckWithReviewCards"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deck/share/copy": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Copy a deck, even a private one, through a share link",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deck"
+                ],
+                "summary": "Copy Shared Deck",
+                "parameters": [
+                    {
+                        "description": "Open Share Link Request",
+                        "name": "open_share_link_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.OpenShareLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CopyDeckResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deck/share/create": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create an unguessable link to preview and copy a deck, optionally expiring and protected by a password. Only the owner can share",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deck"
+                ],
+                "summary": "Create Share Link",
+                "parameters": [
+                    {
+                        "description": "Create Share Link Request",
+                        "name": "create_share_link_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateShareLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ShareLinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deck/share/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the share links of a deck, including expired and revoked ones. Only the owner can list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deck"
+                ],
+                "summary": "Get Share Links",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deck ID",
+                        "name": "deck_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetShareLinksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deck/share/preview": {
+            "post": {
+                "description": "Get a deck and its cards through a share link. Too many wrong passwords or unknown tokens lock the link and the client IP out for a while",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deck"
+                ],
+                "summary": "Preview Shared Deck",
+                "parameters": [
+                    {
+                        "description": "Open Share Link Request",
+                        "name": "open_share_link_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.OpenShareLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SharedDeckResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deck/share/revoke": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revoke a share link so it can no longer be used. Only the owner can revoke",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deck"
+                ],
+                "summary": "Revoke Share Link",
+                "parameters": [
+                    {
+                        "description": "Revoke Share Link Request",
+                        "name": "revoke_share_link_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RevokeShareLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deck/shared": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the decks shared with the logged in user and their review cards, using the user's own review progress",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deck"
+                ],
+                "summary": "Get Shared Decks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetSharedDecksResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deck/update": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update Deck Details. Set clear_location or clear_region to remove the location or the region",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deck"
+                ],
+                "summary": "Update Deck Details",
+                "parameters": [
+                    {
+                        "description": "Update Deck Request",
+                        "name": "update_deck_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateDeckRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UpdateDeckResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deck/view": {
+            "post": {
+                "description": "Record a view of a public deck. Views are counted once per user, or per anonymous IP and user agent, per time window",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deck"
+                ],
+                "summary": "View Deck",
+                "parameters": [
+                    {
+                        "description": "View Deck Request",
+                        "name": "view_deck_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ViewDeckRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ViewDeckResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deck/view-trend": {
+            "get": {
+                "description": "Get daily view counts of a deck for the last days (default 30)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deck"
+                ],
+                "summary": "Get Deck View Trend",
+                "parameters": [
+                    {
+                        "maximum": 365,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "deck_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.DeckViewTrendResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/export/create": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Start an asynchronous export of the logged in user's data as a zip archive",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Create Account Data Export",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ExportJobResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/export/download": {
+            "get": {
+                "description": "Download a ready export archive using the link returned by the export status",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Download Account Data Export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Download Token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/export/status": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the status of an export job and its download link once ready",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Get Account Data Export Status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export Job ID",
+                        "name": "job_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ExportJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/export/stream": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stream the logged in user's data as NDJSON, one {\"type\", \"data\"} object per line",
+                "produces": [
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Stream Account Data Export",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/fact": {
+            "get": {
+                "description": "Get a random fun fact of the locale, or the fact of the day, which is the same for everyone all day. Logged in users can ask for a fact they have not seen in the last 30 days",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fact"
+                ],
+                "summary": "Get Fact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "random (default), daily or unseen. unseen requires logging in",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale, vi by default",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category",
+                        "name": "category",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetFactResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/accept": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Accept the friend request sent by user_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Accept Friend Request",
+                "parameters": [
+                    {
+                        "description": "Friend User Request",
+                        "name": "friend_user_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.FriendUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.FriendshipResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/activity": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get each friend's streak, XP this week and decks studied today. Friends who hide their activity are left out",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Get Friends Activity",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.FriendsActivityResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/decline": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Decline the friend request sent by user_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Decline Friend Request",
+                "parameters": [
+                    {
+                        "description": "Friend User Request",
+                        "name": "friend_user_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.FriendUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get friends and pending friend requests of the logged in user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Get Friends",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetFriendsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/remove": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a friend, or cancel a friend request you sent",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Remove Friend",
+                "parameters": [
+                    {
+                        "description": "Friend User Request",
+                        "name": "friend_user_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.FriendUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/request": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Send a friend request to a user. If they already sent one to you, it is accepted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Send Friend Request",
+                "parameters": [
+                    {
+                        "description": "Friend User Request",
+                        "name": "friend_user_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.FriendUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.FriendshipResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/get-all": {
+            "post": {
+                "description": "Get the user's own decks and cards. Public decks of other users are listed by /api/deck/catalog",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mobile"
+                ],
+                "summary": "Get All Data",
+                "parameters": [
+                    {
+                        "description": "Refresh Token Request",
+                        "name": "refresh_token_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetAllDataResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/leaderboard": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get this week's XP leaderboard of the logged in user's league (default), of everyone, or of their friends who don't hide their activity",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboard"
+                ],
+                "summary": "Get Leaderboard",
+                "parameters": [
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "global",
+                            "friends",
+                            "league"
+                        ],
+                        "type": "string",
+                        "name": "scope",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.LeaderboardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/login": {
+            "post": {
+                "description": "Log In",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Log In",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "email",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/login-get-all": {
+            "post": {
+                "description": "Log in and get the user's own decks and cards. Public decks of other users are listed by /api/deck/catalog",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mobile"
+                ],
+                "summary": "Log In And Get All Data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "email",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.LoginGetAllDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/logout": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revoke the session of the access token. Its refresh token and access tokens stop working",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Log Out",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/map/bbox": {
+            "get": {
+                "description": "Get geolocated public decks and cards inside the visible map area",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "map"
+                ],
+                "summary": "Get Places In Bounding Box",
+                "parameters": [
+                    {
+                        "enum": [
+                            "deck",
+                            "card"
+                        ],
+                        "type": "string",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 90,
+                        "minimum": -90,
+                        "type": "number",
+                        "name": "max_lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 180,
+                        "minimum": -180,
+                        "type": "number",
+                        "name": "max_lng",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 90,
+                        "minimum": -90,
+                        "type": "number",
+                        "name": "min_lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 180,
+                        "minimum": -180,
+                        "type": "number",
+                        "name": "min_lng",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PlacesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/map/near": {
+            "get": {
+                "description": "Get geolocated public decks and cards within radius_km (default 10) of a point, nearest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "map"
+                ],
+                "summary": "Get Nearby Places",
+                "parameters": [
+                    {
+                        "enum": [
+                            "deck",
+                            "card"
+                        ],
+                        "type": "string",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 90,
+                        "minimum": -90,
+                        "type": "number",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 180,
+                        "minimum": -180,
+                        "type": "number",
+                        "name": "lng",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 500,
+                        "type": "number",
+                        "name": "radius_km",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PlacesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/map/province": {
+            "get": {
+                "description": "Get geolocated public decks and cards of a province. Matching ignores diacritics and the \"Tỉnh\"/\"Thành phố\" prefix",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "map"
+                ],
+                "summary": "Get Places By Province",
+                "parameters": [
+                    {
+                        "enum": [
+                            "deck",
+                            "card"
+                        ],
+                        "type": "string",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "province",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PlacesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/quest/claim": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Claim the XP and coins of a completed quest. A reward is only paid once, and a claim that failed can be retried",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quest"
+                ],
+                "summary": "Claim Quest",
+                "parameters": [
+                    {
+                        "description": "Claim Quest Request",
+                        "name": "claim_quest_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ClaimQuestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ClaimQuestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/quest/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the logged in user's quests of today and of this week. Quests rotate every day and week",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quest"
+                ],
+                "summary": "Get Quests",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetQuestsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/refresh": {
+            "post": {
+                "description": "Refresh Token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Refresh Token",
+                "parameters": [
+                    {
+                        "description": "Refresh Token Request",
+                        "name": "refresh_token_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.RefreshTokenResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/report": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Report a deck, card or comment to the moderators. Content reported by enough distinct users is hidden automatically",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Report Content",
+                "parameters": [
+                    {
+                        "description": "Report Content Request",
+                        "name": "report_content_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ReportContentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Full-text search over deck names/descriptions and card questions/answers, ignoring Vietnamese diacritics",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search Decks And Cards",
+                "parameters": [
+                    {
+                        "maximum": 50,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "mine",
+                            "public"
+                        ],
+                        "type": "string",
+                        "name": "scope",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "all",
+                            "decks",
+                            "cards"
+                        ],
+                        "type": "string",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/shop/items": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the items for sale, cheapest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shop"
+                ],
+                "summary": "Get Shop Items",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetShopItemsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/shop/purchase": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Buy a shop item with coins. Retrying with the same idempotency key returns the first purchase without charging again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shop"
+                ],
+                "summary": "Purchase Item",
+                "parameters": [
+                    {
+                        "description": "Purchase Item Request",
+                        "name": "purchase_item_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PurchaseItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PurchaseItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/signup": {
+            "post": {
+                "description": "Sign Up",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Sign Up",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "email",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SignupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/signup-get-all": {
+            "post": {
+                "description": "Sign up and get the user's own decks and cards. Public decks of other users are listed by /api/deck/catalog",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mobile"
+                ],
+                "summary": "Sign Up And Get All Data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "email",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.LoginGetAllDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/achievements": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get every achievement with the logged in user's progress toward it and the badges they unlocked",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get Achievements",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetAchievementsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/activity": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the logged in user's reviews, new cards and XP per day for a heatmap, with the current streak, longest streak and number of days ever studied. Days are local to the time zone",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get Activity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First day, YYYY-MM-DD. A year before the last day by default",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day, YYYY-MM-DD. Today by default",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone, Asia/Ho_Chi_Minh by default",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetActivityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/feed": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get public decks of followed authors, most recently published or updated first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get Following Feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.FeedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/follow": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Follow an author to see their new and updated public decks in the feed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Follow User",
+                "parameters": [
+                    {
+                        "description": "Follow User Request",
+                        "name": "follow_user_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.FollowUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.FollowUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/goal": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the logged in user's daily goal, today's progress toward it and how often it was met in the last days. Today only counts in the hit rate once the goal is hit. The goal is changed with the user update API",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get Daily Goal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of days of history, 30 by default",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetDailyGoalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/profile": {
+            "get": {
+                "description": "Get the public profile of a user with their latest published decks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get Author Profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.AuthorProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the logged in user's active sessions with their device, most recently seen first. The session of the access token is marked as current",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get Sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetSessionsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/sessions/revoke": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sign out one of the logged in user's sessions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Revoke Session",
+                "parameters": [
+                    {
+                        "description": "Revoke Session Request",
+                        "name": "revoke_session_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RevokeSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/sessions/revoke-all": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sign out all of the logged in user's other sessions, and the current one too if include_current is set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Revoke All Sessions",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Also sign out the current session",
+                        "name": "include_current",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.RevokeSessionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/streak": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the logged in user's streak, streak freezes, the streak that can still be repaired and which of the last days were studied, frozen, missed or repaired",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get Streak",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of days of history, 30 by default",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetStreakResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/streak/repair": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Restore the streak lost on the last missed days. Only available for a limited time after the streak was lost and once in a while",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Repair Streak",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.RepairStreakResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/unfollow": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stop following an author",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Unfollow User",
+                "parameters": [
+                    {
+                        "description": "Unfollow User Request",
+                        "name": "unfollow_user_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.FollowUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.FollowUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/update": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update User Details. Changing the password signs out every other session",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update User Details",
+                "parameters": [
+                    {
+                        "description": "Update User Request",
+                        "name": "update_user_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UpdateUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/xp/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the logged in user's lifetime XP, level and ledger of earned XP, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get XP History",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetXPHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/wallet": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the logged in user's coin balance, owned items and ledger of earned and spent coins, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Get Wallet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetWalletResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entity.AchievementProgress": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "progress": {
+                    "type": "integer"
+                },
+                "threshold": {
+                    "type": "integer"
+                },
+                "unlocked": {
+                    "type": "boolean"
+                },
+                "unlocked_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ActivityCalendar": {
+            "type": "object",
+            "properties": {
+                "current_streak": {
+                    "type": "integer"
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ActivityDay"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "longest_streak": {
+                    "type": "integer"
+                },
+                "time_zone": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total_active_days": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.ActivityDay": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "string"
+                },
+                "new_cards": {
+                    "type": "integer"
+                },
+                "reviews": {
+                    "type": "integer"
+                },
+                "xp": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.AuthorProfile": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "bio": {
+                    "type": "string"
+                },
+                "decks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.DeckSummary"
+                    }
+                },
+                "follower_count": {
+                    "type": "integer"
+                },
+                "following_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_following": {
+                    "type": "boolean"
+                },
+                "level": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "published_decks": {
+                    "type": "integer"
+                },
+                "total_copies": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.Card": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string"
+                },
+                "card_type": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deck_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "is_hidden": {
+                    "type": "boolean"
+                },
+                "last_review": {
+                    "type": "string"
+                },
+                "location": {
+                    "$ref": "#/definitions/entity.GeoPoint"
+                },
+                "next_review": {
+                    "type": "string"
+                },
+                "num_reviews": {
+                    "type": "integer"
+                },
+                "question": {
+                    "type": "string"
+                },
+                "question_img_label": {
+                    "type": "string"
+                },
+                "question_img_url": {
+                    "type": "string"
+                },
+                "region": {
+                    "$ref": "#/definitions/entity.Region"
+                },
+                "sm2_ef": {
+                    "type": "number"
+                },
+                "sm2_i": {
+                    "type": "integer"
+                },
+                "sm2_n": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "wrong_answers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "entity.CardSearchHit": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string"
+                },
+                "card_type": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deck_id": {
+                    "type": "string"
+                },
+                "deck_name": {
+                    "type": "string"
+                },
+                "highlights": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.SearchHighlight"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "is_hidden": {
+                    "type": "boolean"
+                },
+                "last_review": {
+                    "type": "string"
+                },
+                "location": {
+                    "$ref": "#/definitions/entity.GeoPoint"
+                },
+                "next_review": {
+                    "type": "string"
+                },
+                "num_reviews": {
+                    "type": "integer"
+                },
+                "question": {
+                    "type": "string"
+                },
+                "question_img_label": {
+                    "type": "string"
+                },
+                "question_img_url": {
+                    "type": "string"
+                },
+                "region": {
+                    "$ref": "#/definitions/entity.Region"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "sm2_ef": {
+                    "type": "number"
+                },
+                "sm2_i": {
+                    "type": "integer"
+                },
+                "sm2_n": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "wrong_answers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "entity.Comment": {
+            "type": "object",
+            "properties": {
+                "card_id": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deck_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_deleted": {
+                    "type": "boolean"
+                },
+                "is_edited": {
+                    "type": "boolean"
+                },
+                "is_hidden": {
+                    "type": "boolean"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "reply_count": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "user_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.DailyGoal": {
+            "type": "object",
+            "properties": {
+                "streak_requires_goal": {
+                    "description": "StreakRequiresGoal only counts the days where the goal was met in the\nstreak, instead of any day with a review.",
+                    "type": "boolean"
+                },
+                "target": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.DailyGoalStats": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "integer"
+                },
+                "days_hit": {
+                    "type": "integer"
+                },
+                "goal": {
+                    "$ref": "#/definitions/entity.DailyGoal"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.DailyProgress"
+                    }
+                },
+                "hit_rate": {
+                    "type": "number"
+                },
+                "today": {
+                    "$ref": "#/definitions/entity.DailyProgress"
+                }
+            }
+        },
+        "entity.DailyProgress": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "boolean"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "day": {
+                    "type": "string"
+                },
+                "goal": {
+                    "$ref": "#/definitions/entity.DailyGoal"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reviews": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "xp": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.Deck": {
+            "type": "object",
+            "properties": {
+                "bayesian_rating": {
+                    "type": "number"
+                },
+                "comment_count": {
+                    "type": "integer"
+                },
+                "copies": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "cur_new_cards": {
+                    "type": "integer"
+                },
+                "cur_review_cards": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "description_img_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_favorite": {
+                    "type": "boolean"
+                },
+                "is_hidden": {
+                    "type": "boolean"
+                },
+                "is_public": {
+                    "type": "boolean"
+                },
+                "last_review": {
+                    "type": "string"
+                },
+                "location": {
+                    "$ref": "#/definitions/entity.GeoPoint"
+                },
+                "max_new_cards": {
+                    "type": "integer"
+                },
+                "max_review_cards": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "rating_count": {
+                    "type": "integer"
+                },
+                "region": {
+                    "$ref": "#/definitions/entity.Region"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total_cards": {
+                    "type": "integer"
+                },
+                "total_learned_cards": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "views": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.DeckMember": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deck_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "invited_by": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.DeckMemberInfo": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.DeckSearchHit": {
+            "type": "object",
+            "properties": {
+                "bayesian_rating": {
+                    "type": "number"
+                },
+                "comment_count": {
+                    "type": "integer"
+                },
+                "copies": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "description_img_url": {
+                    "type": "string"
+                },
+                "highlights": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.SearchHighlight"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location": {
+                    "$ref": "#/definitions/entity.GeoPoint"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "rating_count": {
+                    "type": "integer"
+                },
+                "region": {
+                    "$ref": "#/definitions/entity.Region"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total_cards": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "views": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.DeckSummary": {
+            "type": "object",
+            "properties": {
+                "bayesian_rating": {
+                    "type": "number"
+                },
+                "comment_count": {
+                    "type": "integer"
+                },
+                "copies": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "description_img_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location": {
+                    "$ref": "#/definitions/entity.GeoPoint"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "rating_count": {
+                    "type": "integer"
+                },
+                "region": {
+                    "$ref": "#/definitions/entity.Region"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total_cards": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "views": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.DeckViewDaily": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "string"
+                },
+                "deck_id": {
+                    "type": "string"
+                },
+                "views": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.DeckWithCards": {
+            "type": "object",
+            "properties": {
+                "bayesian_rating": {
+                    "type": "number"
+                },
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Card"
+                    }
+                },
+                "comment_count": {
+                    "type": "integer"
+                },
+                "copies": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "cur_new_cards": {
+                    "type": "integer"
+                },
+                "cur_review_cards": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "description_img_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_favorite": {
+                    "type": "boolean"
+                },
+                "is_hidden": {
+                    "type": "boolean"
+                },
+                "is_public": {
+                    "type": "boolean"
+                },
+                "last_review": {
+                    "type": "string"
+                },
+                "location": {
+                    "$ref": "#/definitions/entity.GeoPoint"
+                },
+                "max_new_cards": {
+                    "type": "integer"
+                },
+                "max_review_cards": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "num_blue_cards": {
+                    "type": "integer"
+                },
+                "num_green_cards": {
+                    "type": "integer"
+                },
+                "num_red_cards": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "rating_count": {
+                    "type": "integer"
+                },
+                "region": {
+                    "$ref": "#/definitions/entity.Region"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total_cards": {
+                    "type": "integer"
+                },
+                "total_learned_cards": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "views": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.DeckWithReviewCards": {
+            "type": "object",
+            "properties": {
+                "bayesian_rating": {
+                    "type": "number"
+                },
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Card"
+                    }
+                },
+                "comment_count": {
+                    "type": "integer"
+                },
+                "copies": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "cur_new_cards": {
+                    "type": "integer"
+                },
+                "cur_review_cards": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "description_img_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_favorite": {
+                    "type": "boolean"
+                },
+                "is_hidden": {
+                    "type": "boolean"
+                },
+                "is_public": {
+                    "type": "boolean"
+                },
+                "last_review": {
+                    "type": "string"
+                },
+                "location": {
+                    "$ref": "#/definitions/entity.GeoPoint"
+                },
+                "max_new_cards": {
+                    "type": "integer"
+                },
+                "max_review_cards": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "num_blue_cards": {
+                    "type": "integer"
+                },
+                "num_green_cards": {
+                    "type": "integer"
+                },
+                "num_red_cards": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "rating_count": {
+                    "type": "integer"
+                },
+                "region": {
+                    "$ref": "#/definitions/entity.Region"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total_cards": {
+                    "type": "integer"
+                },
+                "total_learned_cards": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "views": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.ExportJob": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Fact": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deck_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "source_url": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Friend": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "since": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.FriendActivity": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "decks_studied_today": {
+                    "type": "integer"
+                },
+                "level": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "streak": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "weekly_xp": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.Friendship": {
+            "type": "object",
+            "properties": {
+                "addressee_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "requester_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.GeoPoint": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Leaderboard": {
+            "type": "object",
+            "properties": {
+                "demote_count": {
+                    "type": "integer"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.LeaderboardEntry"
+                    }
+                },
+                "me": {
+                    "$ref": "#/definitions/entity.LeaderboardEntry"
+                },
+                "promote_count": {
+                    "type": "integer"
+                },
+                "scope": {
+                    "type": "string"
+                },
+                "tier": {
+                    "type": "integer"
+                },
+                "tier_name": {
+                    "type": "string"
+                },
+                "week": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.LeaderboardEntry": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "xp": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.LedgerEntry": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "idempotency_key": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "ref_id": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.MapPlace": {
+            "type": "object",
+            "properties": {
+                "deck_id": {
+                    "type": "string"
+                },
+                "deck_name": {
+                    "type": "string"
+                },
+                "distance_m": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "location": {
+                    "$ref": "#/definitions/entity.GeoPoint"
+                },
+                "region": {
+                    "$ref": "#/definitions/entity.Region"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ModerationAction": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "moderator_id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "report_count": {
+                    "type": "integer"
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "target_owner_id": {
+                    "type": "string"
+                },
+                "target_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ModerationQueueItem": {
+            "type": "object",
+            "properties": {
+                "first_reported_at": {
+                    "type": "string"
+                },
+                "last_reported_at": {
+                    "type": "string"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "report_count": {
+                    "type": "integer"
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "target_owner_id": {
+                    "type": "string"
+                },
+                "target_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.PrivacySettings": {
+            "type": "object",
+            "properties": {
+                "block_friend_requests": {
+                    "type": "boolean"
+                },
+                "hide_activity": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "entity.Purchase": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "fail_reason": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "idempotency_key": {
+                    "type": "string"
+                },
+                "item_id": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Region": {
+            "type": "object",
+            "properties": {
+                "district": {
+                    "type": "string"
+                },
+                "province": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ReminderSettings": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "last_sent_at": {
+                    "type": "string"
+                },
+                "push_token": {
+                    "type": "string"
+                },
+                "quiet_end": {
+                    "type": "string"
+                },
+                "quiet_start": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "time_zone": {
+                    "type": "string"
+                },
+                "weekdays": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "entity.Report": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reporter_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "target_owner_id": {
+                    "type": "string"
+                },
+                "target_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ReviewLog": {
+            "type": "object",
+            "properties": {
+                "awarded_xp": {
+                    "type": "integer"
+                },
+                "breakdown": {
+                    "$ref": "#/definitions/entity.XPBreakdown"
+                },
+                "claimed_xp": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deck_id": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "flags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_flagged": {
+                    "type": "boolean"
+                },
+                "num_cards": {
+                    "type": "integer"
+                },
+                "num_correct": {
+                    "type": "integer"
+                },
+                "num_new": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.SearchHighlight": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "ranges": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "snippet": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Session": {
+            "type": "object",
+            "properties": {
+                "app_version": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "is_current": {
+                    "type": "boolean"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "revoke_reason": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ShareLink": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deck_id": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "has_password": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ShopItem": {
+            "type": "object",
+            "properties": {
+                "boost_minutes": {
+                    "type": "integer"
+                },
+                "boost_percent": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.StreakDay": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.StreakInfo": {
+            "type": "object",
+            "properties": {
+                "can_repair": {
+                    "type": "boolean"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.StreakDay"
+                    }
+                },
+                "max_streak_freezes": {
+                    "type": "integer"
+                },
+                "streak": {
+                    "type": "integer"
+                },
+                "streak_freezes": {
+                    "type": "integer"
+                },
+                "streak_repair": {
+                    "$ref": "#/definitions/entity.StreakRepair"
+                }
+            }
+        },
+        "entity.StreakRepair": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "missed_from": {
+                    "type": "string"
+                },
+                "missed_to": {
+                    "type": "string"
+                },
+                "streak": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.User": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "bio": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "daily_goal": {
+                    "$ref": "#/definitions/entity.DailyGoal"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "follower_count": {
+                    "type": "integer"
+                },
+                "following_count": {
+                    "type": "integer"
+                },
+                "hashed_password": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_admin": {
+                    "type": "boolean"
+                },
+                "is_banned": {
+                    "type": "boolean"
+                },
+                "last_streak": {
+                    "type": "string"
+                },
+                "last_streak_repair": {
+                    "type": "string"
+                },
+                "league_tier": {
+                    "type": "integer"
+                },
+                "level": {
+                    "type": "integer"
+                },
+                "lifetime_xp": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "privacy": {
+                    "$ref": "#/definitions/entity.PrivacySettings"
+                },
+                "reminder": {
+                    "$ref": "#/definitions/entity.ReminderSettings"
+                },
+                "streak": {
+                    "type": "integer"
+                },
+                "streak_freezes": {
+                    "type": "integer"
+                },
+                "streak_repair": {
+                    "$ref": "#/definitions/entity.StreakRepair"
+                },
+                "warning_count": {
+                    "type": "integer"
+                },
+                "xp": {
+                    "type": "integer"
+                },
+                "xp_boost": {
+                    "$ref": "#/definitions/entity.XPBoost"
+                },
+                "xp_to_level_up": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.UserItem": {
+            "type": "object",
+            "properties": {
+                "acquired_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "item_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.UserQuest": {
+            "type": "object",
+            "properties": {
+                "claimed_at": {
+                    "type": "string"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "period": {
+                    "type": "string"
+                },
+                "period_key": {
+                    "type": "string"
+                },
+                "progress": {
+                    "type": "integer"
+                },
+                "reward_coins": {
+                    "type": "integer"
+                },
+                "reward_xp": {
+                    "type": "integer"
+                },
+                "target": {
+                    "type": "integer"
+                },
+                "template_key": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "topic": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Wallet": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.XPBoost": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "percent": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.XPBreakdown": {
+            "type": "object",
+            "properties": {
+                "base_xp": {
+                    "type": "integer"
+                },
+                "boost_xp": {
+                    "type": "integer"
+                },
+                "first_learn_xp": {
+                    "type": "integer"
+                },
+                "perfect_bonus_xp": {
+                    "type": "integer"
+                },
+                "streak_bonus_xp": {
+                    "type": "integer"
+                },
+                "total_xp": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.XPEntry": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ref_id": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.ClaimQuestRequest": {
+            "type": "object",
+            "required": [
+                "quest_id"
+            ],
+            "properties": {
+                "quest_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CopyCardToDeckRequest": {
+            "type": "object",
+            "required": [
+                "card_id",
+                "deck_id"
+            ],
+            "properties": {
+                "card_id": {
+                    "type": "string"
+                },
+                "deck_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CopyDeckRequest": {
+            "type": "object",
+            "required": [
+                "deck_id"
+            ],
+            "properties": {
+                "deck_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateCardRequest": {
+            "type": "object",
+            "required": [
+                "answer",
+                "deck_id",
+                "question",
+                "wrong_answers"
+            ],
+            "properties": {
+                "answer": {
+                    "type": "string"
+                },
+                "deck_id": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "location": {
+                    "$ref": "#/definitions/entity.GeoPoint"
+                },
+                "question": {
+                    "type": "string"
+                },
+                "question_img_label": {
+                    "type": "string"
+                },
+                "question_img_url": {
+                    "type": "string"
+                },
+                "region": {
+                    "$ref": "#/definitions/entity.Region"
+                },
+                "wrong_answers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "request.CreateCommentRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "deck_id"
+            ],
+            "properties": {
+                "card_id": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "deck_id": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateDeckRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "description_img_url": {
+                    "type": "string"
+                },
+                "location": {
+                    "$ref": "#/definitions/entity.GeoPoint"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "region": {
+                    "$ref": "#/definitions/entity.Region"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "request.CreateFactRequest": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "content": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "deck_id": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "source_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateShareLinkRequest": {
+            "type": "object",
+            "required": [
+                "deck_id"
+            ],
+            "properties": {
+                "deck_id": {
+                    "type": "string"
+                },
+                "expires_in_hour": {
+                    "type": "integer",
+                    "maximum": 8760,
+                    "minimum": 1
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                }
+            }
+        },
+        "request.CreateShopItemRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "price",
+                "type"
+            ],
+            "properties": {
+                "boost_minutes": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "boost_percent": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "streak_freeze",
+                        "xp_boost",
+                        "cosmetic"
+                    ]
+                }
+            }
+        },
+        "request.DeleteCardRequest": {
+            "type": "object",
+            "required": [
+                "card_id"
+            ],
+            "properties": {
+                "card_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.DeleteCommentRequest": {
+            "type": "object",
+            "required": [
+                "comment_id"
+            ],
+            "properties": {
+                "comment_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.DeleteDeckRequest": {
+            "type": "object",
+            "required": [
+                "deck_id"
+            ],
+            "properties": {
+                "deck_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.DeleteFactRequest": {
+            "type": "object",
+            "required": [
+                "fact_id"
+            ],
+            "properties": {
+                "fact_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.FollowUserRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.FriendUserRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.GrantCoinsRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "idempotency_key",
+                "user_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "idempotency_key": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.InviteMemberRequest": {
+            "type": "object",
+            "required": [
+                "deck_id",
+                "role"
+            ],
+            "properties": {
+                "deck_id": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "editor",
+                        "viewer"
+                    ]
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.ModerateContentRequest": {
+            "type": "object",
+            "required": [
+                "action",
+                "target_id",
+                "target_type"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "hide",
+                        "restore",
+                        "unpublish",
+                        "warn",
+                        "ban",
+                        "unban",
+                        "dismiss"
+                    ]
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "target_type": {
+                    "type": "string",
+                    "enum": [
+                        "deck",
+                        "card",
+                        "comment"
+                    ]
+                }
+            }
+        },
+        "request.OpenShareLinkRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.PurchaseItemRequest": {
+            "type": "object",
+            "required": [
+                "idempotency_key",
+                "item_id"
+            ],
+            "properties": {
+                "idempotency_key": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "item_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.RateDeckRequest": {
+            "type": "object",
+            "required": [
+                "deck_id",
+                "rating"
+            ],
+            "properties": {
+                "deck_id": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                }
+            }
+        },
+        "request.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.RemoveMemberRequest": {
+            "type": "object",
+            "required": [
+                "deck_id",
+                "user_id"
+            ],
+            "properties": {
+                "deck_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.ReportContentRequest": {
+            "type": "object",
+            "required": [
+                "reason",
+                "target_id",
+                "target_type"
+            ],
+            "properties": {
+                "details": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "spam",
+                        "inappropriate",
+                        "copyright",
+                        "misinformation",
+                        "other"
+                    ]
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "target_type": {
+                    "type": "string",
+                    "enum": [
+                        "deck",
+                        "card",
+                        "comment"
+                    ]
+                }
+            }
+        },
+        "request.RevokeSessionRequest": {
+            "type": "object",
+            "required": [
+                "session_id"
+            ],
+            "properties": {
+                "session_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.RevokeShareLinkRequest": {
+            "type": "object",
+            "required": [
+                "share_link_id"
+            ],
+            "properties": {
+                "share_link_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.UpdateCardRequest": {
+            "type": "object",
+            "required": [
+                "card_id"
+            ],
+            "properties": {
+                "answer": {
                     "type": "string"
                 },
-                "id": {
+                "card_id": {
                     "type": "string"
                 },
-                "index": {
-                    "type": "integer"
+                "clear_location": {
+                    "type": "boolean"
                 },
-                "last_review": {
-                    "type": "string"
+                "clear_region": {
+                    "type": "boolean"
                 },
-                "next_review": {
+                "deck_id": {
                     "type": "string"
                 },
-                "num_reviews": {
-                    "type": "integer"
+                "location": {
+                    "$ref": "#/definitions/entity.GeoPoint"
                 },
                 "question": {
                     "type": "string"
@@ -1056,17 +6738,8 @@ const docTemplate = `{
                 "question_img_url": {
                     "type": "string"
                 },
-                "sm2_ef": {
-                    "type": "number"
-                },
-                "sm2_i": {
-                    "type": "integer"
-                },
-                "sm2_n": {
-                    "type": "integer"
-                },
-                "user_id": {
-                    "type": "string"
+                "region": {
+                    "$ref": "#/definitions/entity.Region"
                 },
                 "wrong_answers": {
                     "type": "array",
@@ -1076,25 +6749,47 @@ const docTemplate = `{
                 }
             }
         },
-        "entity.Deck": {
+        "request.UpdateCommentRequest": {
             "type": "object",
+            "required": [
+                "comment_id",
+                "content"
+            ],
             "properties": {
-                "created_at": {
+                "comment_id": {
                     "type": "string"
                 },
+                "content": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "request.UpdateDeckRequest": {
+            "type": "object",
+            "required": [
+                "deck_id"
+            ],
+            "properties": {
+                "clear_location": {
+                    "type": "boolean"
+                },
+                "clear_region": {
+                    "type": "boolean"
+                },
                 "cur_new_cards": {
                     "type": "integer"
                 },
                 "cur_review_cards": {
                     "type": "integer"
                 },
-                "description": {
+                "deck_id": {
                     "type": "string"
                 },
-                "description_img_url": {
+                "description": {
                     "type": "string"
                 },
-                "id": {
+                "description_img_url": {
                     "type": "string"
                 },
                 "is_favorite": {
@@ -1106,6 +6801,9 @@ const docTemplate = `{
                 "last_review": {
                     "type": "string"
                 },
+                "location": {
+                    "$ref": "#/definitions/entity.GeoPoint"
+                },
                 "max_new_cards": {
                     "type": "integer"
                 },
@@ -1118,580 +6816,729 @@ const docTemplate = `{
                 "position": {
                     "type": "string"
                 },
-                "rating": {
-                    "type": "number"
+                "region": {
+                    "$ref": "#/definitions/entity.Region"
                 },
-                "total_cards": {
-                    "type": "integer"
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "total_learned_cards": {
                     "type": "integer"
-                },
-                "user_id": {
-                    "type": "string"
-                },
-                "views": {
-                    "type": "integer"
                 }
             }
         },
-        "entity.DeckWithCards": {
+        "request.UpdateFactRequest": {
             "type": "object",
+            "required": [
+                "fact_id"
+            ],
             "properties": {
-                "cards": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.Card"
-                    }
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "cur_new_cards": {
-                    "type": "integer"
+                "category": {
+                    "type": "string",
+                    "maxLength": 50
                 },
-                "cur_review_cards": {
-                    "type": "integer"
+                "content": {
+                    "type": "string",
+                    "maxLength": 2000
                 },
-                "description": {
+                "deck_id": {
                     "type": "string"
                 },
-                "description_img_url": {
+                "fact_id": {
                     "type": "string"
                 },
-                "id": {
+                "locale": {
                     "type": "string"
                 },
-                "is_favorite": {
+                "remove_deck": {
+                    "description": "RemoveDeck unlinks the related deck.",
                     "type": "boolean"
                 },
-                "is_public": {
-                    "type": "boolean"
+                "source": {
+                    "type": "string",
+                    "maxLength": 500
                 },
-                "last_review": {
+                "source_url": {
                     "type": "string"
+                }
+            }
+        },
+        "request.UpdateReviewCardsRequest": {
+            "type": "object",
+            "required": [
+                "card_ids",
+                "deck_id",
+                "is_correct"
+            ],
+            "properties": {
+                "card_ids": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "max_new_cards": {
-                    "type": "integer"
+                "deck_id": {
+                    "type": "string"
                 },
-                "max_review_cards": {
-                    "type": "integer"
+                "duration_ms": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "name": {
-                    "type": "string"
+                "is_correct": {
+                    "type": "array",
+                    "items": {
+                        "type": "boolean"
+                    }
                 },
-                "num_blue_cards": {
+                "total_xp": {
                     "type": "integer"
+                }
+            }
+        },
+        "request.UpdateShopItemRequest": {
+            "type": "object",
+            "required": [
+                "item_id"
+            ],
+            "properties": {
+                "boost_minutes": {
+                    "type": "integer",
+                    "minimum": 1
                 },
-                "num_green_cards": {
-                    "type": "integer"
+                "boost_percent": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
                 },
-                "num_red_cards": {
-                    "type": "integer"
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
                 },
-                "position": {
+                "image_url": {
                     "type": "string"
                 },
-                "rating": {
-                    "type": "number"
+                "is_active": {
+                    "type": "boolean"
                 },
-                "total_cards": {
-                    "type": "integer"
+                "item_id": {
+                    "type": "string"
                 },
-                "total_learned_cards": {
-                    "type": "integer"
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "user_id": {
-                    "type": "string"
+                "price": {
+                    "type": "integer",
+                    "minimum": 1
                 },
-                "views": {
-                    "type": "integer"
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
                 }
             }
         },
-        "entity.DeckWithReviewCards": {
+        "request.UpdateUserRequest": {
             "type": "object",
             "properties": {
-                "cards": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.Card"
-                    }
-                },
-                "created_at": {
+                "avatar_url": {
                     "type": "string"
                 },
-                "cur_new_cards": {
-                    "type": "integer"
-                },
-                "cur_review_cards": {
-                    "type": "integer"
+                "bio": {
+                    "type": "string",
+                    "maxLength": 500
                 },
-                "description": {
-                    "type": "string"
+                "block_friend_requests": {
+                    "type": "boolean"
                 },
-                "description_img_url": {
-                    "type": "string"
+                "daily_goal_target": {
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 1
                 },
-                "id": {
-                    "type": "string"
+                "daily_goal_type": {
+                    "type": "string",
+                    "enum": [
+                        "xp",
+                        "reviews"
+                    ]
                 },
-                "is_favorite": {
+                "hide_activity": {
                     "type": "boolean"
                 },
-                "is_public": {
-                    "type": "boolean"
+                "name": {
+                    "type": "string"
                 },
-                "last_review": {
+                "new_password": {
                     "type": "string"
                 },
-                "max_new_cards": {
-                    "type": "integer"
+                "old_password": {
+                    "type": "string"
                 },
-                "max_review_cards": {
-                    "type": "integer"
+                "push_token": {
+                    "type": "string"
                 },
-                "name": {
+                "quiet_hours_end": {
                     "type": "string"
                 },
-                "num_blue_cards": {
-                    "type": "integer"
+                "quiet_hours_start": {
+                    "type": "string"
                 },
-                "num_green_cards": {
-                    "type": "integer"
+                "reminder_channels": {
+                    "type": "array",
+                    "maxItems": 2,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "num_red_cards": {
-                    "type": "integer"
+                "reminder_enabled": {
+                    "type": "boolean"
                 },
-                "position": {
+                "reminder_time": {
                     "type": "string"
                 },
-                "rating": {
-                    "type": "number"
+                "reminder_weekdays": {
+                    "type": "array",
+                    "maxItems": 7,
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "total_cards": {
-                    "type": "integer"
+                "streak_requires_goal": {
+                    "type": "boolean"
                 },
-                "total_learned_cards": {
-                    "type": "integer"
+                "time_zone": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.ViewDeckRequest": {
+            "type": "object",
+            "required": [
+                "deck_id"
+            ],
+            "properties": {
+                "deck_id": {
+                    "type": "string"
                 },
-                "user_id": {
+                "fingerprint": {
                     "type": "string"
+                }
+            }
+        },
+        "response.AuthorProfileResponse": {
+            "type": "object",
+            "properties": {
+                "profile": {
+                    "$ref": "#/definitions/entity.AuthorProfile"
+                }
+            }
+        },
+        "response.ClaimQuestResponse": {
+            "type": "object",
+            "properties": {
+                "quest": {
+                    "$ref": "#/definitions/entity.UserQuest"
                 },
-                "views": {
-                    "type": "integer"
+                "user": {
+                    "$ref": "#/definitions/entity.User"
+                }
+            }
+        },
+        "response.CommentResponse": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "$ref": "#/definitions/entity.Comment"
+                }
+            }
+        },
+        "response.CopyCardToDeckResponse": {
+            "type": "object",
+            "properties": {
+                "card": {
+                    "$ref": "#/definitions/entity.Card"
+                }
+            }
+        },
+        "response.CopyDeckResponse": {
+            "type": "object",
+            "properties": {
+                "deck": {
+                    "$ref": "#/definitions/entity.DeckWithCards"
+                },
+                "deck_review": {
+                    "$ref": "#/definitions/entity.DeckWithReviewCards"
                 }
             }
         },
-        "entity.User": {
+        "response.CreateCardResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "hashed_password": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                "card": {
+                    "$ref": "#/definitions/entity.Card"
+                }
+            }
+        },
+        "response.CreateDeckResponse": {
+            "type": "object",
+            "properties": {
+                "deck": {
+                    "$ref": "#/definitions/entity.Deck"
+                }
+            }
+        },
+        "response.CreateFactResponse": {
+            "type": "object",
+            "properties": {
+                "fact": {
+                    "$ref": "#/definitions/entity.Fact"
                 },
-                "is_admin": {
+                "success": {
                     "type": "boolean"
-                },
-                "last_streak": {
-                    "type": "string"
-                },
-                "level": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "streak": {
-                    "type": "integer"
-                },
-                "xp": {
-                    "type": "integer"
-                },
-                "xp_to_level_up": {
-                    "type": "integer"
                 }
             }
         },
-        "request.CopyCardToDeckRequest": {
+        "response.DeckCatalogResponse": {
             "type": "object",
-            "required": [
-                "card_id",
-                "deck_id"
-            ],
             "properties": {
-                "card_id": {
-                    "type": "string"
+                "decks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.DeckSummary"
+                    }
                 },
-                "deck_id": {
+                "next_cursor": {
                     "type": "string"
                 }
             }
         },
-        "request.CopyDeckRequest": {
+        "response.DeckMemberResponse": {
             "type": "object",
-            "required": [
-                "deck_id"
-            ],
             "properties": {
-                "deck_id": {
-                    "type": "string"
+                "member": {
+                    "$ref": "#/definitions/entity.DeckMember"
                 }
             }
         },
-        "request.CreateCardRequest": {
+        "response.DeckRatingResponse": {
             "type": "object",
-            "required": [
-                "answer",
-                "deck_id",
-                "question",
-                "wrong_answers"
-            ],
             "properties": {
-                "answer": {
-                    "type": "string"
+                "bayesian_rating": {
+                    "type": "number"
                 },
                 "deck_id": {
                     "type": "string"
                 },
-                "index": {
+                "my_rating": {
                     "type": "integer"
                 },
-                "question": {
-                    "type": "string"
-                },
-                "question_img_label": {
-                    "type": "string"
-                },
-                "question_img_url": {
-                    "type": "string"
+                "rating": {
+                    "type": "number"
                 },
-                "wrong_answers": {
+                "rating_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.DeckViewTrendResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/entity.DeckViewDaily"
                     }
                 }
             }
         },
-        "request.CreateDeckRequest": {
+        "response.DeleteDeckResponse": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "description_img_url": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "position": {
-                    "type": "string"
-                },
-                "total_cards": {
-                    "type": "integer"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "request.CreateFactRequest": {
+        "response.ErrorResponse": {
             "type": "object",
-            "required": [
-                "content"
-            ],
             "properties": {
-                "content": {
+                "error": {
                     "type": "string"
                 }
             }
         },
-        "request.DeleteCardRequest": {
+        "response.ExportJobResponse": {
             "type": "object",
-            "required": [
-                "card_id"
-            ],
             "properties": {
-                "card_id": {
+                "download_url": {
                     "type": "string"
+                },
+                "job": {
+                    "$ref": "#/definitions/entity.ExportJob"
                 }
             }
         },
-        "request.DeleteDeckRequest": {
+        "response.FactResponse": {
             "type": "object",
-            "required": [
-                "deck_id"
-            ],
             "properties": {
-                "deck_id": {
-                    "type": "string"
+                "fact": {
+                    "$ref": "#/definitions/entity.Fact"
                 }
             }
         },
-        "request.RefreshTokenRequest": {
+        "response.FeedResponse": {
             "type": "object",
-            "required": [
-                "refresh_token"
-            ],
             "properties": {
-                "refresh_token": {
+                "decks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.DeckSummary"
+                    }
+                },
+                "next_cursor": {
                     "type": "string"
                 }
             }
         },
-        "request.UpdateCardRequest": {
+        "response.FollowUserResponse": {
             "type": "object",
-            "required": [
-                "card_id"
-            ],
             "properties": {
-                "answer": {
-                    "type": "string"
-                },
-                "card_id": {
-                    "type": "string"
-                },
-                "deck_id": {
-                    "type": "string"
-                },
-                "question": {
-                    "type": "string"
-                },
-                "question_img_label": {
-                    "type": "string"
-                },
-                "question_img_url": {
-                    "type": "string"
+                "follower_count": {
+                    "type": "integer"
                 },
-                "wrong_answers": {
+                "following": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "response.FriendsActivityResponse": {
+            "type": "object",
+            "properties": {
+                "activities": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/entity.FriendActivity"
                     }
                 }
             }
         },
-        "request.UpdateDeckRequest": {
+        "response.FriendshipResponse": {
             "type": "object",
-            "required": [
-                "deck_id"
-            ],
             "properties": {
-                "cur_new_cards": {
-                    "type": "integer"
-                },
-                "cur_review_cards": {
-                    "type": "integer"
-                },
-                "deck_id": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "description_img_url": {
-                    "type": "string"
-                },
-                "is_favorite": {
-                    "type": "boolean"
-                },
-                "is_public": {
-                    "type": "boolean"
-                },
-                "last_review": {
+                "friendship": {
+                    "$ref": "#/definitions/entity.Friendship"
+                }
+            }
+        },
+        "response.GetAchievementsResponse": {
+            "type": "object",
+            "properties": {
+                "achievements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.AchievementProgress"
+                    }
+                }
+            }
+        },
+        "response.GetActivityResponse": {
+            "type": "object",
+            "properties": {
+                "activity": {
+                    "$ref": "#/definitions/entity.ActivityCalendar"
+                }
+            }
+        },
+        "response.GetAllDataResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
                     "type": "string"
                 },
-                "max_new_cards": {
-                    "type": "integer"
+                "all_cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Card"
+                    }
                 },
-                "max_review_cards": {
-                    "type": "integer"
+                "decks_review": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.DeckWithReviewCards"
+                    }
                 },
-                "name": {
-                    "type": "string"
+                "public_decks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.DeckWithCards"
+                    }
                 },
-                "position": {
+                "refresh_token": {
                     "type": "string"
                 },
-                "rating": {
-                    "type": "number"
-                },
-                "total_learned_cards": {
-                    "type": "integer"
+                "user": {
+                    "$ref": "#/definitions/entity.User"
                 },
-                "views": {
-                    "type": "integer"
+                "user_decks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.DeckWithCards"
+                    }
                 }
             }
         },
-        "request.UpdateReviewCardsRequest": {
+        "response.GetCommentsResponse": {
             "type": "object",
-            "required": [
-                "card_ids",
-                "deck_id",
-                "is_correct"
-            ],
             "properties": {
-                "card_ids": {
+                "comments": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/entity.Comment"
                     }
                 },
-                "deck_id": {
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.GetDailyGoalResponse": {
+            "type": "object",
+            "properties": {
+                "daily_goal": {
+                    "$ref": "#/definitions/entity.DailyGoalStats"
+                }
+            }
+        },
+        "response.GetFactResponse": {
+            "type": "object",
+            "properties": {
+                "fact": {
                     "type": "string"
                 },
-                "is_correct": {
+                "fact_info": {
+                    "$ref": "#/definitions/entity.Fact"
+                }
+            }
+        },
+        "response.GetFactsResponse": {
+            "type": "object",
+            "properties": {
+                "facts": {
                     "type": "array",
                     "items": {
-                        "type": "boolean"
+                        "$ref": "#/definitions/entity.Fact"
                     }
                 },
-                "total_xp": {
-                    "type": "integer"
+                "next_cursor": {
+                    "type": "string"
                 }
             }
         },
-        "request.UpdateUserRequest": {
+        "response.GetFlaggedReviewsResponse": {
             "type": "object",
             "properties": {
-                "name": {
-                    "type": "string"
-                },
-                "new_password": {
+                "next_cursor": {
                     "type": "string"
                 },
-                "old_password": {
-                    "type": "string"
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ReviewLog"
+                    }
                 }
             }
         },
-        "request.UpdateViewDeckRequest": {
+        "response.GetFriendsResponse": {
             "type": "object",
-            "required": [
-                "deck_id"
-            ],
             "properties": {
-                "deck_id": {
-                    "type": "string"
+                "friends": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Friend"
+                    }
+                }
+            }
+        },
+        "response.GetLedgerResponse": {
+            "type": "object",
+            "properties": {
+                "ledger": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.LedgerEntry"
+                    }
                 },
-                "views": {
-                    "type": "integer"
+                "next_cursor": {
+                    "type": "string"
                 }
             }
         },
-        "response.CopyCardToDeckResponse": {
+        "response.GetMembersResponse": {
             "type": "object",
             "properties": {
-                "card": {
-                    "$ref": "#/definitions/entity.Card"
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.DeckMemberInfo"
+                    }
                 }
             }
         },
-        "response.CopyDeckResponse": {
+        "response.GetModerationLogResponse": {
             "type": "object",
             "properties": {
-                "deck": {
-                    "$ref": "#/definitions/entity.DeckWithCards"
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ModerationAction"
+                    }
                 },
-                "deck_review": {
-                    "$ref": "#/definitions/entity.DeckWithReviewCards"
+                "next_cursor": {
+                    "type": "string"
                 }
             }
         },
-        "response.CreateCardResponse": {
+        "response.GetModerationQueueResponse": {
             "type": "object",
             "properties": {
-                "card": {
-                    "$ref": "#/definitions/entity.Card"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ModerationQueueItem"
+                    }
                 }
             }
         },
-        "response.CreateDeckResponse": {
+        "response.GetQuestsResponse": {
             "type": "object",
             "properties": {
-                "deck": {
-                    "$ref": "#/definitions/entity.Deck"
+                "quests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.UserQuest"
+                    }
                 }
             }
         },
-        "response.CreateFactResponse": {
+        "response.GetSessionsResponse": {
             "type": "object",
             "properties": {
-                "success": {
-                    "type": "boolean"
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Session"
+                    }
                 }
             }
         },
-        "response.DeleteDeckResponse": {
+        "response.GetShareLinksResponse": {
             "type": "object",
             "properties": {
-                "success": {
-                    "type": "boolean"
+                "share_links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ShareLink"
+                    }
                 }
             }
         },
-        "response.ErrorResponse": {
+        "response.GetSharedDecksResponse": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string"
+                "decks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.DeckWithReviewCards"
+                    }
                 }
             }
         },
-        "response.GetAllDataResponse": {
+        "response.GetShopItemsResponse": {
             "type": "object",
             "properties": {
-                "access_token": {
-                    "type": "string"
-                },
-                "all_cards": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.Card"
+                        "$ref": "#/definitions/entity.ShopItem"
                     }
-                },
-                "decks_review": {
+                }
+            }
+        },
+        "response.GetStreakResponse": {
+            "type": "object",
+            "properties": {
+                "streak": {
+                    "$ref": "#/definitions/entity.StreakInfo"
+                }
+            }
+        },
+        "response.GetWalletResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.DeckWithReviewCards"
+                        "$ref": "#/definitions/entity.UserItem"
                     }
                 },
-                "public_decks": {
+                "ledger": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.DeckWithCards"
+                        "$ref": "#/definitions/entity.LedgerEntry"
                     }
                 },
-                "refresh_token": {
+                "next_cursor": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/entity.User"
-                },
-                "user_decks": {
+                "wallet": {
+                    "$ref": "#/definitions/entity.Wallet"
+                }
+            }
+        },
+        "response.GetXPHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.DeckWithCards"
+                        "$ref": "#/definitions/entity.XPEntry"
                     }
+                },
+                "level": {
+                    "type": "integer"
+                },
+                "lifetime_xp": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "xp": {
+                    "type": "integer"
+                },
+                "xp_to_level_up": {
+                    "type": "integer"
                 }
             }
         },
-        "response.GetFactResponse": {
+        "response.GrantCoinsResponse": {
             "type": "object",
             "properties": {
-                "fact": {
-                    "type": "string"
+                "entry": {
+                    "$ref": "#/definitions/entity.LedgerEntry"
+                }
+            }
+        },
+        "response.LeaderboardResponse": {
+            "type": "object",
+            "properties": {
+                "leaderboard": {
+                    "$ref": "#/definitions/entity.Leaderboard"
                 }
             }
         },
@@ -1744,6 +7591,41 @@ const docTemplate = `{
                 }
             }
         },
+        "response.ModerationActionResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/entity.ModerationAction"
+                }
+            }
+        },
+        "response.PlacesResponse": {
+            "type": "object",
+            "properties": {
+                "places": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.MapPlace"
+                    }
+                }
+            }
+        },
+        "response.PurchaseItemResponse": {
+            "type": "object",
+            "properties": {
+                "purchase": {
+                    "$ref": "#/definitions/entity.Purchase"
+                }
+            }
+        },
+        "response.RecomputeLevelsResponse": {
+            "type": "object",
+            "properties": {
+                "num_users": {
+                    "type": "integer"
+                }
+            }
+        },
         "response.RefreshTokenResponse": {
             "type": "object",
             "properties": {
@@ -1755,6 +7637,71 @@ const docTemplate = `{
                 }
             }
         },
+        "response.RepairStreakResponse": {
+            "type": "object",
+            "properties": {
+                "user": {
+                    "$ref": "#/definitions/entity.User"
+                }
+            }
+        },
+        "response.ReportResponse": {
+            "type": "object",
+            "properties": {
+                "report": {
+                    "$ref": "#/definitions/entity.Report"
+                }
+            }
+        },
+        "response.RevokeSessionsResponse": {
+            "type": "object",
+            "properties": {
+                "num_sessions": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.SearchResponse": {
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.CardSearchHit"
+                    }
+                },
+                "decks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.DeckSearchHit"
+                    }
+                }
+            }
+        },
+        "response.ShareLinkResponse": {
+            "type": "object",
+            "properties": {
+                "share_link": {
+                    "$ref": "#/definitions/entity.ShareLink"
+                }
+            }
+        },
+        "response.SharedDeckResponse": {
+            "type": "object",
+            "properties": {
+                "deck": {
+                    "$ref": "#/definitions/entity.DeckWithCards"
+                }
+            }
+        },
+        "response.ShopItemResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "$ref": "#/definitions/entity.ShopItem"
+                }
+            }
+        },
         "response.SignupResponse": {
             "type": "object",
             "properties": {
@@ -1805,6 +7752,21 @@ const docTemplate = `{
                         "$ref": "#/definitions/entity.Card"
                     }
                 },
+                "completed_quests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.UserQuest"
+                    }
+                },
+                "daily_goal": {
+                    "$ref": "#/definitions/entity.DailyProgress"
+                },
+                "new_badges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.AchievementProgress"
+                    }
+                },
                 "num_blue_cards": {
                     "type": "integer"
                 },
@@ -1814,6 +7776,9 @@ const docTemplate = `{
                 "num_red_cards": {
                     "type": "integer"
                 },
+                "review": {
+                    "$ref": "#/definitions/entity.ReviewLog"
+                },
                 "user": {
                     "$ref": "#/definitions/entity.User"
                 }
@@ -1826,6 +7791,17 @@ const docTemplate = `{
                     "$ref": "#/definitions/entity.User"
                 }
             }
+        },
+        "response.ViewDeckResponse": {
+            "type": "object",
+            "properties": {
+                "counted": {
+                    "type": "boolean"
+                },
+                "views": {
+                    "type": "integer"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAchievements	godoc
+// GetAchievements	API
+//
+//	@Summary		Get Achievements
+//	@Description	Get every achievement with the logged in user's progress toward it and the badges they unlocked
+//	@Tags			user
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/user/achievements [get]
+//	@Success		200	{object}	response.GetAchievementsResponse
+//	@Failure		500	{object}	response.ErrorResponse
+func (h *restHandler) GetAchievements(c *gin.Context) {
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	achievements, err := h.achievementUsecase.GetAchievements(&uID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.GetAchievementsResponse{Achievements: *achievements})
+}
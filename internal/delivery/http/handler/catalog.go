@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetDeckCatalog	godoc
+// GetDeckCatalog	API
+//
+//	@Summary		Get Public Deck Catalog
+//	@Description	Get a page of public deck summaries (without cards), sorted and filtered
+//	@Tags			deck
+//	@Produce		json
+//	@Router			/api/deck/catalog [get]
+//	@Param			deck_catalog_request	query		request.DeckCatalogRequest	false	"Deck Catalog Request"
+//	@Success		200						{object}	response.DeckCatalogResponse
+//	@Failure		400						{object}	response.ErrorResponse
+//	@Failure		500						{object}	response.ErrorResponse
+func (h *restHandler) GetDeckCatalog(c *gin.Context) {
+	var (
+		req request.DeckCatalogRequest
+		err error
+	)
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if req.MinCards != nil && req.MaxCards != nil && *req.MinCards > *req.MaxCards {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "min_cards must not be greater than max_cards"})
+		return
+	}
+
+	decks, nextCursor, err := h.deckUsecase.GetPublicDeckCatalog(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	resp := response.DeckCatalogResponse{
+		Decks:      *decks,
+		NextCursor: nextCursor,
+	}
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateComment	godoc
+// CreateComment	API
+//
+//	@Summary		Create Comment
+//	@Description	Comment on a deck or one of its cards, or reply to another comment by setting parent_id. The deck must be public, owned by the user or shared with them
+//	@Tags			comment
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/comment/create [post]
+//	@Param			create_comment_request	body		request.CreateCommentRequest	true	"Create Comment Request"
+//	@Success		200						{object}	response.CommentResponse
+//	@Failure		400						{object}	response.ErrorResponse
+//	@Failure		500						{object}	response.ErrorResponse
+func (h *restHandler) CreateComment(c *gin.Context) {
+	var (
+		req request.CreateCommentRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	comment, err := h.commentUsecase.CreateComment(&uID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.CommentResponse{Comment: *comment})
+}
+
+// UpdateComment	godoc
+// UpdateComment	API
+//
+//	@Summary		Update Comment
+//	@Description	Edit the content of a comment. Only the author can edit
+//	@Tags			comment
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/comment/update [put]
+//	@Param			update_comment_request	body		request.UpdateCommentRequest	true	"Update Comment Request"
+//	@Success		200						{object}	response.CommentResponse
+//	@Failure		400						{object}	response.ErrorResponse
+//	@Failure		500						{object}	response.ErrorResponse
+func (h *restHandler) UpdateComment(c *gin.Context) {
+	var (
+		req request.UpdateCommentRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	comment, err := h.commentUsecase.UpdateComment(&uID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.CommentResponse{Comment: *comment})
+}
+
+// DeleteComment	godoc
+// DeleteComment	API
+//
+//	@Summary		Delete Comment
+//	@Description	Delete a comment. The author, the deck owner and admins can delete. Replies are kept
+//	@Tags			comment
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/comment/delete [delete]
+//	@Param			delete_comment_request	body		request.DeleteCommentRequest	true	"Delete Comment Request"
+//	@Success		200						{object}	response.CommentResponse
+//	@Failure		400						{object}	response.ErrorResponse
+//	@Failure		500						{object}	response.ErrorResponse
+func (h *restHandler) DeleteComment(c *gin.Context) {
+	var (
+		req request.DeleteCommentRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	commentID := req.CommentID.Hex()
+	comment, err := h.commentUsecase.DeleteComment(&uID, &commentID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.CommentResponse{Comment: *comment})
+}
+
+// GetComments	godoc
+// GetComments	API
+//
+//	@Summary		Get Comments
+//	@Description	Get comments of a deck, oldest first. Without card_id only deck level comments are returned, without parent_id only top level comments
+//	@Tags			comment
+//	@Produce		json
+//	@Router			/api/comment/list [get]
+//	@Param			get_comments_request	query		request.GetCommentsRequest	true	"Get Comments Request"
+//	@Success		200						{object}	response.GetCommentsResponse
+//	@Failure		400						{object}	response.ErrorResponse
+func (h *restHandler) GetComments(c *gin.Context) {
+	var (
+		req request.GetCommentsRequest
+		err error
+	)
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	uID, _ := GetLoggedInUserID(c)
+	comments, nextCursor, err := h.commentUsecase.GetComments(uID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	resp := response.GetCommentsResponse{
+		Comments:   *comments,
+		NextCursor: nextCursor,
+	}
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+	"vietcard-backend/internal/domain/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newExportJobResponse(job *entity.ExportJob) response.ExportJobResponse {
+	resp := response.ExportJobResponse{
+		Job: *job,
+	}
+	if job.Status == entity.EXPORT_STATUS_READY && job.Token != "" {
+		resp.DownloadURL = "/api/export/download?token=" + job.Token
+	}
+	return resp
+}
+
+// CreateExport	godoc
+// CreateExport	API
+//
+//	@Summary		Create Account Data Export
+//	@Description	Start an asynchronous export of the logged in user's data as a zip archive
+//	@Tags			export
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/export/create [post]
+//	@Success		200	{object}	response.ExportJobResponse
+//	@Failure		500	{object}	response.ErrorResponse
+func (h *restHandler) CreateExport(c *gin.Context) {
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	job, err := h.exportUsecase.CreateExportJob(&uID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newExportJobResponse(job))
+}
+
+// GetExportJob	godoc
+// GetExportJob	API
+//
+//	@Summary		Get Account Data Export Status
+//	@Description	Get the status of an export job and its download link once ready
+//	@Tags			export
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/export/status [get]
+//	@Param			job_id	query		string	true	"Export Job ID"
+//	@Success		200		{object}	response.ExportJobResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		404		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+func (h *restHandler) GetExportJob(c *gin.Context) {
+	var (
+		req request.GetExportJobRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	job, err := h.exportUsecase.GetExportJob(&uID, &req.JobID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if job == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Export job not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, newExportJobResponse(job))
+}
+
+// DownloadExport	godoc
+// DownloadExport	API
+//
+//	@Summary		Download Account Data Export
+//	@Description	Download a ready export archive using the link returned by the export status
+//	@Tags			export
+//	@Produce		application/zip
+//	@Router			/api/export/download [get]
+//	@Param			token	query		string	true	"Download Token"
+//	@Success		200		{file}		binary
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		404		{object}	response.ErrorResponse
+//	@Failure		410		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+func (h *restHandler) DownloadExport(c *gin.Context) {
+	var (
+		req request.DownloadExportRequest
+		err error
+	)
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	job, err := h.exportUsecase.GetExportJobByToken(&req.Token)
+	if err != nil {
+		c.JSON(http.StatusGone, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if job == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Export not found"})
+		return
+	}
+
+	c.FileAttachment(job.FilePath, "vietcard-export-"+job.CreatedAt.Format("20060102")+".zip")
+}
+
+// StreamExport	godoc
+// StreamExport	API
+//
+//	@Summary		Stream Account Data Export
+//	@Description	Stream the logged in user's data as NDJSON, one {"type", "data"} object per line
+//	@Tags			export
+//	@Produce		application/x-ndjson
+//	@Security		ApiKeyAuth
+//	@Router			/api/export/stream [get]
+//	@Success		200	{string}	string
+//	@Failure		500	{object}	response.ErrorResponse
+func (h *restHandler) StreamExport(c *gin.Context) {
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("Content-Disposition", `attachment; filename="vietcard-export.ndjson"`)
+	c.Status(http.StatusOK)
+	err = h.exportUsecase.StreamExport(&uID, c.Writer)
+	if err != nil {
+		// Headers are already sent, so report the failure in-band.
+		json.NewEncoder(c.Writer).Encode(gin.H{"type": "error", "data": err.Error()})
+	}
+}
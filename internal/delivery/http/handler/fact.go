@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateFact	godoc
+// UpdateFact	API
+//
+//	@Summary		Update Fact
+//	@Description	Update a fun fact. Content that duplicates another fact of the locale is rejected. Admin only
+//	@Tags			fact
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/admin/fact/update [put]
+//	@Param			update_fact_request	body		request.UpdateFactRequest	true	"Update Fact Request"
+//	@Success		200					{object}	response.FactResponse
+//	@Failure		400					{object}	response.ErrorResponse
+//	@Failure		403					{object}	response.ErrorResponse
+//	@Failure		404					{object}	response.ErrorResponse
+//	@Failure		409					{object}	response.ErrorResponse
+func (h *restHandler) UpdateFact(c *gin.Context) {
+	var (
+		req request.UpdateFactRequest
+		err error
+	)
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	fact, updated, err := h.factUsecase.UpdateFact(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if fact == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Fact not found"})
+		return
+	}
+	if !updated {
+		c.JSON(http.StatusConflict, response.ErrorResponse{Message: "Duplicate of fact " + fact.ID.Hex()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.FactResponse{Fact: *fact})
+}
+
+// DeleteFact	godoc
+// DeleteFact	API
+//
+//	@Summary		Delete Fact
+//	@Description	Delete a fun fact. Admin only
+//	@Tags			fact
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/admin/fact/delete [delete]
+//	@Param			delete_fact_request	body		request.DeleteFactRequest	true	"Delete Fact Request"
+//	@Success		200					{object}	response.SuccessResponse
+//	@Failure		400					{object}	response.ErrorResponse
+//	@Failure		403					{object}	response.ErrorResponse
+//	@Failure		404					{object}	response.ErrorResponse
+//	@Failure		500					{object}	response.ErrorResponse
+func (h *restHandler) DeleteFact(c *gin.Context) {
+	var (
+		req request.DeleteFactRequest
+		err error
+	)
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	factID := req.FactID.Hex()
+	deleted, err := h.factUsecase.DeleteFact(&factID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Fact not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{Success: true})
+}
+
+// GetFacts	godoc
+// GetFacts	API
+//
+//	@Summary		Get Facts
+//	@Description	List the fun facts, newest first, optionally of a locale and a category. Admin only
+//	@Tags			fact
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/admin/fact/list [get]
+//	@Param			locale		query		string	false	"Locale"
+//	@Param			category	query		string	false	"Category"
+//	@Param			limit		query		int		false	"Page size"
+//	@Param			cursor		query		string	false	"Cursor from the previous page"
+//	@Success		200			{object}	response.GetFactsResponse
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		403			{object}	response.ErrorResponse
+func (h *restHandler) GetFacts(c *gin.Context) {
+	var (
+		req request.GetFactsRequest
+		err error
+	)
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	facts, nextCursor, err := h.factUsecase.GetFacts(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.GetFactsResponse{Facts: *facts, NextCursor: nextCursor})
+}
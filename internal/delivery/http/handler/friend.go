@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SendFriendRequest	godoc
+// SendFriendRequest	API
+//
+//	@Summary		Send Friend Request
+//	@Description	Send a friend request to a user. If they already sent one to you, it is accepted
+//	@Tags			friend
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/friend/request [post]
+//	@Param			friend_user_request	body		request.FriendUserRequest	true	"Friend User Request"
+//	@Success		200					{object}	response.FriendshipResponse
+//	@Failure		400					{object}	response.ErrorResponse
+//	@Failure		500					{object}	response.ErrorResponse
+func (h *restHandler) SendFriendRequest(c *gin.Context) {
+	var (
+		req request.FriendUserRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	otherID := req.UserID.Hex()
+	friendship, err := h.friendUsecase.SendFriendRequest(&uID, &otherID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.FriendshipResponse{Friendship: *friendship})
+}
+
+// AcceptFriendRequest	godoc
+// AcceptFriendRequest	API
+//
+//	@Summary		Accept Friend Request
+//	@Description	Accept the friend request sent by user_id
+//	@Tags			friend
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/friend/accept [put]
+//	@Param			friend_user_request	body		request.FriendUserRequest	true	"Friend User Request"
+//	@Success		200					{object}	response.FriendshipResponse
+//	@Failure		400					{object}	response.ErrorResponse
+//	@Failure		500					{object}	response.ErrorResponse
+func (h *restHandler) AcceptFriendRequest(c *gin.Context) {
+	var (
+		req request.FriendUserRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	otherID := req.UserID.Hex()
+	friendship, err := h.friendUsecase.AcceptFriendRequest(&uID, &otherID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.FriendshipResponse{Friendship: *friendship})
+}
+
+// DeclineFriendRequest	godoc
+// DeclineFriendRequest	API
+//
+//	@Summary		Decline Friend Request
+//	@Description	Decline the friend request sent by user_id
+//	@Tags			friend
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/friend/decline [delete]
+//	@Param			friend_user_request	body		request.FriendUserRequest	true	"Friend User Request"
+//	@Success		200					{object}	response.SuccessResponse
+//	@Failure		400					{object}	response.ErrorResponse
+//	@Failure		500					{object}	response.ErrorResponse
+func (h *restHandler) DeclineFriendRequest(c *gin.Context) {
+	var (
+		req request.FriendUserRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	otherID := req.UserID.Hex()
+	err = h.friendUsecase.DeclineFriendRequest(&uID, &otherID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{Success: true})
+}
+
+// RemoveFriend	godoc
+// RemoveFriend	API
+//
+//	@Summary		Remove Friend
+//	@Description	Remove a friend, or cancel a friend request you sent
+//	@Tags			friend
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/friend/remove [delete]
+//	@Param			friend_user_request	body		request.FriendUserRequest	true	"Friend User Request"
+//	@Success		200					{object}	response.SuccessResponse
+//	@Failure		400					{object}	response.ErrorResponse
+//	@Failure		500					{object}	response.ErrorResponse
+func (h *restHandler) RemoveFriend(c *gin.Context) {
+	var (
+		req request.FriendUserRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	otherID := req.UserID.Hex()
+	err = h.friendUsecase.RemoveFriend(&uID, &otherID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{Success: true})
+}
+
+// GetFriends	godoc
+// GetFriends	API
+//
+//	@Summary		Get Friends
+//	@Description	Get friends and pending friend requests of the logged in user
+//	@Tags			friend
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/friend/list [get]
+//	@Success		200	{object}	response.GetFriendsResponse
+//	@Failure		500	{object}	response.ErrorResponse
+func (h *restHandler) GetFriends(c *gin.Context) {
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	friends, err := h.friendUsecase.GetFriends(&uID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.GetFriendsResponse{Friends: *friends})
+}
+
+// GetFriendsActivity	godoc
+// GetFriendsActivity	API
+//
+//	@Summary		Get Friends Activity
+//	@Description	Get each friend's streak, XP this week and decks studied today. Friends who hide their activity are left out
+//	@Tags			friend
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/friend/activity [get]
+//	@Success		200	{object}	response.FriendsActivityResponse
+//	@Failure		500	{object}	response.ErrorResponse
+func (h *restHandler) GetFriendsActivity(c *gin.Context) {
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	activities, err := h.friendUsecase.GetFriendsActivity(&uID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.FriendsActivityResponse{Activities: *activities})
+}
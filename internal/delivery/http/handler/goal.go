@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+const DEFAULT_DAILY_GOAL_HISTORY_DAYS = 30
+
+// GetDailyGoal	godoc
+// GetDailyGoal	API
+//
+//	@Summary		Get Daily Goal
+//	@Description	Get the logged in user's daily goal, today's progress toward it and how often it was met in the last days. Today only counts in the hit rate once the goal is hit. The goal is changed with the user update API
+//	@Tags			user
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/user/goal [get]
+//	@Param			days	query		int	false	"Number of days of history, 30 by default"
+//	@Success		200		{object}	response.GetDailyGoalResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+func (h *restHandler) GetDailyGoal(c *gin.Context) {
+	var (
+		req request.GetDailyGoalRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if req.Days == 0 {
+		req.Days = DEFAULT_DAILY_GOAL_HISTORY_DAYS
+	}
+
+	stats, err := h.goalUsecase.GetDailyGoal(&uID, req.Days)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.GetDailyGoalResponse{DailyGoal: *stats})
+}
@@ -20,9 +20,29 @@ type restHandler struct {
 	cardUsecase         usecase.CardUsecase
 	deckUsecase         usecase.DeckUsecase
 	userUsecase         usecase.UserUsecase
+	exportUsecase       usecase.ExportUsecase
+	searchUsecase       usecase.SearchUsecase
+	ratingUsecase       usecase.RatingUsecase
+	viewUsecase         usecase.ViewUsecase
+	commentUsecase      usecase.CommentUsecase
+	profileUsecase      usecase.ProfileUsecase
+	leagueUsecase       usecase.LeagueUsecase
+	friendUsecase       usecase.FriendUsecase
+	placeUsecase        usecase.PlaceUsecase
+	memberUsecase       usecase.MemberUsecase
+	shareUsecase        usecase.ShareUsecase
+	moderationUsecase   usecase.ModerationUsecase
+	reviewUsecase       usecase.ReviewUsecase
+	achievementUsecase  usecase.AchievementUsecase
+	streakUsecase       usecase.StreakUsecase
+	goalUsecase         usecase.GoalUsecase
+	questUsecase        usecase.QuestUsecase
+	walletUsecase       usecase.WalletUsecase
+	factUsecase         usecase.FactUsecase
+	sessionUsecase      usecase.SessionUsecase
 }
 
-func NewHandler(loginUc usecase.LoginUsecase, signUpUc usecase.SignupUsecase, refreshTokenUc usecase.RefreshTokenUsecase, cardUc usecase.CardUsecase, deckUc usecase.DeckUsecase, userUc usecase.UserUsecase) RestHandler {
+func NewHandler(loginUc usecase.LoginUsecase, signUpUc usecase.SignupUsecase, refreshTokenUc usecase.RefreshTokenUsecase, cardUc usecase.CardUsecase, deckUc usecase.DeckUsecase, userUc usecase.UserUsecase, exportUc usecase.ExportUsecase, searchUc usecase.SearchUsecase, ratingUc usecase.RatingUsecase, viewUc usecase.ViewUsecase, commentUc usecase.CommentUsecase, profileUc usecase.ProfileUsecase, leagueUc usecase.LeagueUsecase, friendUc usecase.FriendUsecase, placeUc usecase.PlaceUsecase, memberUc usecase.MemberUsecase, shareUc usecase.ShareUsecase, moderationUc usecase.ModerationUsecase, reviewUc usecase.ReviewUsecase, achievementUc usecase.AchievementUsecase, streakUc usecase.StreakUsecase, goalUc usecase.GoalUsecase, questUc usecase.QuestUsecase, walletUc usecase.WalletUsecase, factUc usecase.FactUsecase, sessionUc usecase.SessionUsecase) RestHandler {
 	return &restHandler{
 		loginUsecase:        loginUc,
 		signUpUsecase:       signUpUc,
@@ -30,6 +50,26 @@ func NewHandler(loginUc usecase.LoginUsecase, signUpUc usecase.SignupUsecase, re
 		cardUsecase:         cardUc,
 		deckUsecase:         deckUc,
 		userUsecase:         userUc,
+		exportUsecase:       exportUc,
+		searchUsecase:       searchUc,
+		ratingUsecase:       ratingUc,
+		viewUsecase:         viewUc,
+		commentUsecase:      commentUc,
+		profileUsecase:      profileUc,
+		leagueUsecase:       leagueUc,
+		friendUsecase:       friendUc,
+		placeUsecase:        placeUc,
+		memberUsecase:       memberUc,
+		shareUsecase:        shareUc,
+		moderationUsecase:   moderationUc,
+		reviewUsecase:       reviewUc,
+		achievementUsecase:  achievementUc,
+		streakUsecase:       streakUc,
+		goalUsecase:         goalUc,
+		questUsecase:        questUc,
+		walletUsecase:       walletUc,
+		factUsecase:         factUc,
+		sessionUsecase:      sessionUc,
 	}
 }
 
@@ -89,13 +129,13 @@ func (h *restHandler) SignUp(c *gin.Context) {
 		return
 	}
 
-	accessToken, er := h.signUpUsecase.CreateAccessToken(user, &bootstrap.E.AccessTokenSecret, bootstrap.E.AccessTokenExpiryHour)
-	if er != nil {
+	session, refreshToken, err := h.refreshTokenUsecase.CreateSession(user, getSessionDevice(c), bootstrap.E.RefreshTokenExpiryHour)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
 
-	refreshToken, err := h.signUpUsecase.CreateRefreshToken(user, &bootstrap.E.RefreshTokenSecret, bootstrap.E.RefreshTokenExpiryHour)
+	accessToken, err := h.signUpUsecase.CreateAccessToken(user, session, &bootstrap.E.AccessTokenSecret, bootstrap.E.AccessTokenExpiryHour)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
@@ -122,6 +162,7 @@ func (h *restHandler) SignUp(c *gin.Context) {
 //	@Success		200				{object}	response.LoginResponse
 //	@Failure		400				{object}	response.ErrorResponse
 //	@Failure		401				{object}	response.ErrorResponse
+//	@Failure		403				{object}	response.ErrorResponse
 //	@Failure		404				{object}	response.ErrorResponse
 //	@Failure		500				{object}	response.ErrorResponse
 func (h *restHandler) LogIn(c *gin.Context) {
@@ -143,14 +184,18 @@ func (h *restHandler) LogIn(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "Invalid credentials"})
 		return
 	}
+	if user.IsBanned {
+		c.JSON(http.StatusForbidden, response.ErrorResponse{Message: "This account has been banned"})
+		return
+	}
 
-	accessToken, err := h.loginUsecase.CreateAccessToken(user, &bootstrap.E.AccessTokenSecret, bootstrap.E.AccessTokenExpiryHour)
+	session, refreshToken, err := h.refreshTokenUsecase.CreateSession(user, getSessionDevice(c), bootstrap.E.RefreshTokenExpiryHour)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
 
-	refreshToken, err := h.loginUsecase.CreateRefreshToken(user, &bootstrap.E.RefreshTokenSecret, bootstrap.E.RefreshTokenExpiryHour)
+	accessToken, err := h.loginUsecase.CreateAccessToken(user, session, &bootstrap.E.AccessTokenSecret, bootstrap.E.AccessTokenExpiryHour)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
@@ -176,6 +221,7 @@ func (h *restHandler) LogIn(c *gin.Context) {
 //	@Param			refresh_token_request	body		request.RefreshTokenRequest	true	"Refresh Token Request"
 //	@Success		200						{object}	response.RefreshTokenResponse
 //	@Failure		401						{object}	response.ErrorResponse
+//	@Failure		403						{object}	response.ErrorResponse
 //	@Failure		500						{object}	response.ErrorResponse
 func (h *restHandler) RefreshToken(c *gin.Context) {
 	var request request.RefreshTokenRequest
@@ -186,25 +232,32 @@ func (h *restHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	id, err := h.refreshTokenUsecase.ExtractIDFromToken(&request.RefreshToken, &bootstrap.E.RefreshTokenSecret)
+	session, refreshToken, err := h.refreshTokenUsecase.RotateRefreshToken(&request.RefreshToken, getSessionDevice(c), bootstrap.E.RefreshTokenExpiryHour)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: err.Error()})
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
-
-	user, err := h.userUsecase.GetUserByID(&id)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: err.Error()})
+	if session == nil {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "Invalid refresh token"})
 		return
 	}
 
-	accessToken, err := h.refreshTokenUsecase.CreateAccessToken(user, &bootstrap.E.AccessTokenSecret, bootstrap.E.AccessTokenExpiryHour)
+	uID := session.UserID.Hex()
+	user, err := h.userUsecase.GetUserByID(&uID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "Invalid refresh token"})
+		return
+	}
+	if user.IsBanned {
+		c.JSON(http.StatusForbidden, response.ErrorResponse{Message: "This account has been banned"})
+		return
+	}
 
-	refreshToken, err := h.refreshTokenUsecase.CreateRefreshToken(user, &bootstrap.E.RefreshTokenSecret, bootstrap.E.RefreshTokenExpiryHour)
+	accessToken, err := h.refreshTokenUsecase.CreateAccessToken(user, session, &bootstrap.E.AccessTokenSecret, bootstrap.E.AccessTokenExpiryHour)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
@@ -252,13 +305,25 @@ func (h *restHandler) CreateCard(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
 		return
 	}
+
+	if req.Location != nil {
+		if err = req.Location.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+			return
+		}
+	}
 	if len(req.WrongAnswers) < 3 {
 		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "Must have at least 3 wrong answers"})
 		return
 	}
+	deck, _, ok := h.authorizeDeck(c, uID, req.DeckID.Hex(), entity.DECK_ROLE_EDITOR)
+	if !ok {
+		return
+	}
 
+	// Cards belong to the deck's owner whoever of its editors creates them.
 	card := &entity.Card{
-		UserID:           req.UserID,
+		UserID:           deck.UserID,
 		DeckID:           req.DeckID,
 		Index:            req.Index,
 		QuestionImgURL:   req.QuestionImgURL,
@@ -266,6 +331,8 @@ func (h *restHandler) CreateCard(c *gin.Context) {
 		Question:         req.Question,
 		Answer:           req.Answer,
 		WrongAnswers:     req.WrongAnswers,
+		Location:         req.Location,
+		Region:           req.Region,
 	}
 	card, err = h.cardUsecase.CreateCard(card)
 	if err != nil {
@@ -316,6 +383,13 @@ func (h *restHandler) CreateDeck(c *gin.Context) {
 		return
 	}
 
+	if req.Location != nil {
+		if err = req.Location.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+			return
+		}
+	}
+
 	user, err := h.userUsecase.GetUserByID(&uID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
@@ -331,13 +405,20 @@ func (h *restHandler) CreateDeck(c *gin.Context) {
 		Description:         req.Description,
 		DescriptionImageURL: req.DescriptionImageURL,
 		Position:            req.Position,
-		TotalCards:          req.TotalCards,
+		Location:            req.Location,
+		Region:              req.Region,
+		Tags:                req.Tags,
 	}
 	deck, err = h.deckUsecase.CreateDeck(deck)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
+	_, err = h.questUsecase.RecordEvent(&uID, &entity.QuestEvent{DecksCreated: 1})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
 
 	createDeckResponse := response.CreateDeckResponse{
 		Deck: *deck,
@@ -377,7 +458,7 @@ func (h *restHandler) GetDeckWithReviewCards(c *gin.Context) {
 // UpdateUser	API
 //
 //	@Summary		Update User Details
-//	@Description	Update User Details
+//	@Description	Update User Details. Changing the password signs out every other session
 //	@Tags			user
 //	@Accept			json
 //	@Produce		json
@@ -434,6 +515,18 @@ func (h *restHandler) UpdateUser(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
+	if req.HashedPassword != nil {
+		sID, err := GetLoggedInSessionID(c)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+			return
+		}
+		_, err = h.sessionUsecase.RevokeSessions(&uID, &sID, entity.SESSION_REVOKE_PASSWORD)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+			return
+		}
+	}
 
 	resp := response.UpdateUserResponse{
 		User: *user,
@@ -445,7 +538,7 @@ func (h *restHandler) UpdateUser(c *gin.Context) {
 // UpdateCard	API
 //
 //	@Summary		Update Card Details
-//	@Description	Update Card Details
+//	@Description	Update Card Details. Set clear_location or clear_region to remove the location or the region
 //	@Tags			card
 //	@Accept			json
 //	@Produce		json
@@ -473,16 +566,31 @@ func (h *restHandler) UpdateCard(c *gin.Context) {
 		return
 	}
 
+	if req.Location != nil {
+		if err = req.Location.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+			return
+		}
+	}
+
 	cardID := req.CardID.Hex()
 	card, err := h.cardUsecase.GetCardByID(&cardID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
-	if card.UserID.Hex() != uID {
-		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "Not your card! Can't update! Logged in user != card's user"})
+	if card == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Card not found"})
+		return
+	}
+	if _, _, ok := h.authorizeDeck(c, uID, card.DeckID.Hex(), entity.DECK_ROLE_EDITOR); !ok {
 		return
 	}
+	if req.DeckID != nil && *req.DeckID != card.DeckID {
+		if _, _, ok := h.authorizeDeck(c, uID, req.DeckID.Hex(), entity.DECK_ROLE_EDITOR); !ok {
+			return
+		}
+	}
 
 	req.CardID = nil
 	card, err = h.cardUsecase.UpdateCard(&cardID, &req)
@@ -502,7 +610,7 @@ func (h *restHandler) UpdateCard(c *gin.Context) {
 // UpdateDeck	API
 //
 //	@Summary		Update Deck Details
-//	@Description	Update Deck Details
+//	@Description	Update Deck Details. Set clear_location or clear_region to remove the location or the region
 //	@Tags			deck
 //	@Accept			json
 //	@Produce		json
@@ -511,6 +619,7 @@ func (h *restHandler) UpdateCard(c *gin.Context) {
 //	@Param			update_deck_request	body		request.UpdateDeckRequest	true	"Update Deck Request"
 //	@Success		200					{object}	response.UpdateDeckResponse
 //	@Failure		400					{object}	response.ErrorResponse
+//	@Failure		403					{object}	response.ErrorResponse
 //	@Failure		500					{object}	response.ErrorResponse
 func (h *restHandler) UpdateDeck(c *gin.Context) {
 	var (
@@ -530,14 +639,27 @@ func (h *restHandler) UpdateDeck(c *gin.Context) {
 		return
 	}
 
+	if req.Location != nil {
+		if err = req.Location.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+			return
+		}
+	}
+
 	deckID := req.DeckID.Hex()
-	deck, err := h.deckUsecase.GetDeckByID(&deckID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+	deck, role, ok := h.authorizeDeck(c, uID, deckID, entity.DECK_ROLE_EDITOR)
+	if !ok {
+		return
+	}
+	if deck.IsHidden && req.IsPublic != nil && *req.IsPublic {
+		c.JSON(http.StatusForbidden, response.ErrorResponse{Message: "This deck was hidden by moderators and can't be published"})
 		return
 	}
-	if deck.UserID.Hex() != uID {
-		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "Not your deck! Can't update! Logged in user != deck's user"})
+	// Editors can change the content of the deck, but visibility and study
+	// settings stay with the owner.
+	if role != entity.DECK_ROLE_OWNER && (req.IsPublic != nil || req.IsFavorite != nil || req.TotalLearnedCards != nil || req.MaxNewCards != nil ||
+		req.MaxReviewCards != nil || req.LastReview != nil || req.CurNewCards != nil || req.CurReviewCards != nil) {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "Only the owner can change the visibility and study settings of the deck"})
 		return
 	}
 
@@ -547,6 +669,12 @@ func (h *restHandler) UpdateDeck(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
+	if req.IsPublic != nil && *req.IsPublic {
+		if _, err = h.achievementUsecase.Evaluate(&uID, entity.ACHIEVEMENT_EVENT_PUBLISH); err != nil {
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+			return
+		}
+	}
 
 	resp := response.UpdateDeckResponse{
 		Success: true,
@@ -559,7 +687,7 @@ func (h *restHandler) UpdateDeck(c *gin.Context) {
 // UpdateReviewCards	API
 //
 //	@Summary		Update Review Cards
-//	@Description	Update Review Cards
+//	@Description	Grade reviewed cards of a deck and earn the XP computed on the server. Only the cards the deck serves today, due and within its daily new and review limits, can be reviewed
 //	@Tags			card
 //	@Accept			json
 //	@Produce		json
@@ -588,13 +716,8 @@ func (h *restHandler) UpdateReviewCards(c *gin.Context) {
 	}
 
 	deckID := req.DeckID.Hex()
-	deck, err := h.deckUsecase.GetDeckByID(&deckID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
-		return
-	}
-	if deck.UserID.Hex() != uID {
-		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "Not your deck! Can't update! Logged in user != deck's user"})
+	deck, role, ok := h.authorizeDeck(c, uID, deckID, entity.DECK_ROLE_VIEWER)
+	if !ok {
 		return
 	}
 
@@ -603,63 +726,47 @@ func (h *restHandler) UpdateReviewCards(c *gin.Context) {
 		return
 	}
 
-	deck.UpdateReview()
-	cards, err := h.cardUsecase.GetCardsByDeck(&deckID)
+	// The XP of the session is computed on the server, the client's total is
+	// only used to flag the session when it claims more.
+	result, err := h.reviewUsecase.SubmitReview(&uID, deck, role == entity.DECK_ROLE_OWNER, &req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
-	needUpdate := make(map[string]bool)
-	cardsMap := make(map[string]*entity.Card)
-	for i := range *cards {
-		cardsMap[(*cards)[i].ID.Hex()] = &(*cards)[i]
-	}
-	for i, id := range req.CardIDs {
-		correct := req.IsCorrect[i]
-		card1, exists := cardsMap[id.Hex()]
-		if !exists {
-			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: "Some card doesn't exist in given deck!"})
-			return
-		}
-		card1.UpdateScheduleSM2(correct)
-		needUpdate[id.Hex()] = true
-		if correct {
-			if card1.NumReviews == 1 {
-				deck.CurNewCards++
-				deck.TotalLearnedCards++
-			} else {
-				deck.CurReviewCards++
-			}
-		}
-	}
-
-	for id, update := range needUpdate {
-		if update {
-			err := h.cardUsecase.UpdateCardReview(cardsMap[id])
-			if err != nil {
-				c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
-				return
-			}
-		}
+	dailyProgress, err := h.goalUsecase.RecordProgress(&uID, result.Log.AwardedXP, result.Log.NumCards)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
 	}
-
-	cards, numBlueCards, numRedCards, numGreenCards, err := h.cardUsecase.GetReviewCardsByDeck(&deckID, deck.MaxNewCards-deck.CurNewCards, deck.MaxReviewCards-deck.CurReviewCards)
+	_, err = h.userUsecase.AddXPToUser(&uID, &entity.XPEntry{
+		Source: entity.XP_SOURCE_REVIEW,
+		Amount: result.Log.AwardedXP,
+		RefID:  result.Log.ID,
+	}, dailyProgress.CountsForStreak())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
-	updateDeckReq := &request.UpdateDeckRequest{
-		LastReview:        &deck.LastReview,
-		CurNewCards:       &deck.CurNewCards,
-		MaxNewCards:       &deck.MaxNewCards,
-		TotalLearnedCards: &deck.TotalLearnedCards,
+	err = h.leagueUsecase.RecordXP(&uID, result.Log.AwardedXP)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
 	}
-	deck, err = h.deckUsecase.UpdateDeck(&deckID, updateDeckReq)
+	newBadges, err := h.achievementUsecase.Evaluate(&uID, entity.ACHIEVEMENT_EVENT_REVIEW)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
-	_, err = h.userUsecase.AddXPToUser(&uID, req.TotalXP)
+	questEvent := &entity.QuestEvent{
+		Reviews:  result.Log.NumCards,
+		NewCards: result.Log.NumNew,
+		XP:       result.Log.AwardedXP,
+		Deck:     deck,
+	}
+	if result.Log.Breakdown.PerfectBonusXP > 0 {
+		questEvent.PerfectSessions = 1
+	}
+	completedQuests, err := h.questUsecase.RecordEvent(&uID, questEvent)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
@@ -672,11 +779,15 @@ func (h *restHandler) UpdateReviewCards(c *gin.Context) {
 	}
 
 	resp := response.UpdateReviewCardsResponse{
-		User:          user,
-		Cards:         *cards,
-		NumBlueCards:  numBlueCards,
-		NumRedCards:   numRedCards,
-		NumGreenCards: numGreenCards,
+		User:            user,
+		Cards:           *result.Cards,
+		NumBlueCards:    result.NumBlueCards,
+		NumRedCards:     result.NumRedCards,
+		NumGreenCards:   result.NumGreenCards,
+		Review:          result.Log,
+		NewBadges:       newBadges,
+		DailyGoal:       dailyProgress,
+		CompletedQuests: completedQuests,
 	}
 	c.JSON(http.StatusOK, resp)
 }
@@ -715,13 +826,17 @@ func (h *restHandler) CopyDeck(c *gin.Context) {
 	}
 
 	deckID := req.DeckID.Hex()
-	deck, err := h.deckUsecase.GetDeckByID(&deckID)
+	role, deck, err := h.memberUsecase.GetDeckRole(&uID, &deckID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
-	if !deck.IsPublic && deck.UserID.Hex() != uID {
-		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "Can't copy private deck! Deck is not public and Logged in user != deck's user"})
+	if deck == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Deck not found"})
+		return
+	}
+	if !deck.IsPublic && !entity.DeckRoleAtLeast(role, entity.DECK_ROLE_VIEWER) {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "Can't copy private deck! Deck is not public and Logged in user is not a member of the deck"})
 		return
 	}
 
@@ -730,6 +845,12 @@ func (h *restHandler) CopyDeck(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
+	if authorID := deck.UserID.Hex(); authorID != uID {
+		if _, err = h.achievementUsecase.Evaluate(&authorID, entity.ACHIEVEMENT_EVENT_COPY); err != nil {
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+			return
+		}
+	}
 
 	resp := response.CopyDeckResponse{
 		Deck:       *deckWithCards,
@@ -758,11 +879,11 @@ func (h *restHandler) CopyCardToDeck(c *gin.Context) {
 		err error
 	)
 
-	// uID, err := GetLoggedInUserID(c)
-	// if err != nil {
-	// 	c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
-	// 	return
-	// }
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
 
 	err = c.ShouldBind(&req)
 	if err != nil {
@@ -772,7 +893,30 @@ func (h *restHandler) CopyCardToDeck(c *gin.Context) {
 
 	cardID := req.CardID.Hex()
 	deckID := req.DeckID.Hex()
-	card, err := h.cardUsecase.CopyCardToDeck(&cardID, &deckID)
+	card, err := h.cardUsecase.GetCardByID(&cardID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if card == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Card not found"})
+		return
+	}
+	sourceDeckID := card.DeckID.Hex()
+	role, sourceDeck, err := h.memberUsecase.GetDeckRole(&uID, &sourceDeckID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if sourceDeck == nil || (!sourceDeck.IsPublic && !entity.DeckRoleAtLeast(role, entity.DECK_ROLE_VIEWER)) {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "Can't copy card of a private deck you are not a member of"})
+		return
+	}
+	if _, _, ok := h.authorizeDeck(c, uID, deckID, entity.DECK_ROLE_EDITOR); !ok {
+		return
+	}
+
+	card, err = h.cardUsecase.CopyCardToDeck(&cardID, &deckID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
@@ -788,7 +932,7 @@ func (h *restHandler) CopyCardToDeck(c *gin.Context) {
 // LogInGetAllData	API
 //
 //	@Summary		Log In And Get All Data
-//	@Description	Log In And Get All Data
+//	@Description	Log in and get the user's own decks and cards. Public decks of other users are listed by /api/deck/catalog
 //	@Tags			mobile
 //	@Accept			multipart/form-data
 //	@Produce		json
@@ -797,6 +941,7 @@ func (h *restHandler) CopyCardToDeck(c *gin.Context) {
 //	@Success		200				{object}	response.LoginGetAllDataResponse
 //	@Failure		400				{object}	response.ErrorResponse
 //	@Failure		401				{object}	response.ErrorResponse
+//	@Failure		403				{object}	response.ErrorResponse
 //	@Failure		404				{object}	response.ErrorResponse
 //	@Failure		500				{object}	response.ErrorResponse
 func (h *restHandler) LogInGetAllData(c *gin.Context) {
@@ -818,14 +963,18 @@ func (h *restHandler) LogInGetAllData(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "Invalid credentials"})
 		return
 	}
+	if user.IsBanned {
+		c.JSON(http.StatusForbidden, response.ErrorResponse{Message: "This account has been banned"})
+		return
+	}
 
-	accessToken, err := h.loginUsecase.CreateAccessToken(user, &bootstrap.E.AccessTokenSecret, bootstrap.E.AccessTokenExpiryHour)
+	session, refreshToken, err := h.refreshTokenUsecase.CreateSession(user, getSessionDevice(c), bootstrap.E.RefreshTokenExpiryHour)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
 
-	refreshToken, err := h.loginUsecase.CreateRefreshToken(user, &bootstrap.E.RefreshTokenSecret, bootstrap.E.RefreshTokenExpiryHour)
+	accessToken, err := h.loginUsecase.CreateAccessToken(user, session, &bootstrap.E.AccessTokenSecret, bootstrap.E.AccessTokenExpiryHour)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
@@ -863,13 +1012,15 @@ func (h *restHandler) LogInGetAllData(c *gin.Context) {
 // GetAllData	API
 //
 //	@Summary		Get All Data
-//	@Description	Get All Data
+//	@Description	Get the user's own decks and cards. Public decks of other users are listed by /api/deck/catalog
 //	@Tags			mobile
 //	@Accept			json
 //	@Produce		json
 //	@Router			/api/get-all [post]
 //	@Param			refresh_token_request	body		request.RefreshTokenRequest	true	"Refresh Token Request"
 //	@Success		200						{object}	response.GetAllDataResponse
+//	@Failure		401						{object}	response.ErrorResponse
+//	@Failure		403						{object}	response.ErrorResponse
 //	@Failure		500						{object}	response.ErrorResponse
 func (h *restHandler) GetAllData(c *gin.Context) {
 	var request request.RefreshTokenRequest
@@ -880,25 +1031,32 @@ func (h *restHandler) GetAllData(c *gin.Context) {
 		return
 	}
 
-	uID, err := h.refreshTokenUsecase.ExtractIDFromToken(&request.RefreshToken, &bootstrap.E.RefreshTokenSecret)
+	session, refreshToken, err := h.refreshTokenUsecase.RotateRefreshToken(&request.RefreshToken, getSessionDevice(c), bootstrap.E.RefreshTokenExpiryHour)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: err.Error()})
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if session == nil {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "Invalid refresh token"})
 		return
 	}
 
+	uID := session.UserID.Hex()
 	user, err := h.userUsecase.GetUserByID(&uID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
-
-	accessToken, err := h.loginUsecase.CreateAccessToken(user, &bootstrap.E.AccessTokenSecret, bootstrap.E.AccessTokenExpiryHour)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "Invalid refresh token"})
+		return
+	}
+	if user.IsBanned {
+		c.JSON(http.StatusForbidden, response.ErrorResponse{Message: "This account has been banned"})
 		return
 	}
 
-	refreshToken, err := h.loginUsecase.CreateRefreshToken(user, &bootstrap.E.RefreshTokenSecret, bootstrap.E.RefreshTokenExpiryHour)
+	accessToken, err := h.loginUsecase.CreateAccessToken(user, session, &bootstrap.E.AccessTokenSecret, bootstrap.E.AccessTokenExpiryHour)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
@@ -935,7 +1093,7 @@ func (h *restHandler) GetAllData(c *gin.Context) {
 // SignUpGetAllData	API
 //
 //	@Summary		Sign Up And Get All Data
-//	@Description	Sign Up And Get All Data
+//	@Description	Sign up and get the user's own decks and cards. Public decks of other users are listed by /api/deck/catalog
 //	@Tags			mobile
 //	@Accept			multipart/form-data
 //	@Produce		json
@@ -989,13 +1147,13 @@ func (h *restHandler) SignUpGetAllData(c *gin.Context) {
 	}
 	user.ID, _ = primitive.ObjectIDFromHex(uID)
 
-	accessToken, er := h.signUpUsecase.CreateAccessToken(user, &bootstrap.E.AccessTokenSecret, bootstrap.E.AccessTokenExpiryHour)
-	if er != nil {
+	session, refreshToken, err := h.refreshTokenUsecase.CreateSession(user, getSessionDevice(c), bootstrap.E.RefreshTokenExpiryHour)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
 
-	refreshToken, err := h.signUpUsecase.CreateRefreshToken(user, &bootstrap.E.RefreshTokenSecret, bootstrap.E.RefreshTokenExpiryHour)
+	accessToken, err := h.signUpUsecase.CreateAccessToken(user, session, &bootstrap.E.AccessTokenSecret, bootstrap.E.AccessTokenExpiryHour)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
@@ -1061,13 +1219,7 @@ func (h *restHandler) DeleteDeck(c *gin.Context) {
 	}
 
 	deckID := req.DeckID.Hex()
-	deck, err := h.deckUsecase.GetDeckByID(&deckID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
-		return
-	}
-	if deck.UserID.Hex() != uID {
-		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "Not your deck! Can't update! Logged in user != deck's user"})
+	if _, _, ok := h.authorizeDeck(c, uID, deckID, entity.DECK_ROLE_OWNER); !ok {
 		return
 	}
 
@@ -1076,6 +1228,11 @@ func (h *restHandler) DeleteDeck(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
+	err = h.memberUsecase.DeleteDeckMembership(&deckID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
 
 	deleteDeckResponse := response.DeleteDeckResponse{
 		Success: true,
@@ -1088,15 +1245,17 @@ func (h *restHandler) DeleteDeck(c *gin.Context) {
 // CreateFact	API
 //
 //	@Summary		Create New Fact
-//	@Description	Create New Fact
+//	@Description	Create a fun fact with an optional category, source citation, related deck and locale, Vietnamese by default. A fact with the same content in the locale, ignoring case, diacritics and punctuation, is rejected as a duplicate. Admin only
 //	@Tags			fact
 //	@Accept			json
 //	@Produce		json
 //	@Security		ApiKeyAuth
-//	@Router			/api/fact/create [post]
+//	@Router			/api/admin/fact/create [post]
 //	@Param			create_fact_request	body		request.CreateFactRequest	true	"Create Fact Request"
 //	@Success		200					{object}	response.CreateFactResponse
-//	@Failure		500					{object}	response.ErrorResponse
+//	@Failure		400					{object}	response.ErrorResponse
+//	@Failure		403					{object}	response.ErrorResponse
+//	@Failure		409					{object}	response.ErrorResponse
 func (h *restHandler) CreateFact(c *gin.Context) {
 	var (
 		req request.CreateFactRequest
@@ -1109,63 +1268,22 @@ func (h *restHandler) CreateFact(c *gin.Context) {
 		return
 	}
 
-	fact := &entity.Fact{
-		Content: req.Content,
-	}
-	err = h.userUsecase.CreateFact(fact)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
-		return
-	}
-
-	createFactResponse := response.CreateFactResponse{
-		Success: true,
-	}
-
-	c.JSON(http.StatusOK, createFactResponse)
-}
-
-// UpdateViewDeck	godoc
-// UpdateViewDeck	API
-//
-//	@Summary		Update View Deck
-//	@Description	Update View Deck
-//	@Tags			deck
-//	@Accept			json
-//	@Produce		json
-//	@Router			/api/deck/view [put]
-//	@Param			view_deck_request	body		request.UpdateViewDeckRequest	true	"View Deck Request"
-//	@Success		200					{object}	response.SuccessResponse
-//	@Failure		400					{object}	response.ErrorResponse
-//	@Failure		500					{object}	response.ErrorResponse
-func (h *restHandler) UpdateViewDeck(c *gin.Context) {
-	var (
-		req  request.UpdateViewDeckRequest
-		req1 request.UpdateDeckRequest
-		err  error
-	)
-
-	err = c.ShouldBind(&req)
+	fact, created, err := h.factUsecase.CreateFact(&req)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
 		return
 	}
-
-	deckID := req.DeckID.Hex()
-
-	req1 = request.UpdateDeckRequest{
-		Views: req.Views,
-	}
-	_, err = h.deckUsecase.UpdateDeck(&deckID, &req1)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+	if !created {
+		c.JSON(http.StatusConflict, response.ErrorResponse{Message: "Duplicate of fact " + fact.ID.Hex()})
 		return
 	}
 
-	resp := response.SuccessResponse{
+	createFactResponse := response.CreateFactResponse{
 		Success: true,
+		Fact:    *fact,
 	}
-	c.JSON(http.StatusOK, resp)
+
+	c.JSON(http.StatusOK, createFactResponse)
 }
 
 // DeleteCard	godoc
@@ -1206,14 +1324,11 @@ func (h *restHandler) DeleteCard(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
-	deckID := card.DeckID.Hex()
-	deck, err := h.deckUsecase.GetDeckByID(&deckID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+	if card == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Card not found"})
 		return
 	}
-	if deck.UserID.Hex() != uID {
-		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "Not your card! Can't update! Logged in user != card's user"})
+	if _, _, ok := h.authorizeDeck(c, uID, card.DeckID.Hex(), entity.DECK_ROLE_EDITOR); !ok {
 		return
 	}
 
@@ -1234,20 +1349,48 @@ func (h *restHandler) DeleteCard(c *gin.Context) {
 // GetFact	API
 //
 //	@Summary		Get Fact
-//	@Description	Get Fact
+//	@Description	Get a random fun fact of the locale, or the fact of the day, which is the same for everyone all day. Logged in users can ask for a fact they have not seen in the last 30 days
 //	@Tags			fact
 //	@Produce		json
 //	@Router			/api/fact [get]
-//	@Success		200	{object}	response.GetFactResponse
-//	@Failure		500	{object}	response.ErrorResponse
+//	@Param			mode		query		string	false	"random (default), daily or unseen. unseen requires logging in"
+//	@Param			locale		query		string	false	"Locale, vi by default"
+//	@Param			category	query		string	false	"Category"
+//	@Success		200			{object}	response.GetFactResponse
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		401			{object}	response.ErrorResponse
+//	@Failure		404			{object}	response.ErrorResponse
+//	@Failure		500			{object}	response.ErrorResponse
 func (h *restHandler) GetFact(c *gin.Context) {
-	fact, err := h.userUsecase.GetFact()
+	var (
+		req request.GetFactRequest
+		err error
+	)
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	uID, _ := GetLoggedInUserID(c)
+	if req.Mode == entity.FACT_MODE_UNSEEN && uID == "" {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "Log in to get unseen facts"})
+		return
+	}
+
+	fact, err := h.factUsecase.GetFact(uID, &req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
+	if fact == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Message: "No facts found"})
+		return
+	}
 	resp := response.GetFactResponse{
-		Fact: fact.Content,
+		Fact:     fact.Content,
+		FactInfo: *fact,
 	}
 	c.JSON(http.StatusOK, resp)
 }
@@ -24,9 +24,74 @@ type RestHandler interface {
 	SignUpGetAllData(c *gin.Context)
 	DeleteDeck(c *gin.Context)
 	CreateFact(c *gin.Context)
-	UpdateViewDeck(c *gin.Context)
 	DeleteCard(c *gin.Context)
     GetFact(c *gin.Context)
+	CreateExport(c *gin.Context)
+	GetExportJob(c *gin.Context)
+	DownloadExport(c *gin.Context)
+	StreamExport(c *gin.Context)
+	GetDeckCatalog(c *gin.Context)
+	Search(c *gin.Context)
+	RateDeck(c *gin.Context)
+	GetDeckRating(c *gin.Context)
+	ViewDeck(c *gin.Context)
+	GetDeckViewTrend(c *gin.Context)
+	CreateComment(c *gin.Context)
+	UpdateComment(c *gin.Context)
+	DeleteComment(c *gin.Context)
+	GetComments(c *gin.Context)
+	GetAuthorProfile(c *gin.Context)
+	FollowUser(c *gin.Context)
+	UnfollowUser(c *gin.Context)
+	GetFeed(c *gin.Context)
+	GetLeaderboard(c *gin.Context)
+	SendFriendRequest(c *gin.Context)
+	AcceptFriendRequest(c *gin.Context)
+	DeclineFriendRequest(c *gin.Context)
+	RemoveFriend(c *gin.Context)
+	GetFriends(c *gin.Context)
+	GetFriendsActivity(c *gin.Context)
+	GetNearbyPlaces(c *gin.Context)
+	GetPlacesInBox(c *gin.Context)
+	GetPlacesByProvince(c *gin.Context)
+	InviteDeckMember(c *gin.Context)
+	RemoveDeckMember(c *gin.Context)
+	GetDeckMembers(c *gin.Context)
+	GetSharedDecks(c *gin.Context)
+	CreateShareLink(c *gin.Context)
+	GetShareLinks(c *gin.Context)
+	RevokeShareLink(c *gin.Context)
+	PreviewSharedDeck(c *gin.Context)
+	CopySharedDeck(c *gin.Context)
+	ReportContent(c *gin.Context)
+	GetModerationQueue(c *gin.Context)
+	ModerateContent(c *gin.Context)
+	GetModerationLog(c *gin.Context)
+	GetFlaggedReviews(c *gin.Context)
+	GetAchievements(c *gin.Context)
+	GetStreak(c *gin.Context)
+	RepairStreak(c *gin.Context)
+	GetDailyGoal(c *gin.Context)
+	GetQuests(c *gin.Context)
+	ClaimQuest(c *gin.Context)
+	GetWallet(c *gin.Context)
+	GetUserLedger(c *gin.Context)
+	GrantCoins(c *gin.Context)
+	GetShopItems(c *gin.Context)
+	PurchaseItem(c *gin.Context)
+	GetAllShopItems(c *gin.Context)
+	CreateShopItem(c *gin.Context)
+	UpdateShopItem(c *gin.Context)
+	GetXPHistory(c *gin.Context)
+	RecomputeLevels(c *gin.Context)
+	GetActivity(c *gin.Context)
+	UpdateFact(c *gin.Context)
+	DeleteFact(c *gin.Context)
+	GetFacts(c *gin.Context)
+	LogOut(c *gin.Context)
+	GetSessions(c *gin.Context)
+	RevokeSession(c *gin.Context)
+	RevokeAllSessions(c *gin.Context)
 }
 
 func GetLoggedInUserID(c *gin.Context) (string, error) {
@@ -37,3 +102,12 @@ func GetLoggedInUserID(c *gin.Context) (string, error) {
 
 	return uID.(string), nil
 }
+
+func GetLoggedInSessionID(c *gin.Context) (string, error) {
+	sID, isExisted := c.Get("x-session-id")
+	if !isExisted {
+		return "", errors.New("Missing x-session-id (set at middleware) in Gin Context")
+	}
+
+	return sID.(string), nil
+}
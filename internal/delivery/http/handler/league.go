@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetLeaderboard	godoc
+// GetLeaderboard	API
+//
+//	@Summary		Get Leaderboard
+//	@Description	Get this week's XP leaderboard of the logged in user's league (default), of everyone, or of their friends who don't hide their activity
+//	@Tags			leaderboard
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/leaderboard [get]
+//	@Param			leaderboard_request	query		request.LeaderboardRequest	false	"Leaderboard Request"
+//	@Success		200					{object}	response.LeaderboardResponse
+//	@Failure		400					{object}	response.ErrorResponse
+//	@Failure		500					{object}	response.ErrorResponse
+func (h *restHandler) GetLeaderboard(c *gin.Context) {
+	var (
+		req request.LeaderboardRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	leaderboard, err := h.leagueUsecase.GetLeaderboard(&uID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.LeaderboardResponse{Leaderboard: *leaderboard})
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetXPHistory	godoc
+// GetXPHistory	API
+//
+//	@Summary		Get XP History
+//	@Description	Get the logged in user's lifetime XP, level and ledger of earned XP, newest first
+//	@Tags			user
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/user/xp/history [get]
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			cursor	query		string	false	"Cursor from the previous page"
+//	@Success		200		{object}	response.GetXPHistoryResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+func (h *restHandler) GetXPHistory(c *gin.Context) {
+	var (
+		req request.GetXPHistoryRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	user, err := h.userUsecase.GetUserByID(&uID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	entries, nextCursor, err := h.userUsecase.GetXPHistory(&uID, req.Limit, req.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	resp := response.GetXPHistoryResponse{
+		LifetimeXP:  user.LifetimeXP,
+		Level:       user.Level,
+		XP:          user.XP,
+		XPToLevelUp: user.XPToLevelUp,
+		Entries:     *entries,
+		NextCursor:  nextCursor,
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// RecomputeLevels	godoc
+// RecomputeLevels	API
+//
+//	@Summary		Recompute Levels
+//	@Description	Recompute every user's lifetime XP from the XP ledger and their level on the configured level curve. Run it after changing the curve. Admin only
+//	@Tags			user
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/admin/level/recompute [post]
+//	@Success		200	{object}	response.RecomputeLevelsResponse
+//	@Failure		403	{object}	response.ErrorResponse
+//	@Failure		500	{object}	response.ErrorResponse
+func (h *restHandler) RecomputeLevels(c *gin.Context) {
+	numUsers, err := h.userUsecase.RecomputeLevels()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.RecomputeLevelsResponse{NumUsers: numUsers})
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+	"vietcard-backend/internal/domain/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// authorizeDeck loads the deck and checks that the user has at least minRole
+// on it. On failure it writes the error response and returns false.
+func (h *restHandler) authorizeDeck(c *gin.Context, userID string, deckID string, minRole string) (*entity.Deck, string, bool) {
+	role, deck, err := h.memberUsecase.GetDeckRole(&userID, &deckID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return nil, "", false
+	}
+	if deck == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Deck not found"})
+		return nil, "", false
+	}
+	if !entity.DeckRoleAtLeast(role, minRole) {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "Not allowed! Requires the " + minRole + " role on this deck"})
+		return nil, "", false
+	}
+	return deck, role, true
+}
+
+// InviteDeckMember	godoc
+// InviteDeckMember	API
+//
+//	@Summary		Invite Deck Member
+//	@Description	Give a user, found by user_id or email, the editor or viewer role on a deck. Inviting an existing member changes their role. Only the owner can invite
+//	@Tags			deck
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/deck/member/invite [post]
+//	@Param			invite_member_request	body		request.InviteMemberRequest	true	"Invite Member Request"
+//	@Success		200						{object}	response.DeckMemberResponse
+//	@Failure		400						{object}	response.ErrorResponse
+//	@Failure		500						{object}	response.ErrorResponse
+func (h *restHandler) InviteDeckMember(c *gin.Context) {
+	var (
+		req request.InviteMemberRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	member, err := h.memberUsecase.InviteMember(&uID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.DeckMemberResponse{Member: *member})
+}
+
+// RemoveDeckMember	godoc
+// RemoveDeckMember	API
+//
+//	@Summary		Remove Deck Member
+//	@Description	Remove a member from a deck along with their review progress. The owner can remove anyone, members can remove themselves
+//	@Tags			deck
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/deck/member/remove [delete]
+//	@Param			remove_member_request	body		request.RemoveMemberRequest	true	"Remove Member Request"
+//	@Success		200						{object}	response.SuccessResponse
+//	@Failure		400						{object}	response.ErrorResponse
+//	@Failure		500						{object}	response.ErrorResponse
+func (h *restHandler) RemoveDeckMember(c *gin.Context) {
+	var (
+		req request.RemoveMemberRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = h.memberUsecase.RemoveMember(&uID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{Success: true})
+}
+
+// GetDeckMembers	godoc
+// GetDeckMembers	API
+//
+//	@Summary		Get Deck Members
+//	@Description	List the owner and members of a deck with their roles
+//	@Tags			deck
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/deck/member/list [get]
+//	@Param			deck_id	query		string	true	"Deck ID"
+//	@Success		200		{object}	response.GetMembersResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+func (h *restHandler) GetDeckMembers(c *gin.Context) {
+	var (
+		req request.GetMembersRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	members, err := h.memberUsecase.GetMembers(&uID, &req.DeckID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.GetMembersResponse{Members: *members})
+}
+
+// GetSharedDecks	godoc
+// GetSharedDecks	API
+//
+//	@Summary		Get Shared Decks
+//	@Description	Get the decks shared with the logged in user and their review cards, using the user's own review progress
+//	@Tags			deck
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/deck/shared [get]
+//	@Success		200	{object}	response.GetSharedDecksResponse
+//	@Failure		500	{object}	response.ErrorResponse
+func (h *restHandler) GetSharedDecks(c *gin.Context) {
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	decks, err := h.memberUsecase.GetSharedDecksWithReviewCards(&uID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.GetSharedDecksResponse{Decks: *decks})
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportContent	godoc
+// ReportContent	API
+//
+//	@Summary		Report Content
+//	@Description	Report a deck, card or comment to the moderators. Content reported by enough distinct users is hidden automatically
+//	@Tags			moderation
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/report [post]
+//	@Param			report_content_request	body		request.ReportContentRequest	true	"Report Content Request"
+//	@Success		200						{object}	response.ReportResponse
+//	@Failure		400						{object}	response.ErrorResponse
+//	@Failure		500						{object}	response.ErrorResponse
+func (h *restHandler) ReportContent(c *gin.Context) {
+	var (
+		req request.ReportContentRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	report, err := h.moderationUsecase.ReportContent(&uID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.ReportResponse{Report: *report})
+}
+
+// GetModerationQueue	godoc
+// GetModerationQueue	API
+//
+//	@Summary		Get Moderation Queue
+//	@Description	Get the reported content with open reports, most reported first. Admin only
+//	@Tags			moderation
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/admin/moderation/queue [get]
+//	@Param			target_type	query		string	false	"deck, card or comment"
+//	@Param			page		query		int		false	"Page, starting from 1"
+//	@Param			limit		query		int		false	"Page size"
+//	@Success		200			{object}	response.GetModerationQueueResponse
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		403			{object}	response.ErrorResponse
+//	@Failure		500			{object}	response.ErrorResponse
+func (h *restHandler) GetModerationQueue(c *gin.Context) {
+	var (
+		req request.GetModerationQueueRequest
+		err error
+	)
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	items, err := h.moderationUsecase.GetModerationQueue(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.GetModerationQueueResponse{Items: *items})
+}
+
+// ModerateContent	godoc
+// ModerateContent	API
+//
+//	@Summary		Moderate Content
+//	@Description	Hide, restore, unpublish, warn the author of, ban or unban the author of, or dismiss the reports of a deck, card or comment. A ban signs the author out everywhere and hides their public decks and comments until unbanned. Closes its open reports. Admin only
+//	@Tags			moderation
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/admin/moderation/action [post]
+//	@Param			moderate_content_request	body		request.ModerateContentRequest	true	"Moderate Content Request"
+//	@Success		200							{object}	response.ModerationActionResponse
+//	@Failure		400							{object}	response.ErrorResponse
+//	@Failure		403							{object}	response.ErrorResponse
+//	@Failure		500							{object}	response.ErrorResponse
+func (h *restHandler) ModerateContent(c *gin.Context) {
+	var (
+		req request.ModerateContentRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	action, err := h.moderationUsecase.ModerateContent(&uID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.ModerationActionResponse{Action: *action})
+}
+
+// GetModerationLog	godoc
+// GetModerationLog	API
+//
+//	@Summary		Get Moderation Log
+//	@Description	Get the recorded moderation decisions, newest first, optionally of a single target. Admin only
+//	@Tags			moderation
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/admin/moderation/log [get]
+//	@Param			target_id	query		string	false	"Target ID"
+//	@Param			limit		query		int		false	"Page size"
+//	@Param			cursor		query		string	false	"Cursor from the previous page"
+//	@Success		200			{object}	response.GetModerationLogResponse
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		403			{object}	response.ErrorResponse
+//	@Failure		500			{object}	response.ErrorResponse
+func (h *restHandler) GetModerationLog(c *gin.Context) {
+	var (
+		req request.GetModerationLogRequest
+		err error
+	)
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	actions, nextCursor, err := h.moderationUsecase.GetModerationLog(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.GetModerationLogResponse{Actions: *actions, NextCursor: nextCursor})
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/pkg/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *restHandler) respondPlaces(c *gin.Context, query *entity.PlaceQuery, kind string) {
+	places, err := h.placeUsecase.FindPlaces(query, kind)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.PlacesResponse{Places: *places})
+}
+
+// GetNearbyPlaces	godoc
+// GetNearbyPlaces	API
+//
+//	@Summary		Get Nearby Places
+//	@Description	Get geolocated public decks and cards within radius_km (default 10) of a point, nearest first
+//	@Tags			map
+//	@Produce		json
+//	@Router			/api/map/near [get]
+//	@Param			nearby_places_request	query		request.NearbyPlacesRequest	true	"Nearby Places Request"
+//	@Success		200						{object}	response.PlacesResponse
+//	@Failure		400						{object}	response.ErrorResponse
+//	@Failure		500						{object}	response.ErrorResponse
+func (h *restHandler) GetNearbyPlaces(c *gin.Context) {
+	var (
+		req request.NearbyPlacesRequest
+		err error
+	)
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	radiusKm := req.RadiusKm
+	if radiusKm == 0 {
+		radiusKm = entity.DEFAULT_NEAR_RADIUS_KM
+	}
+	query := &entity.PlaceQuery{
+		Near:              entity.NewGeoPoint(*req.Lng, *req.Lat),
+		MaxDistanceMeters: radiusKm * 1000,
+		Limit:             pagination.NormalizeLimit(req.Limit),
+	}
+	h.respondPlaces(c, query, req.Kind)
+}
+
+// GetPlacesInBox	godoc
+// GetPlacesInBox	API
+//
+//	@Summary		Get Places In Bounding Box
+//	@Description	Get geolocated public decks and cards inside the visible map area
+//	@Tags			map
+//	@Produce		json
+//	@Router			/api/map/bbox [get]
+//	@Param			bounding_box_places_request	query		request.BoundingBoxPlacesRequest	true	"Bounding Box Places Request"
+//	@Success		200							{object}	response.PlacesResponse
+//	@Failure		400							{object}	response.ErrorResponse
+//	@Failure		500							{object}	response.ErrorResponse
+func (h *restHandler) GetPlacesInBox(c *gin.Context) {
+	var (
+		req request.BoundingBoxPlacesRequest
+		err error
+	)
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	if *req.MinLat >= *req.MaxLat || *req.MinLng >= *req.MaxLng {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "min_lat and min_lng must be less than max_lat and max_lng"})
+		return
+	}
+	query := &entity.PlaceQuery{
+		Box:   []float64{*req.MinLng, *req.MinLat, *req.MaxLng, *req.MaxLat},
+		Limit: pagination.NormalizeLimit(req.Limit),
+	}
+	h.respondPlaces(c, query, req.Kind)
+}
+
+// GetPlacesByProvince	godoc
+// GetPlacesByProvince	API
+//
+//	@Summary		Get Places By Province
+//	@Description	Get geolocated public decks and cards of a province. Matching ignores diacritics and the "Tỉnh"/"Thành phố" prefix
+//	@Tags			map
+//	@Produce		json
+//	@Router			/api/map/province [get]
+//	@Param			province_places_request	query		request.ProvincePlacesRequest	true	"Province Places Request"
+//	@Success		200						{object}	response.PlacesResponse
+//	@Failure		400						{object}	response.ErrorResponse
+//	@Failure		500						{object}	response.ErrorResponse
+func (h *restHandler) GetPlacesByProvince(c *gin.Context) {
+	var (
+		req request.ProvincePlacesRequest
+		err error
+	)
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	provinceKey := entity.ProvinceKey(req.Province)
+	if provinceKey == "" {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "Invalid province"})
+		return
+	}
+	query := &entity.PlaceQuery{
+		ProvinceKey: provinceKey,
+		Limit:       pagination.NormalizeLimit(req.Limit),
+	}
+	h.respondPlaces(c, query, req.Kind)
+}
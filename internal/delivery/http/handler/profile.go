@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAuthorProfile	godoc
+// GetAuthorProfile	API
+//
+//	@Summary		Get Author Profile
+//	@Description	Get the public profile of a user with their latest published decks
+//	@Tags			user
+//	@Produce		json
+//	@Router			/api/user/profile [get]
+//	@Param			user_id	query		string	true	"User ID"
+//	@Success		200		{object}	response.AuthorProfileResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		404		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+func (h *restHandler) GetAuthorProfile(c *gin.Context) {
+	var (
+		req request.GetAuthorProfileRequest
+		err error
+	)
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	uID, _ := GetLoggedInUserID(c)
+	profile, err := h.profileUsecase.GetAuthorProfile(&req.UserID, uID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if profile == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Message: "User not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.AuthorProfileResponse{Profile: *profile})
+}
+
+// FollowUser	godoc
+// FollowUser	API
+//
+//	@Summary		Follow User
+//	@Description	Follow an author to see their new and updated public decks in the feed
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/user/follow [post]
+//	@Param			follow_user_request	body		request.FollowUserRequest	true	"Follow User Request"
+//	@Success		200					{object}	response.FollowUserResponse
+//	@Failure		400					{object}	response.ErrorResponse
+//	@Failure		500					{object}	response.ErrorResponse
+func (h *restHandler) FollowUser(c *gin.Context) {
+	var (
+		req request.FollowUserRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	authorID := req.UserID.Hex()
+	author, err := h.profileUsecase.FollowUser(&uID, &authorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	resp := response.FollowUserResponse{
+		Following:     true,
+		FollowerCount: author.FollowerCount,
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// UnfollowUser	godoc
+// UnfollowUser	API
+//
+//	@Summary		Unfollow User
+//	@Description	Stop following an author
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/user/unfollow [delete]
+//	@Param			unfollow_user_request	body		request.FollowUserRequest	true	"Unfollow User Request"
+//	@Success		200						{object}	response.FollowUserResponse
+//	@Failure		400						{object}	response.ErrorResponse
+//	@Failure		500						{object}	response.ErrorResponse
+func (h *restHandler) UnfollowUser(c *gin.Context) {
+	var (
+		req request.FollowUserRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	authorID := req.UserID.Hex()
+	author, err := h.profileUsecase.UnfollowUser(&uID, &authorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	resp := response.FollowUserResponse{
+		Following:     false,
+		FollowerCount: author.FollowerCount,
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetFeed	godoc
+// GetFeed	API
+//
+//	@Summary		Get Following Feed
+//	@Description	Get public decks of followed authors, most recently published or updated first
+//	@Tags			user
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/user/feed [get]
+//	@Param			get_feed_request	query		request.GetFeedRequest	false	"Get Feed Request"
+//	@Success		200					{object}	response.FeedResponse
+//	@Failure		400					{object}	response.ErrorResponse
+//	@Failure		500					{object}	response.ErrorResponse
+func (h *restHandler) GetFeed(c *gin.Context) {
+	var (
+		req request.GetFeedRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	decks, nextCursor, err := h.profileUsecase.GetFeed(&uID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	resp := response.FeedResponse{
+		Decks:      *decks,
+		NextCursor: nextCursor,
+	}
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+	"vietcard-backend/internal/domain/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetQuests	godoc
+// GetQuests	API
+//
+//	@Summary		Get Quests
+//	@Description	Get the logged in user's quests of today and of this week. Quests rotate every day and week
+//	@Tags			quest
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/quest/list [get]
+//	@Success		200	{object}	response.GetQuestsResponse
+//	@Failure		500	{object}	response.ErrorResponse
+func (h *restHandler) GetQuests(c *gin.Context) {
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	quests, err := h.questUsecase.GetQuests(&uID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.GetQuestsResponse{Quests: *quests})
+}
+
+// ClaimQuest	godoc
+// ClaimQuest	API
+//
+//	@Summary		Claim Quest
+//	@Description	Claim the XP and coins of a completed quest. A reward is only paid once, and a claim that failed can be retried
+//	@Tags			quest
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/quest/claim [post]
+//	@Param			claim_quest_request	body		request.ClaimQuestRequest	true	"Claim Quest Request"
+//	@Success		200					{object}	response.ClaimQuestResponse
+//	@Failure		400					{object}	response.ErrorResponse
+//	@Failure		500					{object}	response.ErrorResponse
+func (h *restHandler) ClaimQuest(c *gin.Context) {
+	var (
+		req request.ClaimQuestRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	questID := req.QuestID.Hex()
+	quest, err := h.questUsecase.GetClaimableQuest(&uID, &questID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	// The rewards are paid before the quest is marked claimed. The XP ledger
+	// and the wallet pay a quest only once, so a failed claim can be retried.
+	entry := &entity.XPEntry{
+		Source: entity.XP_SOURCE_QUEST,
+		Amount: quest.RewardXP,
+		RefID:  quest.ID,
+	}
+	user, err := h.userUsecase.AddXPToUser(&uID, entry, false)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	// Only an entry the ledger recorded gets an ID, so a retry doesn't count
+	// the league XP again.
+	if !entry.ID.IsZero() {
+		err = h.leagueUsecase.RecordXP(&uID, quest.RewardXP)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+			return
+		}
+	}
+	if quest.RewardCoins > 0 {
+		_, err = h.walletUsecase.Credit(&uID, quest.RewardCoins, entity.WALLET_SOURCE_QUEST, quest.ID, entity.WALLET_SOURCE_QUEST+":"+quest.ID.Hex())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+			return
+		}
+	}
+	quest, err = h.questUsecase.ClaimQuest(&uID, &questID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.ClaimQuestResponse{Quest: *quest, User: *user})
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+	"vietcard-backend/internal/domain/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newDeckRatingResponse(deck *entity.Deck, myRating int) response.DeckRatingResponse {
+	return response.DeckRatingResponse{
+		DeckID:         deck.ID.Hex(),
+		MyRating:       myRating,
+		Rating:         deck.Rating,
+		RatingCount:    deck.RatingCount,
+		BayesianRating: deck.BayesianRating,
+	}
+}
+
+// RateDeck	godoc
+// RateDeck	API
+//
+//	@Summary		Rate Deck
+//	@Description	Rate a public deck from 1 to 5. Rating again replaces the previous rating
+//	@Tags			deck
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/deck/rate [put]
+//	@Param			rate_deck_request	body		request.RateDeckRequest	true	"Rate Deck Request"
+//	@Success		200					{object}	response.DeckRatingResponse
+//	@Failure		400					{object}	response.ErrorResponse
+//	@Failure		500					{object}	response.ErrorResponse
+func (h *restHandler) RateDeck(c *gin.Context) {
+	var (
+		req request.RateDeckRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	deckID := req.DeckID.Hex()
+	deck, err := h.ratingUsecase.RateDeck(&uID, &deckID, req.Rating)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newDeckRatingResponse(deck, req.Rating))
+}
+
+// GetDeckRating	godoc
+// GetDeckRating	API
+//
+//	@Summary		Get Deck Rating
+//	@Description	Get the aggregated rating of a deck and the logged in user's own rating (0 if not rated)
+//	@Tags			deck
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/deck/rating [get]
+//	@Param			deck_id	query		string	true	"Deck ID"
+//	@Success		200		{object}	response.DeckRatingResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		404		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+func (h *restHandler) GetDeckRating(c *gin.Context) {
+	var (
+		req request.GetDeckRatingRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	deck, err := h.deckUsecase.GetDeckByID(&req.DeckID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if deck == nil || (!deck.IsPublic && deck.UserID.Hex() != uID) {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Deck not found"})
+		return
+	}
+
+	myRating, err := h.ratingUsecase.GetMyDeckRating(&uID, &req.DeckID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	rating := 0
+	if myRating != nil {
+		rating = myRating.Rating
+	}
+
+	c.JSON(http.StatusOK, newDeckRatingResponse(deck, rating))
+}
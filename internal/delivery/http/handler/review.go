@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+	"vietcard-backend/internal/domain/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetFlaggedReviews	godoc
+// GetFlaggedReviews	API
+//
+//	@Summary		Get Flagged Reviews
+//	@Description	Get the review sessions flagged as implausible, newest first, optionally of a single user. Admin only
+//	@Tags			moderation
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/admin/review/flagged [get]
+//	@Param			user_id	query		string	false	"User ID"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			cursor	query		string	false	"Cursor from the previous page"
+//	@Success		200		{object}	response.GetFlaggedReviewsResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		403		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+func (h *restHandler) GetFlaggedReviews(c *gin.Context) {
+	var (
+		req request.GetFlaggedReviewsRequest
+		err error
+	)
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	reviews, nextCursor, err := h.reviewUsecase.GetFlaggedReviews(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.GetFlaggedReviewsResponse{Reviews: *reviews, NextCursor: nextCursor})
+}
+
+const DEFAULT_ACTIVITY_DAYS = 365
+
+// GetActivity	godoc
+// GetActivity	API
+//
+//	@Summary		Get Activity
+//	@Description	Get the logged in user's reviews, new cards and XP per day for a heatmap, with the current streak, longest streak and number of days ever studied. Days are local to the time zone
+//	@Tags			user
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/user/activity [get]
+//	@Param			from	query		string	false	"First day, YYYY-MM-DD. A year before the last day by default"
+//	@Param			to		query		string	false	"Last day, YYYY-MM-DD. Today by default"
+//	@Param			tz		query		string	false	"IANA time zone, Asia/Ho_Chi_Minh by default"
+//	@Success		200		{object}	response.GetActivityResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+func (h *restHandler) GetActivity(c *gin.Context) {
+	var (
+		req request.GetActivityRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if req.TimeZone == "" {
+		req.TimeZone = entity.DEFAULT_ACTIVITY_TIMEZONE
+	}
+	loc, err := time.LoadLocation(req.TimeZone)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "Invalid time zone"})
+		return
+	}
+
+	now := time.Now().In(loc)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if req.To != "" {
+		to, err = time.ParseInLocation(entity.ACTIVITY_DAY_LAYOUT, req.To, loc)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "Invalid to date"})
+			return
+		}
+	}
+	from := to.AddDate(0, 0, 1-DEFAULT_ACTIVITY_DAYS)
+	if req.From != "" {
+		from, err = time.ParseInLocation(entity.ACTIVITY_DAY_LAYOUT, req.From, loc)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "Invalid from date"})
+			return
+		}
+	}
+	if from.After(to) || from.AddDate(0, 0, entity.MAX_ACTIVITY_DAYS-1).Before(to) {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "The range must be from 1 to 366 days"})
+		return
+	}
+
+	activity, err := h.reviewUsecase.GetActivity(&uID, from, to, loc)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.GetActivityResponse{Activity: *activity})
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+	"vietcard-backend/pkg/textutil"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Search	godoc
+// Search	API
+//
+//	@Summary		Search Decks And Cards
+//	@Description	Full-text search over deck names/descriptions and card questions/answers, ignoring Vietnamese diacritics
+//	@Tags			search
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/search [get]
+//	@Param			search_request	query		request.SearchRequest	true	"Search Request"
+//	@Success		200				{object}	response.SearchResponse
+//	@Failure		400				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+func (h *restHandler) Search(c *gin.Context) {
+	var (
+		req request.SearchRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if len(textutil.Terms(req.Query)) == 0 {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "Search query has no searchable words"})
+		return
+	}
+
+	decks, cards, err := h.searchUsecase.Search(&uID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	resp := response.SearchResponse{
+		Decks: *decks,
+		Cards: *cards,
+	}
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+	"vietcard-backend/internal/domain/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Clients send their version in the APP_VERSION_HEADER header so that it shows
+// up in the session list.
+const APP_VERSION_HEADER = "X-App-Version"
+
+func getSessionDevice(c *gin.Context) *entity.SessionDevice {
+	return &entity.SessionDevice{
+		UserAgent:  c.Request.UserAgent(),
+		IP:         c.ClientIP(),
+		AppVersion: c.GetHeader(APP_VERSION_HEADER),
+	}
+}
+
+// LogOut	godoc
+// LogOut	API
+//
+//	@Summary		Log Out
+//	@Description	Revoke the session of the access token. Its refresh token and access tokens stop working
+//	@Tags			user
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/logout [post]
+//	@Success		200	{object}	response.SuccessResponse
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Failure		500	{object}	response.ErrorResponse
+func (h *restHandler) LogOut(c *gin.Context) {
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	sID, err := GetLoggedInSessionID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = h.sessionUsecase.RevokeSession(&uID, &sID, entity.SESSION_REVOKE_LOGOUT)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{Success: true})
+}
+
+// GetSessions	godoc
+// GetSessions	API
+//
+//	@Summary		Get Sessions
+//	@Description	Get the logged in user's active sessions with their device, most recently seen first. The session of the access token is marked as current
+//	@Tags			user
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/user/sessions [get]
+//	@Success		200	{object}	response.GetSessionsResponse
+//	@Failure		500	{object}	response.ErrorResponse
+func (h *restHandler) GetSessions(c *gin.Context) {
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	sID, err := GetLoggedInSessionID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	sessions, err := h.sessionUsecase.GetSessions(&uID, &sID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.GetSessionsResponse{Sessions: *sessions})
+}
+
+// RevokeSession	godoc
+// RevokeSession	API
+//
+//	@Summary		Revoke Session
+//	@Description	Sign out one of the logged in user's sessions
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/user/sessions/revoke [delete]
+//	@Param			revoke_session_request	body		request.RevokeSessionRequest	true	"Revoke Session Request"
+//	@Success		200						{object}	response.SuccessResponse
+//	@Failure		400						{object}	response.ErrorResponse
+//	@Failure		500						{object}	response.ErrorResponse
+func (h *restHandler) RevokeSession(c *gin.Context) {
+	var (
+		req request.RevokeSessionRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	sessionID := req.SessionID.Hex()
+	err = h.sessionUsecase.RevokeSession(&uID, &sessionID, entity.SESSION_REVOKE_USER)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{Success: true})
+}
+
+// RevokeAllSessions	godoc
+// RevokeAllSessions	API
+//
+//	@Summary		Revoke All Sessions
+//	@Description	Sign out all of the logged in user's other sessions, and the current one too if include_current is set
+//	@Tags			user
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/user/sessions/revoke-all [delete]
+//	@Param			include_current	query		bool	false	"Also sign out the current session"
+//	@Success		200				{object}	response.RevokeSessionsResponse
+//	@Failure		400				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+func (h *restHandler) RevokeAllSessions(c *gin.Context) {
+	var (
+		req request.RevokeAllSessionsRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	sID, err := GetLoggedInSessionID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	except := &sID
+	if req.IncludeCurrent {
+		except = nil
+	}
+	numSessions, err := h.sessionUsecase.RevokeSessions(&uID, except, entity.SESSION_REVOKE_USER)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.RevokeSessionsResponse{NumSessions: numSessions})
+}
@@ -0,0 +1,307 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"time"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+	"vietcard-backend/internal/domain/entity"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// openShareLink finds the usable share link of the token and checks its
+// password. Unknown tokens and wrong passwords count towards a lockout of the
+// link and the client IP. On failure it writes the error response and returns
+// nil.
+func (h *restHandler) openShareLink(c *gin.Context, req *request.OpenShareLinkRequest) *entity.ShareLink {
+	locked, err := h.shareUsecase.IsShareLinkLocked(req.Token, c.ClientIP())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return nil
+	}
+	if locked {
+		c.JSON(http.StatusTooManyRequests, response.ErrorResponse{Message: "Too many failed attempts, try again later"})
+		return nil
+	}
+
+	link, err := h.shareUsecase.GetShareLinkByToken(&req.Token)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return nil
+	}
+	if link == nil || !link.IsUsable(time.Now()) {
+		h.recordFailedShareAttempt(c, req)
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Share link not found, expired or revoked"})
+		return nil
+	}
+	if link.HasPassword && bcrypt.CompareHashAndPassword([]byte(link.HashedPassword), []byte(req.Password)) != nil {
+		h.recordFailedShareAttempt(c, req)
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Message: "Wrong share link password"})
+		return nil
+	}
+	return link
+}
+
+func (h *restHandler) recordFailedShareAttempt(c *gin.Context, req *request.OpenShareLinkRequest) {
+	if err := h.shareUsecase.RecordFailedShareAttempt(req.Token, c.ClientIP()); err != nil {
+		log.Printf("Failed to record share link attempt: %v", err)
+	}
+}
+
+// CreateShareLink	godoc
+// CreateShareLink	API
+//
+//	@Summary		Create Share Link
+//	@Description	Create an unguessable link to preview and copy a deck, optionally expiring and protected by a password. Only the owner can share
+//	@Tags			deck
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/deck/share/create [post]
+//	@Param			create_share_link_request	body		request.CreateShareLinkRequest	true	"Create Share Link Request"
+//	@Success		200							{object}	response.ShareLinkResponse
+//	@Failure		400							{object}	response.ErrorResponse
+//	@Failure		500							{object}	response.ErrorResponse
+func (h *restHandler) CreateShareLink(c *gin.Context) {
+	var (
+		req request.CreateShareLinkRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	link := &entity.ShareLink{
+		DeckID: *req.DeckID,
+	}
+	if req.ExpiresInHour > 0 {
+		expiresAt := time.Now().Add(time.Duration(req.ExpiresInHour) * time.Hour)
+		link.ExpiresAt = &expiresAt
+	}
+	if req.Password != "" {
+		hashedPassword, err := bcrypt.GenerateFromPassword(
+			[]byte(req.Password),
+			bcrypt.DefaultCost,
+		)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+			return
+		}
+		link.HashedPassword = string(hashedPassword)
+	}
+
+	link, err = h.shareUsecase.CreateShareLink(&uID, link)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.ShareLinkResponse{ShareLink: *link})
+}
+
+// GetShareLinks	godoc
+// GetShareLinks	API
+//
+//	@Summary		Get Share Links
+//	@Description	List the share links of a deck, including expired and revoked ones. Only the owner can list
+//	@Tags			deck
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/deck/share/list [get]
+//	@Param			deck_id	query		string	true	"Deck ID"
+//	@Success		200		{object}	response.GetShareLinksResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+func (h *restHandler) GetShareLinks(c *gin.Context) {
+	var (
+		req request.GetShareLinksRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	links, err := h.shareUsecase.GetShareLinks(&uID, &req.DeckID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.GetShareLinksResponse{ShareLinks: *links})
+}
+
+// RevokeShareLink	godoc
+// RevokeShareLink	API
+//
+//	@Summary		Revoke Share Link
+//	@Description	Revoke a share link so it can no longer be used. Only the owner can revoke
+//	@Tags			deck
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/deck/share/revoke [delete]
+//	@Param			revoke_share_link_request	body		request.RevokeShareLinkRequest	true	"Revoke Share Link Request"
+//	@Success		200							{object}	response.SuccessResponse
+//	@Failure		400							{object}	response.ErrorResponse
+//	@Failure		500							{object}	response.ErrorResponse
+func (h *restHandler) RevokeShareLink(c *gin.Context) {
+	var (
+		req request.RevokeShareLinkRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	shareLinkID := req.ShareLinkID.Hex()
+	err = h.shareUsecase.RevokeShareLink(&uID, &shareLinkID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{Success: true})
+}
+
+// PreviewSharedDeck	godoc
+// PreviewSharedDeck	API
+//
+//	@Summary		Preview Shared Deck
+//	@Description	Get a deck and its cards through a share link. Too many wrong passwords or unknown tokens lock the link and the client IP out for a while
+//	@Tags			deck
+//	@Accept			json
+//	@Produce		json
+//	@Router			/api/deck/share/preview [post]
+//	@Param			open_share_link_request	body		request.OpenShareLinkRequest	true	"Open Share Link Request"
+//	@Success		200						{object}	response.SharedDeckResponse
+//	@Failure		400						{object}	response.ErrorResponse
+//	@Failure		401						{object}	response.ErrorResponse
+//	@Failure		404						{object}	response.ErrorResponse
+//	@Failure		429						{object}	response.ErrorResponse
+//	@Failure		500						{object}	response.ErrorResponse
+func (h *restHandler) PreviewSharedDeck(c *gin.Context) {
+	var (
+		req request.OpenShareLinkRequest
+		err error
+	)
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	link := h.openShareLink(c, &req)
+	if link == nil {
+		return
+	}
+	deck, err := h.shareUsecase.GetSharedDeck(link)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if deck == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Deck not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SharedDeckResponse{Deck: *deck})
+}
+
+// CopySharedDeck	godoc
+// CopySharedDeck	API
+//
+//	@Summary		Copy Shared Deck
+//	@Description	Copy a deck, even a private one, through a share link
+//	@Tags			deck
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/deck/share/copy [post]
+//	@Param			open_share_link_request	body		request.OpenShareLinkRequest	true	"Open Share Link Request"
+//	@Success		200						{object}	response.CopyDeckResponse
+//	@Failure		400						{object}	response.ErrorResponse
+//	@Failure		401						{object}	response.ErrorResponse
+//	@Failure		404						{object}	response.ErrorResponse
+//	@Failure		429						{object}	response.ErrorResponse
+//	@Failure		500						{object}	response.ErrorResponse
+func (h *restHandler) CopySharedDeck(c *gin.Context) {
+	var (
+		req request.OpenShareLinkRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	link := h.openShareLink(c, &req)
+	if link == nil {
+		return
+	}
+	shared, err := h.shareUsecase.GetSharedDeck(link)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if shared == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Deck not found"})
+		return
+	}
+	deckID := link.DeckID.Hex()
+	deckWithCards, deckWithReviewCards, err := h.deckUsecase.CopyDeck(&uID, &deckID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if authorID := link.UserID.Hex(); authorID != uID {
+		if _, err = h.achievementUsecase.Evaluate(&authorID, entity.ACHIEVEMENT_EVENT_COPY); err != nil {
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+			return
+		}
+	}
+
+	resp := response.CopyDeckResponse{
+		Deck:       *deckWithCards,
+		DeckReview: *deckWithReviewCards,
+	}
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetShopItems	godoc
+// GetShopItems	API
+//
+//	@Summary		Get Shop Items
+//	@Description	Get the items for sale, cheapest first
+//	@Tags			shop
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/shop/items [get]
+//	@Success		200	{object}	response.GetShopItemsResponse
+//	@Failure		500	{object}	response.ErrorResponse
+func (h *restHandler) GetShopItems(c *gin.Context) {
+	items, err := h.walletUsecase.GetShopItems(true)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.GetShopItemsResponse{Items: *items})
+}
+
+// PurchaseItem	godoc
+// PurchaseItem	API
+//
+//	@Summary		Purchase Item
+//	@Description	Buy a shop item with coins. Retrying with the same idempotency key returns the first purchase without charging again
+//	@Tags			shop
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/shop/purchase [post]
+//	@Param			purchase_item_request	body		request.PurchaseItemRequest	true	"Purchase Item Request"
+//	@Success		200						{object}	response.PurchaseItemResponse
+//	@Failure		400						{object}	response.ErrorResponse
+//	@Failure		500						{object}	response.ErrorResponse
+func (h *restHandler) PurchaseItem(c *gin.Context) {
+	var (
+		req request.PurchaseItemRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	purchase, err := h.walletUsecase.PurchaseItem(&uID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.PurchaseItemResponse{Purchase: *purchase})
+}
+
+// GetAllShopItems	godoc
+// GetAllShopItems	API
+//
+//	@Summary		Get All Shop Items
+//	@Description	Get every shop item, including the ones not for sale. Admin only
+//	@Tags			shop
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/admin/shop/items [get]
+//	@Success		200	{object}	response.GetShopItemsResponse
+//	@Failure		403	{object}	response.ErrorResponse
+//	@Failure		500	{object}	response.ErrorResponse
+func (h *restHandler) GetAllShopItems(c *gin.Context) {
+	items, err := h.walletUsecase.GetShopItems(false)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.GetShopItemsResponse{Items: *items})
+}
+
+// CreateShopItem	godoc
+// CreateShopItem	API
+//
+//	@Summary		Create Shop Item
+//	@Description	Add an item to the shop. Admin only
+//	@Tags			shop
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/admin/shop/item/create [post]
+//	@Param			create_shop_item_request	body		request.CreateShopItemRequest	true	"Create Shop Item Request"
+//	@Success		200							{object}	response.ShopItemResponse
+//	@Failure		400							{object}	response.ErrorResponse
+//	@Failure		403							{object}	response.ErrorResponse
+func (h *restHandler) CreateShopItem(c *gin.Context) {
+	var (
+		req request.CreateShopItemRequest
+		err error
+	)
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	item, err := h.walletUsecase.CreateShopItem(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.ShopItemResponse{Item: *item})
+}
+
+// UpdateShopItem	godoc
+// UpdateShopItem	API
+//
+//	@Summary		Update Shop Item
+//	@Description	Update a shop item, or take it off sale. Admin only
+//	@Tags			shop
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/admin/shop/item/update [put]
+//	@Param			update_shop_item_request	body		request.UpdateShopItemRequest	true	"Update Shop Item Request"
+//	@Success		200							{object}	response.ShopItemResponse
+//	@Failure		400							{object}	response.ErrorResponse
+//	@Failure		403							{object}	response.ErrorResponse
+func (h *restHandler) UpdateShopItem(c *gin.Context) {
+	var (
+		req request.UpdateShopItemRequest
+		err error
+	)
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	item, err := h.walletUsecase.UpdateShopItem(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.ShopItemResponse{Item: *item})
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+const DEFAULT_STREAK_HISTORY_DAYS = 30
+
+// GetStreak	godoc
+// GetStreak	API
+//
+//	@Summary		Get Streak
+//	@Description	Get the logged in user's streak, streak freezes, the streak that can still be repaired and which of the last days were studied, frozen, missed or repaired
+//	@Tags			user
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/user/streak [get]
+//	@Param			days	query		int	false	"Number of days of history, 30 by default"
+//	@Success		200		{object}	response.GetStreakResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+func (h *restHandler) GetStreak(c *gin.Context) {
+	var (
+		req request.GetStreakRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if req.Days == 0 {
+		req.Days = DEFAULT_STREAK_HISTORY_DAYS
+	}
+
+	streak, err := h.streakUsecase.GetStreak(&uID, req.Days)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.GetStreakResponse{Streak: *streak})
+}
+
+// RepairStreak	godoc
+// RepairStreak	API
+//
+//	@Summary		Repair Streak
+//	@Description	Restore the streak lost on the last missed days. Only available for a limited time after the streak was lost and once in a while
+//	@Tags			user
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/user/streak/repair [post]
+//	@Success		200	{object}	response.RepairStreakResponse
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Failure		500	{object}	response.ErrorResponse
+func (h *restHandler) RepairStreak(c *gin.Context) {
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	user, err := h.streakUsecase.RepairStreak(&uID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.RepairStreakResponse{User: *user})
+}
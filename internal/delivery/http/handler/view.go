@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+const DEFAULT_VIEW_TREND_DAYS = 30
+
+// ViewDeck	godoc
+// ViewDeck	API
+//
+//	@Summary		View Deck
+//	@Description	Record a view of a public deck. Views are counted once per user, or per anonymous IP and user agent, per time window
+//	@Tags			deck
+//	@Accept			json
+//	@Produce		json
+//	@Router			/api/deck/view [post]
+//	@Param			view_deck_request	body		request.ViewDeckRequest	true	"View Deck Request"
+//	@Success		200					{object}	response.ViewDeckResponse
+//	@Failure		400					{object}	response.ErrorResponse
+//	@Failure		404					{object}	response.ErrorResponse
+//	@Failure		500					{object}	response.ErrorResponse
+func (h *restHandler) ViewDeck(c *gin.Context) {
+	var (
+		req request.ViewDeckRequest
+		err error
+	)
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	uID, _ := GetLoggedInUserID(c)
+	deckID := req.DeckID.Hex()
+	counted, deck, err := h.viewUsecase.RecordDeckView(&deckID, uID, c.ClientIP(), c.Request.UserAgent(), req.Fingerprint)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if deck == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Deck not found"})
+		return
+	}
+
+	resp := response.ViewDeckResponse{
+		Counted: counted,
+		Views:   deck.Views,
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetDeckViewTrend	godoc
+// GetDeckViewTrend	API
+//
+//	@Summary		Get Deck View Trend
+//	@Description	Get daily view counts of a deck for the last days (default 30)
+//	@Tags			deck
+//	@Produce		json
+//	@Router			/api/deck/view-trend [get]
+//	@Param			get_deck_view_trend_request	query		request.GetDeckViewTrendRequest	true	"Deck View Trend Request"
+//	@Success		200							{object}	response.DeckViewTrendResponse
+//	@Failure		400							{object}	response.ErrorResponse
+//	@Failure		404							{object}	response.ErrorResponse
+//	@Failure		500							{object}	response.ErrorResponse
+func (h *restHandler) GetDeckViewTrend(c *gin.Context) {
+	var (
+		req request.GetDeckViewTrendRequest
+		err error
+	)
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if req.Days == 0 {
+		req.Days = DEFAULT_VIEW_TREND_DAYS
+	}
+
+	uID, _ := GetLoggedInUserID(c)
+	deck, err := h.deckUsecase.GetDeckByID(&req.DeckID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if deck == nil || (!deck.IsPublic && deck.UserID.Hex() != uID) {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Message: "Deck not found"})
+		return
+	}
+
+	days, err := h.viewUsecase.GetDeckViewTrend(&req.DeckID, req.Days)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	resp := response.DeckViewTrendResponse{
+		Days: *days,
+	}
+	c.JSON(http.StatusOK, resp)
+}
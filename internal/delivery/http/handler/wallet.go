@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"net/http"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetWallet	godoc
+// GetWallet	API
+//
+//	@Summary		Get Wallet
+//	@Description	Get the logged in user's coin balance, owned items and ledger of earned and spent coins, newest first
+//	@Tags			wallet
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/wallet [get]
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			cursor	query		string	false	"Cursor from the previous page"
+//	@Success		200		{object}	response.GetWalletResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+func (h *restHandler) GetWallet(c *gin.Context) {
+	var (
+		req request.GetWalletRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	wallet, items, err := h.walletUsecase.GetWallet(&uID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	ledger, nextCursor, err := h.walletUsecase.GetLedger(&uID, req.Limit, req.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	resp := response.GetWalletResponse{
+		Wallet:     *wallet,
+		Items:      *items,
+		Ledger:     *ledger,
+		NextCursor: nextCursor,
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetUserLedger	godoc
+// GetUserLedger	API
+//
+//	@Summary		Get User Ledger
+//	@Description	Get the ledger of earned and spent coins of a user, newest first. Admin only
+//	@Tags			wallet
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/admin/wallet/ledger [get]
+//	@Param			user_id	query		string	true	"User ID"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			cursor	query		string	false	"Cursor from the previous page"
+//	@Success		200		{object}	response.GetLedgerResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		403		{object}	response.ErrorResponse
+func (h *restHandler) GetUserLedger(c *gin.Context) {
+	var (
+		req request.GetLedgerRequest
+		err error
+	)
+
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	ledger, nextCursor, err := h.walletUsecase.GetLedger(&req.UserID, req.Limit, req.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.GetLedgerResponse{Ledger: *ledger, NextCursor: nextCursor})
+}
+
+// GrantCoins	godoc
+// GrantCoins	API
+//
+//	@Summary		Grant Coins
+//	@Description	Give coins to a user, or take them away with a negative amount. Retrying with the same idempotency key grants only once. Admin only
+//	@Tags			wallet
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Router			/api/admin/wallet/grant [post]
+//	@Param			grant_coins_request	body		request.GrantCoinsRequest	true	"Grant Coins Request"
+//	@Success		200					{object}	response.GrantCoinsResponse
+//	@Failure		400					{object}	response.ErrorResponse
+//	@Failure		403					{object}	response.ErrorResponse
+//	@Failure		500					{object}	response.ErrorResponse
+func (h *restHandler) GrantCoins(c *gin.Context) {
+	var (
+		req request.GrantCoinsRequest
+		err error
+	)
+
+	uID, err := GetLoggedInUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = c.ShouldBind(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	entry, err := h.walletUsecase.GrantCoins(&uID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.GrantCoinsResponse{Entry: *entry})
+}
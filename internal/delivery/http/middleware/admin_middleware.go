@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+	"vietcard-backend/internal/domain/interface/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminMiddleware must run after JwtAuthMiddleware and only lets admins
+// through.
+func AdminMiddleware(userRepository repository.UserRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString("x-user-id")
+		user, err := userRepository.GetByID(&userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Message: err.Error()})
+			c.Abort()
+			return
+		}
+		if user == nil || !user.IsAdmin {
+			c.JSON(http.StatusForbidden, ErrorResponse{Message: "Admin only"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
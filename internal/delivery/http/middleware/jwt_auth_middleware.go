@@ -1,40 +1,97 @@
 package middleware
 
 import (
+	"log"
 	"net/http"
 	"strings"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
 	"vietcard-backend/pkg/tokenutil"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type ErrorResponse struct {
 	Message string `json:"error"`
 }
 
-func JwtAuthMiddleware(secret string) gin.HandlerFunc {
+// checkSession tells if the session of the access token is still active and
+// updates its last seen time now and then.
+func checkSession(sessionRepository repository.SessionRepository, claims *tokenutil.JwtCustomClaims, ip string) (bool, error) {
+	sID, err := primitive.ObjectIDFromHex(claims.SessionID)
+	if err != nil {
+		return false, nil
+	}
+	session, err := sessionRepository.GetSession(sID)
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	if session == nil || !session.IsActive(now) || session.UserID.Hex() != claims.ID {
+		return false, nil
+	}
+	if now.Sub(session.LastSeenAt) >= entity.SESSION_SEEN_INTERVAL_MINUTE*time.Minute {
+		if err := sessionRepository.SeeSession(sID, ip, now); err != nil {
+			log.Printf("Failed to update last seen time of session %s: %v", claims.SessionID, err)
+		}
+	}
+	return true, nil
+}
+
+// JwtAuthMiddleware sets x-user-id and x-session-id from the access token and
+// rejects tokens whose session was revoked.
+func JwtAuthMiddleware(secret string, sessionRepository repository.SessionRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.Request.Header.Get("Authorization")
 		t := strings.Split(authHeader, " ")
 		if len(t) == 2 {
 			authToken := t[1]
-			authorized, err := tokenutil.IsAuthorized(&authToken, &secret)
-			if authorized {
-				userID, err := tokenutil.ExtractIDFromToken(&authToken, &secret)
-				if err != nil {
-					c.JSON(http.StatusUnauthorized, ErrorResponse{Message: err.Error()})
-					c.Abort()
-					return
-				}
-				c.Set("x-user-id", userID)
-				c.Next()
+			claims, err := tokenutil.ExtractClaimsFromToken(&authToken, &secret)
+			if err != nil {
+				c.JSON(http.StatusUnauthorized, ErrorResponse{Message: err.Error()})
+				c.Abort()
+				return
+			}
+			active, err := checkSession(sessionRepository, claims, c.ClientIP())
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, ErrorResponse{Message: err.Error()})
+				c.Abort()
+				return
+			}
+			if !active {
+				c.JSON(http.StatusUnauthorized, ErrorResponse{Message: "Session has expired or been revoked"})
+				c.Abort()
 				return
 			}
-			c.JSON(http.StatusUnauthorized, ErrorResponse{Message: err.Error()})
-			c.Abort()
+			c.Set("x-user-id", claims.ID)
+			c.Set("x-session-id", claims.SessionID)
+			c.Next()
 			return
 		}
 		c.JSON(http.StatusUnauthorized, ErrorResponse{Message: "Not authorized"})
 		c.Abort()
 	}
 }
+
+// OptionalJwtAuthMiddleware sets x-user-id when a valid access token of an
+// active session is present but lets anonymous requests through.
+func OptionalJwtAuthMiddleware(secret string, sessionRepository repository.SessionRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.Request.Header.Get("Authorization")
+		t := strings.Split(authHeader, " ")
+		if len(t) == 2 {
+			authToken := t[1]
+			claims, err := tokenutil.ExtractClaimsFromToken(&authToken, &secret)
+			if err == nil {
+				active, err := checkSession(sessionRepository, claims, c.ClientIP())
+				if err == nil && active {
+					c.Set("x-user-id", claims.ID)
+					c.Set("x-session-id", claims.SessionID)
+				}
+			}
+		}
+		c.Next()
+	}
+}
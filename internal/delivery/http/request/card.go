@@ -1,6 +1,10 @@
 package request
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
 
 type CreateCardRequest struct {
 	UserID           primitive.ObjectID `json:"user_id" swaggerignore:"true"`
@@ -11,23 +15,33 @@ type CreateCardRequest struct {
 	Question         string             `json:"question" binding:"required"`
 	Answer           string             `json:"answer" binding:"required"`
 	WrongAnswers     []string           `json:"wrong_answers" binding:"required"`
+	Location         *entity.GeoPoint   `json:"location"`
+	Region           *entity.Region     `json:"region"`
 }
 
 type UpdateCardRequest struct {
 	CardID           *primitive.ObjectID `json:"card_id" bson:"_id,omitempty" binding:"required"`
 	DeckID           *primitive.ObjectID `json:"deck_id" bson:"deck_id,omitempty"`
+	UserID           *primitive.ObjectID `json:"-" bson:"user_id,omitempty"`
 	QuestionImgURL   *string             `json:"question_img_url" bson:"question_img_url,omitempty"`
 	QuestionImgLabel string              `json:"question_img_label" bson:"question_img_label,omitempty"`
 	Question         *string             `json:"question" bson:"question,omitempty"`
 	Answer           *string             `json:"answer" bson:"answer,omitempty"`
 	WrongAnswers     *[]string           `json:"wrong_answers" bson:"wrong_answers,omitempty"`
+	Location         *entity.GeoPoint    `json:"location" bson:"location,omitempty"`
+	Region           *entity.Region      `json:"region" bson:"region,omitempty"`
+	ClearLocation    bool                `json:"clear_location" bson:"-" binding:"excluded_with=Location"`
+	ClearRegion      bool                `json:"clear_region" bson:"-" binding:"excluded_with=Region"`
 }
 
+// UpdateReviewCardsRequest.TotalXP is the XP the client computed. The server
+// computes the XP itself and only uses it to flag mismatches.
 type UpdateReviewCardsRequest struct {
-	DeckID    primitive.ObjectID   `json:"deck_id" binding:"required"`
-	TotalXP   int                  `json:"total_xp"`
-	CardIDs   []primitive.ObjectID `json:"card_ids" binding:"required"`
-	IsCorrect []bool               `json:"is_correct" binding:"required"`
+	DeckID     primitive.ObjectID   `json:"deck_id" binding:"required"`
+	TotalXP    int                  `json:"total_xp"`
+	DurationMs int                  `json:"duration_ms" binding:"min=0"`
+	CardIDs    []primitive.ObjectID `json:"card_ids" binding:"required,unique"`
+	IsCorrect  []bool               `json:"is_correct" binding:"required"`
 }
 
 type CopyCardToDeckRequest struct {
@@ -0,0 +1,27 @@
+package request
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type CreateCommentRequest struct {
+	DeckID   *primitive.ObjectID `json:"deck_id" binding:"required"`
+	CardID   *primitive.ObjectID `json:"card_id"`
+	ParentID *primitive.ObjectID `json:"parent_id"`
+	Content  string              `json:"content" binding:"required,max=2000"`
+}
+
+type UpdateCommentRequest struct {
+	CommentID *primitive.ObjectID `json:"comment_id" binding:"required"`
+	Content   string              `json:"content" binding:"required,max=2000"`
+}
+
+type DeleteCommentRequest struct {
+	CommentID *primitive.ObjectID `json:"comment_id" binding:"required"`
+}
+
+type GetCommentsRequest struct {
+	DeckID   string `form:"deck_id" binding:"required"`
+	CardID   string `form:"card_id"`
+	ParentID string `form:"parent_id"`
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor   string `form:"cursor"`
+}
@@ -2,6 +2,7 @@ package request
 
 import (
 	"time"
+	"vietcard-backend/internal/domain/entity"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -12,7 +13,9 @@ type CreateDeckRequest struct {
 	Description         string             `json:"description"`
 	DescriptionImageURL string             `json:"description_img_url"`
 	Position            string             `json:"position"`
-	TotalCards          int                `json:"total_cards"`
+	Location            *entity.GeoPoint   `json:"location"`
+	Region              *entity.Region     `json:"region"`
+	Tags                []string           `json:"tags"`
 }
 
 type UpdateDeckRequest struct {
@@ -23,14 +26,17 @@ type UpdateDeckRequest struct {
 	Description         *string             `json:"description" bson:"description,omitempty"`
 	DescriptionImageURL *string             `json:"description_img_url" bson:"description_img_url,omitempty"`
 	Position            *string             `json:"position" bson:"position,omitempty"`
+	Location            *entity.GeoPoint    `json:"location" bson:"location,omitempty"`
+	Region              *entity.Region      `json:"region" bson:"region,omitempty"`
+	ClearLocation       bool                `json:"clear_location" bson:"-" binding:"excluded_with=Location"`
+	ClearRegion         bool                `json:"clear_region" bson:"-" binding:"excluded_with=Region"`
+	Tags                *[]string           `json:"tags" bson:"tags,omitempty"`
 	TotalLearnedCards   *int                `json:"total_learned_cards" bson:"total_learned_cards,omitempty"`
 	MaxNewCards         *int                `json:"max_new_cards" bson:"max_new_cards,omitempty"`
 	MaxReviewCards      *int                `json:"max_review_cards" bson:"max_review_cards,omitempty"`
 	LastReview          *time.Time          `json:"last_review" bson:"last_review,omitempty"`
 	CurNewCards         *int                `json:"cur_new_cards" bson:"cur_new_cards,omitempty"`
 	CurReviewCards      *int                `json:"cur_review_cards" bson:"cur_review_cards,omitempty"`
-	Views               *int                `json:"views" bson:"views,omitempty"`
-	Rating              *float32            `json:"rating" bson:"rating,omitempty"`
 }
 
 type CopyDeckRequest struct {
@@ -41,7 +47,31 @@ type DeleteDeckRequest struct {
 	DeckID *primitive.ObjectID `json:"deck_id" binding:"required"`
 }
 
-type UpdateViewDeckRequest struct {
-	DeckID *primitive.ObjectID `json:"deck_id" bson:"_id,omitempty" binding:"required"`
-	Views  *int                `json:"views" bson:"views,omitempty"`
+type ViewDeckRequest struct {
+	DeckID      *primitive.ObjectID `json:"deck_id" binding:"required"`
+	Fingerprint string              `json:"fingerprint"`
+}
+
+type GetDeckViewTrendRequest struct {
+	DeckID string `form:"deck_id" binding:"required"`
+	Days   int    `form:"days" binding:"omitempty,min=1,max=365"`
+}
+
+type DeckCatalogRequest struct {
+	Sort     string `form:"sort" binding:"omitempty,oneof=views rating newest copies"`
+	Tag      string `form:"tag"`
+	AuthorID string `form:"author_id"`
+	MinCards *int   `form:"min_cards" binding:"omitempty,min=0"`
+	MaxCards *int   `form:"max_cards" binding:"omitempty,min=0"`
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor   string `form:"cursor"`
+}
+
+type RateDeckRequest struct {
+	DeckID *primitive.ObjectID `json:"deck_id" binding:"required"`
+	Rating int                 `json:"rating" binding:"required,min=1,max=5"`
+}
+
+type GetDeckRatingRequest struct {
+	DeckID string `form:"deck_id" binding:"required"`
 }
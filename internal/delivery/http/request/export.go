@@ -0,0 +1,9 @@
+package request
+
+type GetExportJobRequest struct {
+	JobID string `form:"job_id" binding:"required"`
+}
+
+type DownloadExportRequest struct {
+	Token string `form:"token" binding:"required"`
+}
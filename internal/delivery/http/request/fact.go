@@ -1,5 +1,41 @@
 package request
 
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
 type CreateFactRequest struct {
-	Content string `json:"content" binding:"required"`
+	Content   string              `json:"content" binding:"required,max=2000"`
+	Category  string              `json:"category" binding:"max=50"`
+	Source    string              `json:"source" binding:"max=500"`
+	SourceURL string              `json:"source_url" binding:"omitempty,url"`
+	DeckID    *primitive.ObjectID `json:"deck_id"`
+	Locale    string              `json:"locale" binding:"omitempty,alpha,len=2"`
+}
+
+type UpdateFactRequest struct {
+	FactID    *primitive.ObjectID `json:"fact_id" binding:"required"`
+	Content   *string             `json:"content" binding:"omitempty,max=2000"`
+	Category  *string             `json:"category" binding:"omitempty,max=50"`
+	Source    *string             `json:"source" binding:"omitempty,max=500"`
+	SourceURL *string             `json:"source_url" binding:"omitempty,url"`
+	DeckID    *primitive.ObjectID `json:"deck_id"`
+	// RemoveDeck unlinks the related deck.
+	RemoveDeck bool    `json:"remove_deck"`
+	Locale     *string `json:"locale" binding:"omitempty,alpha,len=2"`
+}
+
+type DeleteFactRequest struct {
+	FactID *primitive.ObjectID `json:"fact_id" binding:"required"`
+}
+
+type GetFactsRequest struct {
+	Locale   string `form:"locale"`
+	Category string `form:"category"`
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor   string `form:"cursor"`
+}
+
+type GetFactRequest struct {
+	Mode     string `form:"mode" binding:"omitempty,oneof=random daily unseen"`
+	Locale   string `form:"locale"`
+	Category string `form:"category"`
 }
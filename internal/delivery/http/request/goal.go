@@ -0,0 +1,5 @@
+package request
+
+type GetDailyGoalRequest struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=366"`
+}
@@ -0,0 +1,6 @@
+package request
+
+type LeaderboardRequest struct {
+	Scope string `form:"scope" binding:"omitempty,oneof=global friends league"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,6 @@
+package request
+
+type GetXPHistoryRequest struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor string `form:"cursor"`
+}
@@ -0,0 +1,19 @@
+package request
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type InviteMemberRequest struct {
+	DeckID *primitive.ObjectID `json:"deck_id" binding:"required"`
+	UserID *primitive.ObjectID `json:"user_id"`
+	Email  string              `json:"email" binding:"omitempty,email"`
+	Role   string              `json:"role" binding:"required,oneof=editor viewer"`
+}
+
+type RemoveMemberRequest struct {
+	DeckID *primitive.ObjectID `json:"deck_id" binding:"required"`
+	UserID *primitive.ObjectID `json:"user_id" binding:"required"`
+}
+
+type GetMembersRequest struct {
+	DeckID string `form:"deck_id" binding:"required"`
+}
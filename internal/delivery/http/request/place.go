@@ -0,0 +1,24 @@
+package request
+
+type NearbyPlacesRequest struct {
+	Lat      *float64 `form:"lat" binding:"required,min=-90,max=90"`
+	Lng      *float64 `form:"lng" binding:"required,min=-180,max=180"`
+	RadiusKm float64  `form:"radius_km" binding:"omitempty,gt=0,max=500"`
+	Kind     string   `form:"kind" binding:"omitempty,oneof=deck card"`
+	Limit    int      `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type BoundingBoxPlacesRequest struct {
+	MinLat *float64 `form:"min_lat" binding:"required,min=-90,max=90"`
+	MinLng *float64 `form:"min_lng" binding:"required,min=-180,max=180"`
+	MaxLat *float64 `form:"max_lat" binding:"required,min=-90,max=90"`
+	MaxLng *float64 `form:"max_lng" binding:"required,min=-180,max=180"`
+	Kind   string   `form:"kind" binding:"omitempty,oneof=deck card"`
+	Limit  int      `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type ProvincePlacesRequest struct {
+	Province string `form:"province" binding:"required"`
+	Kind     string `form:"kind" binding:"omitempty,oneof=deck card"`
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
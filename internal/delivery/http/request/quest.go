@@ -0,0 +1,7 @@
+package request
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type ClaimQuestRequest struct {
+	QuestID *primitive.ObjectID `json:"quest_id" binding:"required"`
+}
@@ -0,0 +1,29 @@
+package request
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type ReportContentRequest struct {
+	TargetType string              `json:"target_type" binding:"required,oneof=deck card comment"`
+	TargetID   *primitive.ObjectID `json:"target_id" binding:"required"`
+	Reason     string              `json:"reason" binding:"required,oneof=spam inappropriate copyright misinformation other"`
+	Details    string              `json:"details" binding:"max=1000"`
+}
+
+type GetModerationQueueRequest struct {
+	TargetType string `form:"target_type" binding:"omitempty,oneof=deck card comment"`
+	Page       int    `form:"page" binding:"omitempty,min=1"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type ModerateContentRequest struct {
+	TargetType string              `json:"target_type" binding:"required,oneof=deck card comment"`
+	TargetID   *primitive.ObjectID `json:"target_id" binding:"required"`
+	Action     string              `json:"action" binding:"required,oneof=hide restore unpublish warn ban unban dismiss"`
+	Note       string              `json:"note" binding:"max=1000"`
+}
+
+type GetModerationLogRequest struct {
+	TargetID string `form:"target_id"`
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor   string `form:"cursor"`
+}
@@ -0,0 +1,15 @@
+package request
+
+type GetFlaggedReviewsRequest struct {
+	UserID string `form:"user_id"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor string `form:"cursor"`
+}
+
+// GetActivityRequest selects the local days of the heatmap, formatted as
+// YYYY-MM-DD.
+type GetActivityRequest struct {
+	From     string `form:"from"`
+	To       string `form:"to"`
+	TimeZone string `form:"tz"`
+}
@@ -0,0 +1,9 @@
+package request
+
+type SearchRequest struct {
+	Query string `form:"q" binding:"required"`
+	Scope string `form:"scope" binding:"omitempty,oneof=mine public"`
+	Type  string `form:"type" binding:"omitempty,oneof=all decks cards"`
+	Page  int    `form:"page" binding:"omitempty,min=1"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=50"`
+}
@@ -0,0 +1,11 @@
+package request
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type RevokeSessionRequest struct {
+	SessionID *primitive.ObjectID `json:"session_id" binding:"required"`
+}
+
+type RevokeAllSessionsRequest struct {
+	IncludeCurrent bool `form:"include_current"`
+}
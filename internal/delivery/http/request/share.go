@@ -0,0 +1,22 @@
+package request
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type CreateShareLinkRequest struct {
+	DeckID        *primitive.ObjectID `json:"deck_id" binding:"required"`
+	ExpiresInHour int                 `json:"expires_in_hour" binding:"omitempty,min=1,max=8760"`
+	Password      string              `json:"password" binding:"omitempty,min=8,max=72"`
+}
+
+type GetShareLinksRequest struct {
+	DeckID string `form:"deck_id" binding:"required"`
+}
+
+type RevokeShareLinkRequest struct {
+	ShareLinkID *primitive.ObjectID `json:"share_link_id" binding:"required"`
+}
+
+type OpenShareLinkRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password"`
+}
@@ -0,0 +1,5 @@
+package request
+
+type GetStreakRequest struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=366"`
+}
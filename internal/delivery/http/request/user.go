@@ -1,5 +1,7 @@
 package request
 
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
 type LoginRequest struct {
 	Email    string `form:"email" binding:"required,email"`
 	Password string `form:"password" binding:"required"`
@@ -16,12 +18,40 @@ type RefreshTokenRequest struct {
 }
 
 type UpdateUserRequest struct {
-	Name           *string `json:"name" bson:"name,omitempty"`
-	OldPassword    *string `json:"old_password" bson:"old_password,omitempty"`
-	NewPassword    *string `json:"new_password" bson:"new_password,omitempty"`
-	HashedPassword *string `json:"hashed_password" bson:"hashed_password,omitempty" swaggerignore:"true"`
+	Name                *string   `json:"name" bson:"name,omitempty"`
+	Bio                 *string   `json:"bio" bson:"bio,omitempty" binding:"omitempty,max=500"`
+	AvatarURL           *string   `json:"avatar_url" bson:"avatar_url,omitempty"`
+	OldPassword         *string   `json:"old_password" bson:"old_password,omitempty"`
+	NewPassword         *string   `json:"new_password" bson:"new_password,omitempty"`
+	HashedPassword      *string   `json:"hashed_password" bson:"hashed_password,omitempty" swaggerignore:"true"`
+	HideActivity        *bool     `json:"hide_activity" bson:"privacy.hide_activity,omitempty"`
+	BlockFriendRequests *bool     `json:"block_friend_requests" bson:"privacy.block_friend_requests,omitempty"`
+	DailyGoalType       *string   `json:"daily_goal_type" bson:"daily_goal.type,omitempty" binding:"omitempty,oneof=xp reviews"`
+	DailyGoalTarget     *int      `json:"daily_goal_target" bson:"daily_goal.target,omitempty" binding:"omitempty,min=1,max=10000"`
+	StreakRequiresGoal  *bool     `json:"streak_requires_goal" bson:"daily_goal.streak_requires_goal,omitempty"`
+	ReminderEnabled     *bool     `json:"reminder_enabled" bson:"reminder.enabled,omitempty"`
+	ReminderTime        *string   `json:"reminder_time" bson:"reminder.time,omitempty" binding:"omitempty,datetime=15:04"`
+	ReminderWeekdays    *[]int    `json:"reminder_weekdays" bson:"reminder.weekdays,omitempty" binding:"omitempty,max=7,dive,min=0,max=6"`
+	QuietHoursStart     *string   `json:"quiet_hours_start" bson:"reminder.quiet_start,omitempty" binding:"omitempty,datetime=15:04"`
+	QuietHoursEnd       *string   `json:"quiet_hours_end" bson:"reminder.quiet_end,omitempty" binding:"omitempty,datetime=15:04"`
+	TimeZone            *string   `json:"time_zone" bson:"reminder.time_zone,omitempty" binding:"omitempty,timezone"`
+	ReminderChannels    *[]string `json:"reminder_channels" bson:"reminder.channels,omitempty" binding:"omitempty,max=2,unique,dive,oneof=email push"`
+	PushToken           *string   `json:"push_token" bson:"reminder.push_token,omitempty"`
+}
+
+type GetAuthorProfileRequest struct {
+	UserID string `form:"user_id" binding:"required"`
+}
+
+type FollowUserRequest struct {
+	UserID *primitive.ObjectID `json:"user_id" binding:"required"`
+}
+
+type GetFeedRequest struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor string `form:"cursor"`
 }
 
-type AddXPRequest struct {
-	XP int `json:"xp" binding:"required"`
+type FriendUserRequest struct {
+	UserID *primitive.ObjectID `json:"user_id" binding:"required"`
 }
@@ -0,0 +1,52 @@
+package request
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type GetWalletRequest struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor string `form:"cursor"`
+}
+
+type GetLedgerRequest struct {
+	UserID string `form:"user_id" binding:"required"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor string `form:"cursor"`
+}
+
+// GrantCoinsRequest adds coins to a user's wallet, or takes them away if the
+// amount is negative.
+type GrantCoinsRequest struct {
+	UserID         *primitive.ObjectID `json:"user_id" binding:"required"`
+	Amount         int                 `json:"amount" binding:"required"`
+	Note           string              `json:"note" binding:"max=1000"`
+	IdempotencyKey string              `json:"idempotency_key" binding:"required,max=100"`
+}
+
+type CreateShopItemRequest struct {
+	Type         string `json:"type" binding:"required,oneof=streak_freeze xp_boost cosmetic"`
+	Name         string `json:"name" binding:"required,max=100"`
+	Description  string `json:"description" binding:"max=1000"`
+	ImageURL     string `json:"image_url"`
+	Price        int    `json:"price" binding:"required,min=1"`
+	Quantity     int    `json:"quantity" binding:"min=0"`
+	BoostPercent int    `json:"boost_percent" binding:"min=0,max=100"`
+	BoostMinutes int    `json:"boost_minutes" binding:"min=0"`
+	IsActive     *bool  `json:"is_active"`
+}
+
+type UpdateShopItemRequest struct {
+	ItemID       *primitive.ObjectID `json:"item_id" bson:"-" binding:"required"`
+	Name         *string             `json:"name" bson:"name,omitempty" binding:"omitempty,max=100"`
+	Description  *string             `json:"description" bson:"description,omitempty" binding:"omitempty,max=1000"`
+	ImageURL     *string             `json:"image_url" bson:"image_url,omitempty"`
+	Price        *int                `json:"price" bson:"price,omitempty" binding:"omitempty,min=1"`
+	Quantity     *int                `json:"quantity" bson:"quantity,omitempty" binding:"omitempty,min=1"`
+	BoostPercent *int                `json:"boost_percent" bson:"boost_percent,omitempty" binding:"omitempty,min=1,max=100"`
+	BoostMinutes *int                `json:"boost_minutes" bson:"boost_minutes,omitempty" binding:"omitempty,min=1"`
+	IsActive     *bool               `json:"is_active" bson:"is_active,omitempty"`
+}
+
+type PurchaseItemRequest struct {
+	ItemID         *primitive.ObjectID `json:"item_id" binding:"required"`
+	IdempotencyKey string              `json:"idempotency_key" binding:"required,max=100"`
+}
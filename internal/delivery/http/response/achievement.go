@@ -0,0 +1,7 @@
+package response
+
+import "vietcard-backend/internal/domain/entity"
+
+type GetAchievementsResponse struct {
+	Achievements []entity.AchievementProgress `json:"achievements"`
+}
@@ -12,11 +12,15 @@ type UpdateCardResponse struct {
 }
 
 type UpdateReviewCardsResponse struct {
-	Cards         []entity.Card `json:"cards"`
-	NumBlueCards  int           `json:"num_blue_cards"`
-	NumRedCards   int           `json:"num_red_cards"`
-	NumGreenCards int           `json:"num_green_cards"`
-	User          *entity.User  `json:"user"`
+	Cards           []entity.Card                `json:"cards"`
+	NumBlueCards    int                          `json:"num_blue_cards"`
+	NumRedCards     int                          `json:"num_red_cards"`
+	NumGreenCards   int                          `json:"num_green_cards"`
+	User            *entity.User                 `json:"user"`
+	Review          *entity.ReviewLog            `json:"review"`
+	NewBadges       []entity.AchievementProgress `json:"new_badges"`
+	DailyGoal       *entity.DailyProgress        `json:"daily_goal"`
+	CompletedQuests []entity.UserQuest           `json:"completed_quests"`
 }
 
 type CopyCardToDeckResponse struct {
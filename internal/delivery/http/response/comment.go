@@ -0,0 +1,12 @@
+package response
+
+import "vietcard-backend/internal/domain/entity"
+
+type CommentResponse struct {
+	Comment entity.Comment `json:"comment"`
+}
+
+type GetCommentsResponse struct {
+	Comments   []entity.Comment `json:"comments"`
+	NextCursor string           `json:"next_cursor"`
+}
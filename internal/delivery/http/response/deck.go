@@ -23,3 +23,25 @@ type DeleteDeckResponse struct {
 type SuccessResponse struct {
 	Success bool        `json:"success"`
 }
+
+type DeckCatalogResponse struct {
+	Decks      []entity.DeckSummary `json:"decks"`
+	NextCursor string               `json:"next_cursor"`
+}
+
+type DeckRatingResponse struct {
+	DeckID         string  `json:"deck_id"`
+	MyRating       int     `json:"my_rating"`
+	Rating         float32 `json:"rating"`
+	RatingCount    int     `json:"rating_count"`
+	BayesianRating float64 `json:"bayesian_rating"`
+}
+
+type ViewDeckResponse struct {
+	Counted bool `json:"counted"`
+	Views   int  `json:"views"`
+}
+
+type DeckViewTrendResponse struct {
+	Days []entity.DeckViewDaily `json:"days"`
+}
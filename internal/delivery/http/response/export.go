@@ -0,0 +1,8 @@
+package response
+
+import "vietcard-backend/internal/domain/entity"
+
+type ExportJobResponse struct {
+	Job         entity.ExportJob `json:"job"`
+	DownloadURL string           `json:"download_url,omitempty"`
+}
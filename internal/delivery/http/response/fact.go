@@ -1,5 +1,17 @@
 package response
 
+import "vietcard-backend/internal/domain/entity"
+
 type CreateFactResponse struct {
-	Success bool `json:"success"`
+	Success bool        `json:"success"`
+	Fact    entity.Fact `json:"fact"`
+}
+
+type FactResponse struct {
+	Fact entity.Fact `json:"fact"`
+}
+
+type GetFactsResponse struct {
+	Facts      []entity.Fact `json:"facts"`
+	NextCursor string        `json:"next_cursor"`
 }
@@ -0,0 +1,15 @@
+package response
+
+import "vietcard-backend/internal/domain/entity"
+
+type FriendshipResponse struct {
+	Friendship entity.Friendship `json:"friendship"`
+}
+
+type GetFriendsResponse struct {
+	Friends []entity.Friend `json:"friends"`
+}
+
+type FriendsActivityResponse struct {
+	Activities []entity.FriendActivity `json:"activities"`
+}
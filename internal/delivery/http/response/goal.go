@@ -0,0 +1,7 @@
+package response
+
+import "vietcard-backend/internal/domain/entity"
+
+type GetDailyGoalResponse struct {
+	DailyGoal entity.DailyGoalStats `json:"daily_goal"`
+}
@@ -0,0 +1,7 @@
+package response
+
+import "vietcard-backend/internal/domain/entity"
+
+type LeaderboardResponse struct {
+	Leaderboard entity.Leaderboard `json:"leaderboard"`
+}
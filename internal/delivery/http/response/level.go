@@ -0,0 +1,16 @@
+package response
+
+import "vietcard-backend/internal/domain/entity"
+
+type GetXPHistoryResponse struct {
+	LifetimeXP  int              `json:"lifetime_xp"`
+	Level       int              `json:"level"`
+	XP          int              `json:"xp"`
+	XPToLevelUp int              `json:"xp_to_level_up"`
+	Entries     []entity.XPEntry `json:"entries"`
+	NextCursor  string           `json:"next_cursor"`
+}
+
+type RecomputeLevelsResponse struct {
+	NumUsers int `json:"num_users"`
+}
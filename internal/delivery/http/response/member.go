@@ -0,0 +1,15 @@
+package response
+
+import "vietcard-backend/internal/domain/entity"
+
+type DeckMemberResponse struct {
+	Member entity.DeckMember `json:"member"`
+}
+
+type GetMembersResponse struct {
+	Members []entity.DeckMemberInfo `json:"members"`
+}
+
+type GetSharedDecksResponse struct {
+	Decks []entity.DeckWithReviewCards `json:"decks"`
+}
@@ -0,0 +1,7 @@
+package response
+
+import "vietcard-backend/internal/domain/entity"
+
+type PlacesResponse struct {
+	Places []entity.MapPlace `json:"places"`
+}
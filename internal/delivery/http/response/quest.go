@@ -0,0 +1,12 @@
+package response
+
+import "vietcard-backend/internal/domain/entity"
+
+type GetQuestsResponse struct {
+	Quests []entity.UserQuest `json:"quests"`
+}
+
+type ClaimQuestResponse struct {
+	Quest entity.UserQuest `json:"quest"`
+	User  entity.User      `json:"user"`
+}
@@ -0,0 +1,20 @@
+package response
+
+import "vietcard-backend/internal/domain/entity"
+
+type ReportResponse struct {
+	Report entity.Report `json:"report"`
+}
+
+type GetModerationQueueResponse struct {
+	Items []entity.ModerationQueueItem `json:"items"`
+}
+
+type ModerationActionResponse struct {
+	Action entity.ModerationAction `json:"action"`
+}
+
+type GetModerationLogResponse struct {
+	Actions    []entity.ModerationAction `json:"actions"`
+	NextCursor string                    `json:"next_cursor"`
+}
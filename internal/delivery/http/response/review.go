@@ -0,0 +1,12 @@
+package response
+
+import "vietcard-backend/internal/domain/entity"
+
+type GetFlaggedReviewsResponse struct {
+	Reviews    []entity.ReviewLog `json:"reviews"`
+	NextCursor string             `json:"next_cursor"`
+}
+
+type GetActivityResponse struct {
+	Activity entity.ActivityCalendar `json:"activity"`
+}
@@ -0,0 +1,8 @@
+package response
+
+import "vietcard-backend/internal/domain/entity"
+
+type SearchResponse struct {
+	Decks []entity.DeckSearchHit `json:"decks"`
+	Cards []entity.CardSearchHit `json:"cards"`
+}
@@ -0,0 +1,11 @@
+package response
+
+import "vietcard-backend/internal/domain/entity"
+
+type GetSessionsResponse struct {
+	Sessions []entity.Session `json:"sessions"`
+}
+
+type RevokeSessionsResponse struct {
+	NumSessions int `json:"num_sessions"`
+}
@@ -0,0 +1,15 @@
+package response
+
+import "vietcard-backend/internal/domain/entity"
+
+type ShareLinkResponse struct {
+	ShareLink entity.ShareLink `json:"share_link"`
+}
+
+type GetShareLinksResponse struct {
+	ShareLinks []entity.ShareLink `json:"share_links"`
+}
+
+type SharedDeckResponse struct {
+	Deck entity.DeckWithCards `json:"deck"`
+}
@@ -0,0 +1,11 @@
+package response
+
+import "vietcard-backend/internal/domain/entity"
+
+type GetStreakResponse struct {
+	Streak entity.StreakInfo `json:"streak"`
+}
+
+type RepairStreakResponse struct {
+	User entity.User `json:"user"`
+}
@@ -26,5 +26,20 @@ type UpdateUserResponse struct {
 }
 
 type GetFactResponse struct {
-	Fact string `json:"fact"`
+	Fact     string      `json:"fact"`
+	FactInfo entity.Fact `json:"fact_info"`
+}
+
+type AuthorProfileResponse struct {
+	Profile entity.AuthorProfile `json:"profile"`
+}
+
+type FollowUserResponse struct {
+	Following     bool `json:"following"`
+	FollowerCount int  `json:"follower_count"`
+}
+
+type FeedResponse struct {
+	Decks      []entity.DeckSummary `json:"decks"`
+	NextCursor string               `json:"next_cursor"`
 }
@@ -0,0 +1,31 @@
+package response
+
+import "vietcard-backend/internal/domain/entity"
+
+type GetWalletResponse struct {
+	Wallet     entity.Wallet        `json:"wallet"`
+	Items      []entity.UserItem    `json:"items"`
+	Ledger     []entity.LedgerEntry `json:"ledger"`
+	NextCursor string               `json:"next_cursor"`
+}
+
+type GetLedgerResponse struct {
+	Ledger     []entity.LedgerEntry `json:"ledger"`
+	NextCursor string               `json:"next_cursor"`
+}
+
+type GrantCoinsResponse struct {
+	Entry entity.LedgerEntry `json:"entry"`
+}
+
+type GetShopItemsResponse struct {
+	Items []entity.ShopItem `json:"items"`
+}
+
+type ShopItemResponse struct {
+	Item entity.ShopItem `json:"item"`
+}
+
+type PurchaseItemResponse struct {
+	Purchase entity.Purchase `json:"purchase"`
+}
@@ -1,18 +1,65 @@
 package route
 
 import (
+	"time"
 	"vietcard-backend/bootstrap"
 	"vietcard-backend/internal/delivery/http/handler"
 	"vietcard-backend/internal/delivery/http/middleware"
+	"vietcard-backend/internal/delivery/job"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/notifier"
+	"vietcard-backend/internal/notifier/pushnotifier"
+	"vietcard-backend/internal/notifier/smtpnotifier"
+	"vietcard-backend/internal/repository/achievementrepo"
 	"vietcard-backend/internal/repository/cardrepo"
+	"vietcard-backend/internal/repository/commentrepo"
 	"vietcard-backend/internal/repository/deckrepo"
+	"vietcard-backend/internal/repository/exportrepo"
+	"vietcard-backend/internal/repository/factrepo"
+	"vietcard-backend/internal/repository/followrepo"
+	"vietcard-backend/internal/repository/friendrepo"
+	"vietcard-backend/internal/repository/goalrepo"
+	"vietcard-backend/internal/repository/leaguerepo"
+	"vietcard-backend/internal/repository/memberrepo"
+	"vietcard-backend/internal/repository/questrepo"
+	"vietcard-backend/internal/repository/ratingrepo"
+	"vietcard-backend/internal/repository/reportrepo"
+	"vietcard-backend/internal/repository/reviewrepo"
+	"vietcard-backend/internal/repository/sessionrepo"
+	"vietcard-backend/internal/repository/sharerepo"
+	"vietcard-backend/internal/repository/shoprepo"
+	"vietcard-backend/internal/repository/streakrepo"
 	"vietcard-backend/internal/repository/userrepo"
+	"vietcard-backend/internal/repository/viewrepo"
+	"vietcard-backend/internal/repository/walletrepo"
+	"vietcard-backend/internal/repository/xprepo"
+	"vietcard-backend/internal/usecase/achievement"
 	"vietcard-backend/internal/usecase/card"
+	"vietcard-backend/internal/usecase/comment"
 	"vietcard-backend/internal/usecase/deck"
+	"vietcard-backend/internal/usecase/export"
+	"vietcard-backend/internal/usecase/fact"
+	"vietcard-backend/internal/usecase/friend"
+	"vietcard-backend/internal/usecase/goal"
+	"vietcard-backend/internal/usecase/league"
 	"vietcard-backend/internal/usecase/login"
+	"vietcard-backend/internal/usecase/member"
+	"vietcard-backend/internal/usecase/moderation"
+	"vietcard-backend/internal/usecase/place"
+	"vietcard-backend/internal/usecase/profile"
+	"vietcard-backend/internal/usecase/quest"
+	"vietcard-backend/internal/usecase/rating"
 	"vietcard-backend/internal/usecase/refreshtkn"
+	"vietcard-backend/internal/usecase/reminder"
+	"vietcard-backend/internal/usecase/review"
+	"vietcard-backend/internal/usecase/search"
+	"vietcard-backend/internal/usecase/session"
+	"vietcard-backend/internal/usecase/share"
 	"vietcard-backend/internal/usecase/signup"
+	"vietcard-backend/internal/usecase/streak"
 	"vietcard-backend/internal/usecase/user"
+	"vietcard-backend/internal/usecase/view"
+	"vietcard-backend/internal/usecase/wallet"
 
 	_ "vietcard-backend/docs"
 
@@ -44,15 +91,87 @@ func Setup(db *mongo.Database, gin *gin.Engine) {
 	userRP := userrepo.NewUserRepository(db)
 	cardRP := cardrepo.NewCardRepository(db)
 	deckRP := deckrepo.NewDeckRepository(db)
+	exportRP := exportrepo.NewExportRepository(db)
+	ratingRP := ratingrepo.NewRatingRepository(db)
+	viewRP := viewrepo.NewViewRepository(db)
+	commentRP := commentrepo.NewCommentRepository(db)
+	followRP := followrepo.NewFollowRepository(db)
+	leagueRP := leaguerepo.NewLeagueRepository(db)
+	friendRP := friendrepo.NewFriendRepository(db)
+	memberRP := memberrepo.NewMemberRepository(db)
+	shareRP := sharerepo.NewShareRepository(db)
+	reportRP := reportrepo.NewReportRepository(db)
+	reviewRP := reviewrepo.NewReviewRepository(db)
+	achievementRP := achievementrepo.NewAchievementRepository(db)
+	streakRP := streakrepo.NewStreakRepository(db)
+	goalRP := goalrepo.NewGoalRepository(db)
+	questRP := questrepo.NewQuestRepository(db)
+	walletRP := walletrepo.NewWalletRepository(db)
+	shopRP := shoprepo.NewShopRepository(db)
+	xpRP := xprepo.NewXPRepository(db)
+	factRP := factrepo.NewFactRepository(db)
+	sessionRP := sessionrepo.NewSessionRepository(db)
+
+	levelCurve := entity.NewLevelCurve(bootstrap.E.LevelCurve, bootstrap.E.LevelCurveBase, bootstrap.E.LevelCurveStep, bootstrap.E.LevelCurveTable)
 
 	loginUsecase := login.NewLoginUsecase(userRP)
-	signUpUsecase := signup.NewSignupUsecase(userRP)
-	refreshTokenUsecase := refreshtkn.NewRefreshTokenUsecase(userRP)
-	userUsecase := user.NewUserUsecase(userRP)
-	cardUsecase := card.NewCardUsecase(cardRP, deckRP)
+	signUpUsecase := signup.NewSignupUsecase(userRP, levelCurve)
+	refreshTokenUsecase := refreshtkn.NewRefreshTokenUsecase(sessionRP)
+	userUsecase := user.NewUserUsecase(userRP, streakRP, xpRP, levelCurve)
+	cardUsecase := card.NewCardUsecase(cardRP, deckRP, memberRP)
 	deckUsecase := deck.NewDeckUsecase(deckRP, cardRP, userRP)
+	exportUsecase := export.NewExportUsecase(exportRP, userRP, deckRP, cardRP, reviewRP, streakRP, xpRP, bootstrap.E.ExportDir, bootstrap.E.ExportExpiryHour, bootstrap.E.ExportMediaHosts)
+	searchUsecase := search.NewSearchUsecase(deckRP, cardRP)
+	ratingUsecase := rating.NewRatingUsecase(ratingRP, deckRP)
+	viewUsecase := view.NewViewUsecase(viewRP, deckRP, bootstrap.E.ViewWindowHour)
+	commentUsecase := comment.NewCommentUsecase(commentRP, deckRP, cardRP, userRP, memberRP)
+	profileUsecase := profile.NewProfileUsecase(followRP, userRP, deckRP)
+	leagueUsecase := league.NewLeagueUsecase(leagueRP, userRP, friendRP)
+	friendUsecase := friend.NewFriendUsecase(friendRP, userRP, reviewRP, leagueRP)
+	placeUsecase := place.NewPlaceUsecase(deckRP, cardRP)
+	memberUsecase := member.NewMemberUsecase(memberRP, deckRP, cardRP, userRP)
+	shareUsecase := share.NewShareUsecase(shareRP, deckRP)
+	moderationUsecase := moderation.NewModerationUsecase(reportRP, deckRP, cardRP, commentRP, userRP, sessionRP, bootstrap.E.ReportHideThreshold)
+	reviewUsecase := review.NewReviewUsecase(reviewRP, cardRP, deckRP, memberRP, userRP, entity.XPRulesConfig{
+		CorrectNewXP:           bootstrap.E.XPCorrectNew,
+		CorrectLearningXP:      bootstrap.E.XPCorrectLearning,
+		CorrectReviewXP:        bootstrap.E.XPCorrectReview,
+		WrongXP:                bootstrap.E.XPWrong,
+		FirstLearnBonusXP:      bootstrap.E.XPFirstLearnBonus,
+		StreakBonusPercent:     bootstrap.E.XPStreakBonusPercent,
+		MaxStreakBonusPercent:  bootstrap.E.XPMaxStreakBonusPercent,
+		PerfectSessionBonusXP:  bootstrap.E.XPPerfectSessionBonus,
+		PerfectSessionMinCards: bootstrap.E.XPPerfectSessionMinCards,
+		MaxReviewsPerSecond:    bootstrap.E.ReviewMaxPerSecond,
+		MaxSessionXP:           bootstrap.E.XPMaxPerSession,
+	}.Rules())
+	achievementUsecase := achievement.NewAchievementUsecase(achievementRP, reviewRP, deckRP, userRP)
+	streakUsecase := streak.NewStreakUsecase(streakRP, userRP)
+	goalUsecase := goal.NewGoalUsecase(goalRP, userRP)
+	questUsecase := quest.NewQuestUsecase(questRP)
+	walletUsecase := wallet.NewWalletUsecase(walletRP, shopRP, userRP)
+	factUsecase := fact.NewFactUsecase(factRP, deckRP)
+	sessionUsecase := session.NewSessionUsecase(sessionRP)
+
+	notifiers := []notifier.Notifier{}
+	if bootstrap.E.SMTPHost != "" {
+		notifiers = append(notifiers, smtpnotifier.NewSMTPNotifier(bootstrap.E.SMTPHost, bootstrap.E.SMTPPort, bootstrap.E.SMTPUsername, bootstrap.E.SMTPPassword, bootstrap.E.SMTPFrom))
+	}
+	if bootstrap.E.PushEndpoint != "" {
+		notifiers = append(notifiers, pushnotifier.NewPushNotifier(bootstrap.E.PushEndpoint, bootstrap.E.PushServerKey))
+	}
+	reminderUsecase := reminder.NewReminderUsecase(userRP, cardRP, memberRP, reviewRP, notifiers)
+	reminderInterval := time.Duration(bootstrap.E.ReminderIntervalMinute) * time.Minute
+	if reminderInterval <= 0 {
+		reminderInterval = entity.DEFAULT_REMINDER_INTERVAL_MINUTE * time.Minute
+	}
+
+	scheduler := job.NewScheduler()
+	scheduler.Every("close league weeks", time.Hour, leagueUsecase.CloseFinishedWeeks)
+	scheduler.Every("send study reminders", reminderInterval, reminderUsecase.SendDueReminders)
+	scheduler.Start()
 
-	h := handler.NewHandler(loginUsecase, signUpUsecase, refreshTokenUsecase, cardUsecase, deckUsecase, userUsecase)
+	h := handler.NewHandler(loginUsecase, signUpUsecase, refreshTokenUsecase, cardUsecase, deckUsecase, userUsecase, exportUsecase, searchUsecase, ratingUsecase, viewUsecase, commentUsecase, profileUsecase, leagueUsecase, friendUsecase, placeUsecase, memberUsecase, shareUsecase, moderationUsecase, reviewUsecase, achievementUsecase, streakUsecase, goalUsecase, questUsecase, walletUsecase, factUsecase, sessionUsecase)
 
 	publicRouter := gin.Group("")
 
@@ -64,11 +183,23 @@ func Setup(db *mongo.Database, gin *gin.Engine) {
 	publicRouter.POST("/api/signup-get-all", h.SignUpGetAllData)
 	publicRouter.POST("/api/refresh", h.RefreshToken)
 	publicRouter.POST("/api/get-all", h.GetAllData)
-	publicRouter.PUT("/api/deck/view", h.UpdateViewDeck)
-	publicRouter.GET("/api/fact", h.GetFact)
+	publicRouter.GET("/api/export/download", h.DownloadExport)
+	publicRouter.GET("/api/deck/catalog", h.GetDeckCatalog)
+	publicRouter.GET("/api/map/near", h.GetNearbyPlaces)
+	publicRouter.GET("/api/map/bbox", h.GetPlacesInBox)
+	publicRouter.GET("/api/map/province", h.GetPlacesByProvince)
+	publicRouter.POST("/api/deck/share/preview", h.PreviewSharedDeck)
+
+	optionalAuthRouter := gin.Group("")
+	optionalAuthRouter.Use(middleware.OptionalJwtAuthMiddleware(bootstrap.E.AccessTokenSecret, sessionRP))
+	optionalAuthRouter.POST("/api/deck/view", h.ViewDeck)
+	optionalAuthRouter.GET("/api/deck/view-trend", h.GetDeckViewTrend)
+	optionalAuthRouter.GET("/api/comment/list", h.GetComments)
+	optionalAuthRouter.GET("/api/user/profile", h.GetAuthorProfile)
+	optionalAuthRouter.GET("/api/fact", h.GetFact)
 
 	protectedRouter := gin.Group("")
-	protectedRouter.Use(middleware.JwtAuthMiddleware(bootstrap.E.AccessTokenSecret))
+	protectedRouter.Use(middleware.JwtAuthMiddleware(bootstrap.E.AccessTokenSecret, sessionRP))
 	protectedRouter.PUT("/api/user/update", h.UpdateUser)
 	protectedRouter.POST("/api/card/create", h.CreateCard)
 	protectedRouter.PUT("/api/card/update", h.UpdateCard)
@@ -80,5 +211,64 @@ func Setup(db *mongo.Database, gin *gin.Engine) {
 	protectedRouter.DELETE("/api/deck/delete", h.DeleteDeck)
 	protectedRouter.GET("/api/deck/review-cards", h.GetDeckWithReviewCards)
 	protectedRouter.POST("/api/deck/copy", h.CopyDeck)
-	protectedRouter.POST("/api/fact/create", h.CreateFact)
+	protectedRouter.POST("/api/export/create", h.CreateExport)
+	protectedRouter.GET("/api/export/status", h.GetExportJob)
+	protectedRouter.GET("/api/export/stream", h.StreamExport)
+	protectedRouter.GET("/api/search", h.Search)
+	protectedRouter.PUT("/api/deck/rate", h.RateDeck)
+	protectedRouter.GET("/api/deck/rating", h.GetDeckRating)
+	protectedRouter.POST("/api/comment/create", h.CreateComment)
+	protectedRouter.PUT("/api/comment/update", h.UpdateComment)
+	protectedRouter.DELETE("/api/comment/delete", h.DeleteComment)
+	protectedRouter.POST("/api/user/follow", h.FollowUser)
+	protectedRouter.DELETE("/api/user/unfollow", h.UnfollowUser)
+	protectedRouter.GET("/api/user/feed", h.GetFeed)
+	protectedRouter.GET("/api/leaderboard", h.GetLeaderboard)
+	protectedRouter.POST("/api/friend/request", h.SendFriendRequest)
+	protectedRouter.PUT("/api/friend/accept", h.AcceptFriendRequest)
+	protectedRouter.DELETE("/api/friend/decline", h.DeclineFriendRequest)
+	protectedRouter.DELETE("/api/friend/remove", h.RemoveFriend)
+	protectedRouter.GET("/api/friend/list", h.GetFriends)
+	protectedRouter.GET("/api/friend/activity", h.GetFriendsActivity)
+	protectedRouter.POST("/api/deck/member/invite", h.InviteDeckMember)
+	protectedRouter.DELETE("/api/deck/member/remove", h.RemoveDeckMember)
+	protectedRouter.GET("/api/deck/member/list", h.GetDeckMembers)
+	protectedRouter.GET("/api/deck/shared", h.GetSharedDecks)
+	protectedRouter.POST("/api/deck/share/create", h.CreateShareLink)
+	protectedRouter.GET("/api/deck/share/list", h.GetShareLinks)
+	protectedRouter.DELETE("/api/deck/share/revoke", h.RevokeShareLink)
+	protectedRouter.POST("/api/deck/share/copy", h.CopySharedDeck)
+	protectedRouter.POST("/api/report", h.ReportContent)
+	protectedRouter.GET("/api/user/achievements", h.GetAchievements)
+	protectedRouter.GET("/api/user/streak", h.GetStreak)
+	protectedRouter.POST("/api/user/streak/repair", h.RepairStreak)
+	protectedRouter.GET("/api/user/goal", h.GetDailyGoal)
+	protectedRouter.GET("/api/quest/list", h.GetQuests)
+	protectedRouter.POST("/api/quest/claim", h.ClaimQuest)
+	protectedRouter.GET("/api/wallet", h.GetWallet)
+	protectedRouter.GET("/api/shop/items", h.GetShopItems)
+	protectedRouter.POST("/api/shop/purchase", h.PurchaseItem)
+	protectedRouter.GET("/api/user/xp/history", h.GetXPHistory)
+	protectedRouter.GET("/api/user/activity", h.GetActivity)
+	protectedRouter.POST("/api/logout", h.LogOut)
+	protectedRouter.GET("/api/user/sessions", h.GetSessions)
+	protectedRouter.DELETE("/api/user/sessions/revoke", h.RevokeSession)
+	protectedRouter.DELETE("/api/user/sessions/revoke-all", h.RevokeAllSessions)
+
+	adminRouter := gin.Group("")
+	adminRouter.Use(middleware.JwtAuthMiddleware(bootstrap.E.AccessTokenSecret, sessionRP), middleware.AdminMiddleware(userRP))
+	adminRouter.GET("/api/admin/moderation/queue", h.GetModerationQueue)
+	adminRouter.POST("/api/admin/moderation/action", h.ModerateContent)
+	adminRouter.GET("/api/admin/moderation/log", h.GetModerationLog)
+	adminRouter.GET("/api/admin/review/flagged", h.GetFlaggedReviews)
+	adminRouter.GET("/api/admin/wallet/ledger", h.GetUserLedger)
+	adminRouter.POST("/api/admin/wallet/grant", h.GrantCoins)
+	adminRouter.GET("/api/admin/shop/items", h.GetAllShopItems)
+	adminRouter.POST("/api/admin/shop/item/create", h.CreateShopItem)
+	adminRouter.PUT("/api/admin/shop/item/update", h.UpdateShopItem)
+	adminRouter.POST("/api/admin/level/recompute", h.RecomputeLevels)
+	adminRouter.GET("/api/admin/fact/list", h.GetFacts)
+	adminRouter.POST("/api/admin/fact/create", h.CreateFact)
+	adminRouter.PUT("/api/admin/fact/update", h.UpdateFact)
+	adminRouter.DELETE("/api/admin/fact/delete", h.DeleteFact)
 }
@@ -0,0 +1,42 @@
+package job
+
+import (
+	"log"
+	"time"
+)
+
+type task struct {
+	name     string
+	interval time.Duration
+	run      func(now time.Time) error
+}
+
+// Scheduler runs background tasks at fixed intervals. Tasks must be safe to
+// run more than once, since every instance of the server runs them.
+type Scheduler struct {
+	tasks []task
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+func (s *Scheduler) Every(name string, interval time.Duration, run func(now time.Time) error) {
+	s.tasks = append(s.tasks, task{name: name, interval: interval, run: run})
+}
+
+// Start runs each task once right away and then on its interval.
+func (s *Scheduler) Start() {
+	for _, t := range s.tasks {
+		go func(t task) {
+			ticker := time.NewTicker(t.interval)
+			defer ticker.Stop()
+			for {
+				if err := t.run(time.Now()); err != nil {
+					log.Printf("Job %q failed: %v", t.name, err)
+				}
+				<-ticker.C
+			}
+		}(t)
+	}
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	ACHIEVEMENT_METRIC_REVIEWS          = "reviews"
+	ACHIEVEMENT_METRIC_STREAK           = "streak"
+	ACHIEVEMENT_METRIC_DECKS_PUBLISHED  = "decks_published"
+	ACHIEVEMENT_METRIC_COPIES_RECEIVED  = "copies_received"
+	ACHIEVEMENT_METRIC_PERFECT_SESSIONS = "perfect_sessions"
+)
+
+const (
+	ACHIEVEMENT_EVENT_REVIEW  = "review"
+	ACHIEVEMENT_EVENT_PUBLISH = "publish"
+	ACHIEVEMENT_EVENT_COPY    = "copy"
+)
+
+// AchievementEventMetrics lists the metrics an event can move, so only the
+// achievements on those metrics are evaluated when it happens.
+var AchievementEventMetrics = map[string][]string{
+	ACHIEVEMENT_EVENT_REVIEW:  {ACHIEVEMENT_METRIC_REVIEWS, ACHIEVEMENT_METRIC_STREAK, ACHIEVEMENT_METRIC_PERFECT_SESSIONS},
+	ACHIEVEMENT_EVENT_PUBLISH: {ACHIEVEMENT_METRIC_DECKS_PUBLISHED},
+	ACHIEVEMENT_EVENT_COPY:    {ACHIEVEMENT_METRIC_COPIES_RECEIVED},
+}
+
+// Achievement is unlocked once the user's metric reaches the threshold.
+type Achievement struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Metric      string `json:"metric"`
+	Threshold   int    `json:"threshold"`
+}
+
+// Achievements are the rules the badges are awarded by. Keys are stored with
+// the awarded badges, so they must not change.
+var Achievements = []Achievement{
+	{Key: "reviews_100", Name: "First Steps", Description: "Review 100 cards", Metric: ACHIEVEMENT_METRIC_REVIEWS, Threshold: 100},
+	{Key: "reviews_1000", Name: "Dedicated Learner", Description: "Review 1000 cards", Metric: ACHIEVEMENT_METRIC_REVIEWS, Threshold: 1000},
+	{Key: "reviews_10000", Name: "Card Master", Description: "Review 10000 cards", Metric: ACHIEVEMENT_METRIC_REVIEWS, Threshold: 10000},
+	{Key: "streak_7", Name: "On Fire", Description: "Reach a 7 day streak", Metric: ACHIEVEMENT_METRIC_STREAK, Threshold: 7},
+	{Key: "streak_30", Name: "Unstoppable", Description: "Reach a 30 day streak", Metric: ACHIEVEMENT_METRIC_STREAK, Threshold: 30},
+	{Key: "streak_100", Name: "Centurion", Description: "Reach a 100 day streak", Metric: ACHIEVEMENT_METRIC_STREAK, Threshold: 100},
+	{Key: "decks_published_1", Name: "Author", Description: "Publish a deck", Metric: ACHIEVEMENT_METRIC_DECKS_PUBLISHED, Threshold: 1},
+	{Key: "decks_published_10", Name: "Prolific Author", Description: "Publish 10 decks", Metric: ACHIEVEMENT_METRIC_DECKS_PUBLISHED, Threshold: 10},
+	{Key: "copies_received_10", Name: "Helpful", Description: "Have your public decks copied 10 times", Metric: ACHIEVEMENT_METRIC_COPIES_RECEIVED, Threshold: 10},
+	{Key: "copies_received_100", Name: "Influencer", Description: "Have your public decks copied 100 times", Metric: ACHIEVEMENT_METRIC_COPIES_RECEIVED, Threshold: 100},
+	{Key: "perfect_sessions_1", Name: "Flawless", Description: "Finish a perfect review session", Metric: ACHIEVEMENT_METRIC_PERFECT_SESSIONS, Threshold: 1},
+	{Key: "perfect_sessions_25", Name: "Perfectionist", Description: "Finish 25 perfect review sessions", Metric: ACHIEVEMENT_METRIC_PERFECT_SESSIONS, Threshold: 25},
+}
+
+// UserBadge is an achievement unlocked by a user. A badge is only awarded
+// once per user and is kept even if the metric later drops, like a streak.
+type UserBadge struct {
+	ID         primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	UserID     primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Key        string             `json:"key" bson:"key"`
+	UnlockedAt time.Time          `json:"unlocked_at" bson:"unlocked_at"`
+}
+
+type AchievementProgress struct {
+	Achievement
+	Progress   int        `json:"progress"`
+	Unlocked   bool       `json:"unlocked"`
+	UnlockedAt *time.Time `json:"unlocked_at"`
+}
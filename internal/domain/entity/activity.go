@@ -0,0 +1,30 @@
+package entity
+
+const (
+	ACTIVITY_DAY_LAYOUT = "2006-01-02"
+	// Days are in Vietnam time unless the client sends its time zone.
+	DEFAULT_ACTIVITY_TIMEZONE = "Asia/Ho_Chi_Minh"
+	MAX_ACTIVITY_DAYS         = 366
+)
+
+// ActivityDay sums up the review sessions of a local day, formatted with
+// ACTIVITY_DAY_LAYOUT.
+type ActivityDay struct {
+	Day      string `json:"day" bson:"_id"`
+	Reviews  int    `json:"reviews" bson:"reviews"`
+	NewCards int    `json:"new_cards" bson:"new_cards"`
+	XP       int    `json:"xp" bson:"xp"`
+}
+
+// ActivityCalendar is a heatmap of the days in [From, To], including the days
+// without activity. The streaks and active days count every review session
+// ever, not only those in the range.
+type ActivityCalendar struct {
+	From            string        `json:"from"`
+	To              string        `json:"to"`
+	TimeZone        string        `json:"time_zone"`
+	Days            []ActivityDay `json:"days"`
+	CurrentStreak   int           `json:"current_streak"`
+	LongestStreak   int           `json:"longest_streak"`
+	TotalActiveDays int           `json:"total_active_days"`
+}
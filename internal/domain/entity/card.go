@@ -4,11 +4,18 @@ import (
 	"math"
 	"math/rand"
 	"time"
+	"vietcard-backend/pkg/textutil"
 	"vietcard-backend/pkg/timeutil"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	CARD_TYPE_NEW      = 0
+	CARD_TYPE_LEARNING = 1
+	CARD_TYPE_REVIEW   = 2
+)
+
 type Card struct {
 	ID               primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
 	CreatedAt        time.Time          `json:"created_at" bson:"created_at"`
@@ -20,6 +27,8 @@ type Card struct {
 	QuestionImgLabel string             `json:"question_img_label" bson:"question_img_label"`
 	Answer           string             `json:"answer" bson:"answer"`
 	WrongAnswers     []string           `json:"wrong_answers" bson:"wrong_answers"`
+	Location         *GeoPoint          `json:"location" bson:"location,omitempty"`
+	Region           *Region            `json:"region" bson:"region,omitempty"`
 	LastReview       time.Time          `json:"last_review" bson:"last_review"`
 	NextReview       time.Time          `json:"next_review" bson:"next_review"`
 	NumReviews       int                `json:"num_reviews" bson:"num_reviews"`
@@ -27,6 +36,8 @@ type Card struct {
 	Sm2EF            float64            `json:"sm2_ef" bson:"sm2_ef"`
 	Sm2I             int                `json:"sm2_i" bson:"sm2_i"`
 	CardType         int                `json:"card_type"`
+	IsHidden         bool               `json:"is_hidden" bson:"is_hidden"`
+	SearchText       string             `json:"-" bson:"search_text"`
 }
 
 func (card *Card) SetDefault() *Card {
@@ -39,6 +50,35 @@ func (card *Card) SetDefault() *Card {
 	return card
 }
 
+// ReviewType is whether the card is new, being learned or relearned, or
+// being reviewed.
+func (card *Card) ReviewType() int {
+	if card.NumReviews == 0 {
+		return CARD_TYPE_NEW
+	}
+	if card.Sm2N == 0 || card.NumReviews == 1 {
+		return CARD_TYPE_LEARNING
+	}
+	return CARD_TYPE_REVIEW
+}
+
+// VisibleCards leaves out the cards hidden by moderators, which only their
+// owner still sees.
+func VisibleCards(cards *[]Card) *[]Card {
+	visible := []Card{}
+	for _, card := range *cards {
+		if !card.IsHidden {
+			visible = append(visible, card)
+		}
+	}
+	return &visible
+}
+
+func (card *Card) SetSearchText() *Card {
+	card.SearchText = textutil.Fold(card.Question + " " + card.Answer)
+	return card
+}
+
 /*
  * Reference: https://en.wikipedia.org/wiki/SuperMemo#Description_of_SM-2_algorithm
  */
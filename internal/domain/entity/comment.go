@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	MAX_COMMENT_LENGTH = 2000
+)
+
+type Comment struct {
+	ID         primitive.ObjectID  `json:"id,omitempty" bson:"_id,omitempty"`
+	CreatedAt  time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at" bson:"updated_at"`
+	DeckID     primitive.ObjectID  `json:"deck_id" bson:"deck_id"`
+	CardID     *primitive.ObjectID `json:"card_id" bson:"card_id"`
+	ParentID   *primitive.ObjectID `json:"parent_id" bson:"parent_id"`
+	UserID     primitive.ObjectID  `json:"user_id" bson:"user_id"`
+	UserName   string              `json:"user_name" bson:"user_name"`
+	Content    string              `json:"content" bson:"content"`
+	ReplyCount int                 `json:"reply_count" bson:"reply_count"`
+	IsEdited   bool                `json:"is_edited" bson:"is_edited"`
+	IsDeleted  bool                `json:"is_deleted" bson:"is_deleted"`
+	IsHidden   bool                `json:"is_hidden" bson:"is_hidden"`
+	// HiddenByBan is set on comments hidden because their author was banned,
+	// so that they come back on unban.
+	HiddenByBan bool `json:"-" bson:"hidden_by_ban,omitempty"`
+}
+
+func (comment *Comment) SetDefault() *Comment {
+	comment.CreatedAt = time.Now()
+	comment.UpdatedAt = comment.CreatedAt
+	comment.ReplyCount = 0
+	comment.IsEdited = false
+	comment.IsDeleted = false
+	comment.IsHidden = false
+	return comment
+}
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"time"
+	"vietcard-backend/pkg/textutil"
 	"vietcard-backend/pkg/timeutil"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -10,6 +11,7 @@ import (
 type Deck struct {
 	ID                  primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
 	CreatedAt           time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt           time.Time          `json:"updated_at" bson:"updated_at"`
 	UserID              primitive.ObjectID `json:"user_id" bson:"user_id"`
 	IsPublic            bool               `json:"is_public" bson:"is_public"`
 	IsFavorite          bool               `json:"is_favorite" bson:"is_favorite"`
@@ -17,8 +19,16 @@ type Deck struct {
 	Description         string             `json:"description" bson:"description"`
 	DescriptionImageURL string             `json:"description_img_url" bson:"description_img_url"`
 	Position            string             `json:"position" bson:"position"`
+	Location            *GeoPoint          `json:"location" bson:"location,omitempty"`
+	Region              *Region            `json:"region" bson:"region,omitempty"`
+	Tags                []string           `json:"tags" bson:"tags"`
 	Views               int                `json:"views" bson:"views"`
 	Rating              float32            `json:"rating" bson:"rating"`
+	RatingCount         int                `json:"rating_count" bson:"rating_count"`
+	RatingSum           int                `json:"-" bson:"rating_sum"`
+	BayesianRating      float64            `json:"bayesian_rating" bson:"bayesian_rating"`
+	Copies              int                `json:"copies" bson:"copies"`
+	CommentCount        int                `json:"comment_count" bson:"comment_count"`
 	TotalCards          int                `json:"total_cards" bson:"total_cards"`
 	TotalLearnedCards   int                `json:"total_learned_cards" bson:"total_learned_cards"`
 	MaxNewCards         int                `json:"max_new_cards" bson:"max_new_cards"`
@@ -26,6 +36,30 @@ type Deck struct {
 	LastReview          time.Time          `json:"last_review" bson:"last_review"`
 	CurNewCards         int                `json:"cur_new_cards" bson:"cur_new_cards"`
 	CurReviewCards      int                `json:"cur_review_cards" bson:"cur_review_cards"`
+	IsHidden            bool               `json:"is_hidden" bson:"is_hidden"`
+	HiddenByBan         bool               `json:"-" bson:"hidden_by_ban,omitempty"`
+	SearchText          string             `json:"-" bson:"search_text"`
+}
+
+type DeckSummary struct {
+	ID                  primitive.ObjectID `json:"id" bson:"_id"`
+	CreatedAt           time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt           time.Time          `json:"updated_at" bson:"updated_at"`
+	UserID              primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Name                string             `json:"name" bson:"name"`
+	Description         string             `json:"description" bson:"description"`
+	DescriptionImageURL string             `json:"description_img_url" bson:"description_img_url"`
+	Position            string             `json:"position" bson:"position"`
+	Location            *GeoPoint          `json:"location" bson:"location,omitempty"`
+	Region              *Region            `json:"region" bson:"region,omitempty"`
+	Tags                []string           `json:"tags" bson:"tags"`
+	Views               int                `json:"views" bson:"views"`
+	Rating              float32            `json:"rating" bson:"rating"`
+	RatingCount         int                `json:"rating_count" bson:"rating_count"`
+	BayesianRating      float64            `json:"bayesian_rating" bson:"bayesian_rating"`
+	Copies              int                `json:"copies" bson:"copies"`
+	CommentCount        int                `json:"comment_count" bson:"comment_count"`
+	TotalCards          int                `json:"total_cards" bson:"total_cards"`
 }
 
 type DeckWithReviewCards struct {
@@ -46,12 +80,20 @@ type DeckWithCards struct {
 
 func (deck *Deck) SetDefault() *Deck {
 	deck.CreatedAt = time.Now()
+	deck.UpdatedAt = deck.CreatedAt
 	deck.MaxNewCards = 20
 	deck.MaxReviewCards = 100
-	deck.Rating = 5
+	deck.Rating = 0
+	deck.RatingCount = 0
+	deck.RatingSum = 0
+	deck.BayesianRating = BayesianRating(0, 0)
 	deck.Views = 1
+	deck.Copies = 0
+	deck.CommentCount = 0
+	deck.TotalCards = 0
 	deck.TotalLearnedCards = 0
 	deck.IsFavorite = false
+	deck.IsHidden = false
 	return deck
 }
 
@@ -65,3 +107,8 @@ func (deck *Deck) UpdateReview() *Deck {
 	deck.CurReviewCards = 0
 	return deck
 }
+
+func (deck *Deck) SetSearchText() *Deck {
+	deck.SearchText = textutil.Fold(deck.Name + " " + deck.Description)
+	return deck
+}
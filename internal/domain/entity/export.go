@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	EXPORT_STATUS_PENDING = "pending"
+	EXPORT_STATUS_RUNNING = "running"
+	EXPORT_STATUS_READY   = "ready"
+	EXPORT_STATUS_FAILED  = "failed"
+	EXPORT_STATUS_EXPIRED = "expired"
+)
+
+type ExportJob struct {
+	ID          primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+	UserID      primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Status      string             `json:"status" bson:"status"`
+	Error       string             `json:"error,omitempty" bson:"error,omitempty"`
+	Token       string             `json:"-" bson:"token,omitempty"`
+	FilePath    string             `json:"-" bson:"file_path,omitempty"`
+	Size        int64              `json:"size" bson:"size"`
+	CompletedAt time.Time          `json:"completed_at" bson:"completed_at"`
+	ExpiresAt   time.Time          `json:"expires_at" bson:"expires_at"`
+}
+
+func (job *ExportJob) SetDefault() *ExportJob {
+	job.CreatedAt = time.Now()
+	job.Status = EXPORT_STATUS_PENDING
+	return job
+}
+
+func (job *ExportJob) IsActive() bool {
+	return job.Status == EXPORT_STATUS_PENDING || job.Status == EXPORT_STATUS_RUNNING
+}
+
+func (job *ExportJob) IsExpired() bool {
+	return job.Status == EXPORT_STATUS_EXPIRED ||
+		(job.Status == EXPORT_STATUS_READY && time.Now().After(job.ExpiresAt))
+}
@@ -1,8 +1,77 @@
 package entity
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash/fnv"
+	"strings"
+	"time"
+	"vietcard-backend/pkg/textutil"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	FACT_MODE_RANDOM = "random"
+	FACT_MODE_DAILY  = "daily"
+	FACT_MODE_UNSEEN = "unseen"
+)
+
+const (
+	DEFAULT_FACT_LOCALE = "vi"
+	// In the unseen mode, facts seen in the last FACT_RECENT_DAYS days are not
+	// shown again until every fact was seen.
+	FACT_RECENT_DAYS = 30
+)
 
 type Fact struct {
-	ID      primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
-	Content string             `json:"content" bson:"content"`
+	ID          primitive.ObjectID  `json:"id,omitempty" bson:"_id,omitempty"`
+	CreatedAt   time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at" bson:"updated_at"`
+	Content     string              `json:"content" bson:"content"`
+	Category    string              `json:"category" bson:"category"`
+	Source      string              `json:"source" bson:"source"`
+	SourceURL   string              `json:"source_url" bson:"source_url"`
+	DeckID      *primitive.ObjectID `json:"deck_id" bson:"deck_id,omitempty"`
+	Locale      string              `json:"locale" bson:"locale"`
+	ContentHash string              `json:"-" bson:"content_hash"`
+}
+
+// FactView records when a user last saw a fact.
+type FactView struct {
+	ID     primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	UserID primitive.ObjectID `json:"user_id" bson:"user_id"`
+	FactID primitive.ObjectID `json:"fact_id" bson:"fact_id"`
+	SeenAt time.Time          `json:"seen_at" bson:"seen_at"`
+}
+
+// Normalize trims the fact, lowercases its category and locale and sets its
+// content hash.
+func (fact *Fact) Normalize() *Fact {
+	fact.Content = strings.TrimSpace(fact.Content)
+	fact.Category = strings.ToLower(strings.TrimSpace(fact.Category))
+	fact.Source = strings.TrimSpace(fact.Source)
+	fact.SourceURL = strings.TrimSpace(fact.SourceURL)
+	fact.Locale = strings.ToLower(strings.TrimSpace(fact.Locale))
+	if fact.Locale == "" {
+		fact.Locale = DEFAULT_FACT_LOCALE
+	}
+	fact.ContentHash = FactContentHash(fact.Locale, fact.Content)
+	return fact
+}
+
+// FactContentHash identifies the content of a fact regardless of case,
+// diacritics, punctuation and spacing, so that rewordings as small as
+// "Huế" and "hue." are detected as duplicates.
+func FactContentHash(locale string, content string) string {
+	sum := sha256.Sum256([]byte(locale + "|" + strings.Join(textutil.Terms(content), " ")))
+	return hex.EncodeToString(sum[:])
+}
+
+// DailyFactIndex picks the fact of the day among count facts. Every instance
+// picks the same one for a locale on a given day.
+func DailyFactIndex(locale string, day time.Time, count int) int {
+	h := fnv.New64a()
+	h.Write([]byte(locale + "|" + day.Format(ACTIVITY_DAY_LAYOUT)))
+	return int(h.Sum64() % uint64(count))
 }
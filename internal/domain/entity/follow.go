@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Follow struct {
+	ID         primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+	FollowerID primitive.ObjectID `json:"follower_id" bson:"follower_id"`
+	FolloweeID primitive.ObjectID `json:"followee_id" bson:"followee_id"`
+}
+
+// AuthorProfile is the public view of a user shown next to their decks.
+type AuthorProfile struct {
+	ID             primitive.ObjectID `json:"id"`
+	Name           string             `json:"name"`
+	AvatarURL      string             `json:"avatar_url"`
+	Bio            string             `json:"bio"`
+	Level          int                `json:"level"`
+	FollowerCount  int                `json:"follower_count"`
+	FollowingCount int                `json:"following_count"`
+	PublishedDecks int                `json:"published_decks"`
+	TotalCopies    int                `json:"total_copies"`
+	IsFollowing    bool               `json:"is_following"`
+	Decks          []DeckSummary      `json:"decks"`
+}
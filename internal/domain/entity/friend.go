@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	FRIENDSHIP_STATUS_PENDING  = "pending"
+	FRIENDSHIP_STATUS_ACCEPTED = "accepted"
+
+	FRIEND_DIRECTION_INCOMING = "incoming"
+	FRIEND_DIRECTION_OUTGOING = "outgoing"
+)
+
+// Friendship links two users. It starts pending from RequesterID to
+// AddresseeID and becomes accepted once the addressee accepts. PairKey is the
+// same whichever user sent the request, so a unique index on it keeps one
+// friendship per pair.
+type Friendship struct {
+	ID          primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at" bson:"updated_at"`
+	PairKey     string             `json:"-" bson:"pair_key"`
+	RequesterID primitive.ObjectID `json:"requester_id" bson:"requester_id"`
+	AddresseeID primitive.ObjectID `json:"addressee_id" bson:"addressee_id"`
+	Status      string             `json:"status" bson:"status"`
+}
+
+type Friend struct {
+	UserID    primitive.ObjectID `json:"user_id"`
+	Name      string             `json:"name"`
+	AvatarURL string             `json:"avatar_url"`
+	Level     int                `json:"level"`
+	Status    string             `json:"status"`
+	Direction string             `json:"direction"`
+	Since     time.Time          `json:"since"`
+}
+
+type FriendActivity struct {
+	UserID            primitive.ObjectID `json:"user_id"`
+	Name              string             `json:"name"`
+	AvatarURL         string             `json:"avatar_url"`
+	Level             int                `json:"level"`
+	Streak            int                `json:"streak"`
+	WeeklyXP          int                `json:"weekly_xp"`
+	DecksStudiedToday int                `json:"decks_studied_today"`
+}
+
+func FriendPairKey(a primitive.ObjectID, b primitive.ObjectID) string {
+	if a.Hex() > b.Hex() {
+		a, b = b, a
+	}
+	return a.Hex() + ":" + b.Hex()
+}
+
+// OtherUser returns the user of the friendship that isn't userID.
+func (friendship *Friendship) OtherUser(userID primitive.ObjectID) primitive.ObjectID {
+	if friendship.RequesterID == userID {
+		return friendship.AddresseeID
+	}
+	return friendship.RequesterID
+}
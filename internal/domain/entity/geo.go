@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"vietcard-backend/pkg/textutil"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	GEO_POINT_TYPE = "Point"
+
+	PLACE_KIND_DECK = "deck"
+	PLACE_KIND_CARD = "card"
+
+	DEFAULT_NEAR_RADIUS_KM = 10
+	MAX_NEAR_RADIUS_KM     = 500
+)
+
+// GeoPoint is a GeoJSON point. Coordinates are [longitude, latitude].
+type GeoPoint struct {
+	Type        string    `json:"type" bson:"type"`
+	Coordinates []float64 `json:"coordinates" bson:"coordinates"`
+}
+
+// Region is the administrative region a place belongs to. ProvinceKey is the
+// province folded and without its "Tỉnh"/"Thành phố" prefix, so that
+// "TP. Hồ Chí Minh" and "ho chi minh" match.
+type Region struct {
+	Province    string `json:"province" bson:"province"`
+	District    string `json:"district" bson:"district"`
+	ProvinceKey string `json:"-" bson:"province_key"`
+}
+
+// PlaceQuery selects geolocated places either near a point, inside a
+// bounding box or in a province.
+type PlaceQuery struct {
+	Near              *GeoPoint
+	MaxDistanceMeters float64
+	// Box is [minLng, minLat, maxLng, maxLat].
+	Box         []float64
+	ProvinceKey string
+	Limit       int
+}
+
+type MapPlace struct {
+	Kind           string             `json:"kind" bson:"kind"`
+	ID             primitive.ObjectID `json:"id" bson:"_id"`
+	DeckID         primitive.ObjectID `json:"deck_id" bson:"deck_id"`
+	DeckName       string             `json:"deck_name" bson:"deck_name"`
+	Title          string             `json:"title" bson:"title"`
+	ImageURL       string             `json:"image_url" bson:"image_url"`
+	Location       GeoPoint           `json:"location" bson:"location"`
+	Region         *Region            `json:"region" bson:"region"`
+	DistanceMeters float64            `json:"distance_m,omitempty" bson:"distance_m,omitempty"`
+}
+
+func NewGeoPoint(lng float64, lat float64) *GeoPoint {
+	return &GeoPoint{
+		Type:        GEO_POINT_TYPE,
+		Coordinates: []float64{lng, lat},
+	}
+}
+
+func (point *GeoPoint) Validate() error {
+	if point.Type != GEO_POINT_TYPE || len(point.Coordinates) != 2 {
+		return errors.New("location must be a GeoJSON Point with [longitude, latitude] coordinates")
+	}
+	lng, lat := point.Coordinates[0], point.Coordinates[1]
+	if lng < -180 || lng > 180 || lat < -90 || lat > 90 {
+		return errors.New("location coordinates are out of range")
+	}
+	return nil
+}
+
+func (region *Region) Normalize() *Region {
+	region.Province = strings.TrimSpace(region.Province)
+	region.District = strings.TrimSpace(region.District)
+	region.ProvinceKey = ProvinceKey(region.Province)
+	return region
+}
+
+func ProvinceKey(province string) string {
+	terms := textutil.Terms(province)
+	if len(terms) > 0 && (terms[0] == "tinh" || terms[0] == "tp") {
+		terms = terms[1:]
+	} else if len(terms) > 1 && terms[0] == "thanh" && terms[1] == "pho" {
+		terms = terms[2:]
+	}
+	return strings.Join(terms, " ")
+}
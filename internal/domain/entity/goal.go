@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DAILY_GOAL_XP      = "xp"
+	DAILY_GOAL_REVIEWS = "reviews"
+)
+
+const (
+	DEFAULT_DAILY_GOAL_XP_TARGET      = 50
+	DEFAULT_DAILY_GOAL_REVIEWS_TARGET = 20
+)
+
+// DailyGoal is the XP or number of reviews a user aims for each day. Users
+// created before goals existed get the default XP goal.
+type DailyGoal struct {
+	Type   string `json:"type" bson:"type"`
+	Target int    `json:"target" bson:"target"`
+	// StreakRequiresGoal only counts the days where the goal was met in the
+	// streak, instead of any day with a review.
+	StreakRequiresGoal bool `json:"streak_requires_goal" bson:"streak_requires_goal"`
+}
+
+func (goal DailyGoal) WithDefaults() DailyGoal {
+	if goal.Type != DAILY_GOAL_REVIEWS {
+		goal.Type = DAILY_GOAL_XP
+	}
+	if goal.Target <= 0 {
+		if goal.Type == DAILY_GOAL_REVIEWS {
+			goal.Target = DEFAULT_DAILY_GOAL_REVIEWS_TARGET
+		} else {
+			goal.Target = DEFAULT_DAILY_GOAL_XP_TARGET
+		}
+	}
+	return goal
+}
+
+// DailyProgress is what a user did toward their goal on a day, with the goal
+// they had at the time.
+type DailyProgress struct {
+	ID          primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	UserID      primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Day         time.Time          `json:"day" bson:"day"`
+	XP          int                `json:"xp" bson:"xp"`
+	Reviews     int                `json:"reviews" bson:"reviews"`
+	Goal        DailyGoal          `json:"goal" bson:"goal"`
+	Completed   bool               `json:"completed" bson:"completed"`
+	CompletedAt *time.Time         `json:"completed_at" bson:"completed_at"`
+}
+
+func (progress *DailyProgress) IsGoalMet() bool {
+	if progress.Goal.Type == DAILY_GOAL_REVIEWS {
+		return progress.Reviews >= progress.Goal.Target
+	}
+	return progress.XP >= progress.Goal.Target
+}
+
+// CountsForStreak tells whether the day counts in the streak.
+func (progress *DailyProgress) CountsForStreak() bool {
+	return !progress.Goal.StreakRequiresGoal || progress.Completed
+}
+
+type DailyGoalStats struct {
+	Goal    DailyGoal       `json:"goal"`
+	Today   DailyProgress   `json:"today"`
+	History []DailyProgress `json:"history"`
+	Days    int             `json:"days"`
+	DaysHit int             `json:"days_hit"`
+	HitRate float64         `json:"hit_rate"`
+}
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"time"
+	"vietcard-backend/pkg/timeutil"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	LEAGUE_GROUP_SIZE    = 30
+	LEAGUE_PROMOTE_COUNT = 7
+	LEAGUE_DEMOTE_COUNT  = 5
+	LEAGUE_WEEK_LAYOUT   = "2006-01-02"
+
+	LEAGUE_RESULT_PROMOTED = "promoted"
+	LEAGUE_RESULT_STAYED   = "stayed"
+	LEAGUE_RESULT_DEMOTED  = "demoted"
+
+	LEADERBOARD_SCOPE_GLOBAL  = "global"
+	LEADERBOARD_SCOPE_FRIENDS = "friends"
+	LEADERBOARD_SCOPE_LEAGUE  = "league"
+)
+
+// LEAGUE_TIERS are ordered from lowest to highest. User.LeagueTier indexes
+// into it.
+var LEAGUE_TIERS = []string{"bronze", "silver", "gold", "sapphire", "ruby", "emerald", "amethyst", "pearl", "obsidian", "diamond"}
+
+// League is one group of up to LEAGUE_GROUP_SIZE users of the same tier
+// competing during a week. Week is the LEAGUE_WEEK_LAYOUT date of the
+// week's Monday in UTC.
+type League struct {
+	ID        primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	Week      string             `json:"week" bson:"week"`
+	Tier      int                `json:"tier" bson:"tier"`
+	Size      int                `json:"size" bson:"size"`
+	ClosedAt  *time.Time         `json:"closed_at" bson:"closed_at"`
+}
+
+// WeeklyXP is a user's XP ledger for one week. Users join a league with
+// their first XP of the week.
+type WeeklyXP struct {
+	ID        primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Week      string             `json:"week" bson:"week"`
+	XP        int                `json:"xp" bson:"xp"`
+	LeagueID  primitive.ObjectID `json:"league_id" bson:"league_id"`
+	Tier      int                `json:"tier" bson:"tier"`
+	Rank      int                `json:"rank" bson:"rank"`
+	Result    string             `json:"result" bson:"result"`
+}
+
+type LeaderboardEntry struct {
+	Rank      int                `json:"rank" bson:"rank"`
+	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Name      string             `json:"name" bson:"name"`
+	AvatarURL string             `json:"avatar_url" bson:"avatar_url"`
+	Level     int                `json:"level" bson:"level"`
+	XP        int                `json:"xp" bson:"xp"`
+}
+
+// LeagueWeek returns the week key of the league week containing t.
+func LeagueWeek(t time.Time) string {
+	return timeutil.StartOfWeek(t).Format(LEAGUE_WEEK_LAYOUT)
+}
+
+func LeagueTierName(tier int) string {
+	if tier < 0 || tier >= len(LEAGUE_TIERS) {
+		return ""
+	}
+	return LEAGUE_TIERS[tier]
+}
+
+type Leaderboard struct {
+	Scope        string             `json:"scope"`
+	Week         string             `json:"week"`
+	EndsAt       time.Time          `json:"ends_at"`
+	Tier         int                `json:"tier"`
+	TierName     string             `json:"tier_name"`
+	PromoteCount int                `json:"promote_count"`
+	DemoteCount  int                `json:"demote_count"`
+	Entries      []LeaderboardEntry `json:"entries"`
+	Me           *LeaderboardEntry  `json:"me"`
+}
@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	LEVEL_CURVE_LINEAR    = "linear"
+	LEVEL_CURVE_QUADRATIC = "quadratic"
+	LEVEL_CURVE_TABLE     = "table"
+)
+
+const (
+	XP_SOURCE_REVIEW    = "review"
+	XP_SOURCE_QUEST     = "quest"
+	XP_SOURCE_MIGRATION = "migration"
+)
+
+// LevelCurve decides how much XP each level takes. A linear curve takes Base
+// XP for level 1 and Step more for every next level, a quadratic one grows
+// with the square of the level, and a table lists the XP of each level, the
+// last one repeating.
+type LevelCurve struct {
+	Type  string
+	Base  int
+	Step  int
+	Table []int
+}
+
+// DefaultLevelCurve is the curve levels always followed before it could be
+// configured.
+var DefaultLevelCurve = LevelCurve{
+	Type: LEVEL_CURVE_LINEAR,
+	Base: 100,
+	Step: 100,
+}
+
+// NewLevelCurve builds a curve from its configuration, the table being comma
+// separated. Missing or invalid values fall back to DefaultLevelCurve.
+func NewLevelCurve(curveType string, base int, step int, table string) LevelCurve {
+	curve := LevelCurve{Type: curveType, Base: base, Step: step}
+	for _, value := range strings.Split(table, ",") {
+		xp, err := strconv.Atoi(strings.TrimSpace(value))
+		if err == nil && xp > 0 {
+			curve.Table = append(curve.Table, xp)
+		}
+	}
+	if curve.Type != LEVEL_CURVE_QUADRATIC && curve.Type != LEVEL_CURVE_TABLE {
+		curve.Type = LEVEL_CURVE_LINEAR
+	}
+	if curve.Type == LEVEL_CURVE_TABLE && len(curve.Table) == 0 {
+		curve.Type = LEVEL_CURVE_LINEAR
+	}
+	if curve.Base <= 0 {
+		curve.Base = DefaultLevelCurve.Base
+	}
+	if curve.Step <= 0 {
+		curve.Step = DefaultLevelCurve.Step
+	}
+	return curve
+}
+
+// XPToLevelUp returns the XP it takes to go from the level to the next.
+func (curve LevelCurve) XPToLevelUp(level int) int {
+	switch curve.Type {
+	case LEVEL_CURVE_TABLE:
+		if level > len(curve.Table) {
+			return curve.Table[len(curve.Table)-1]
+		}
+		return curve.Table[level-1]
+	case LEVEL_CURVE_QUADRATIC:
+		return curve.Base + curve.Step*(level-1)*(level-1)
+	}
+	return curve.Base + curve.Step*(level-1)
+}
+
+// Level returns the level reached with the lifetime XP, the XP earned in that
+// level and the XP the level takes.
+func (curve LevelCurve) Level(lifetimeXP int) (int, int, int) {
+	level, xp := 1, lifetimeXP
+	for xp >= curve.XPToLevelUp(level) {
+		xp -= curve.XPToLevelUp(level)
+		level++
+	}
+	return level, xp, curve.XPToLevelUp(level)
+}
+
+// LifetimeXP returns the XP it took to reach the level with xp earned in it.
+func (curve LevelCurve) LifetimeXP(level int, xp int) int {
+	for l := 1; l < level; l++ {
+		xp += curve.XPToLevelUp(l)
+	}
+	return xp
+}
+
+// XPEntry is an entry of the append-only XP ledger. Levels can be recomputed
+// from the sum of a user's entries whenever the curve changes.
+type XPEntry struct {
+	ID        primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Source    string             `json:"source" bson:"source"`
+	Amount    int                `json:"amount" bson:"amount"`
+	RefID     primitive.ObjectID `json:"ref_id,omitempty" bson:"ref_id,omitempty"`
+}
+
+type XPTotal struct {
+	UserID primitive.ObjectID `bson:"_id"`
+	Total  int                `bson:"total"`
+}
@@ -0,0 +1,55 @@
+package entity
+
+import "testing"
+
+func TestLevelCurveXPToLevelUp(t *testing.T) {
+	linear := NewLevelCurve(LEVEL_CURVE_LINEAR, 100, 50, "")
+	quadratic := NewLevelCurve(LEVEL_CURVE_QUADRATIC, 100, 50, "")
+	table := NewLevelCurve(LEVEL_CURVE_TABLE, 0, 0, "50, 80,x,120")
+
+	// Each level's XP, starting from level 1.
+	for name, c := range map[string]struct {
+		curve LevelCurve
+		want  []int
+	}{
+		"linear":    {linear, []int{100, 150, 200, 250}},
+		"quadratic": {quadratic, []int{100, 150, 300, 550}},
+		"table":     {table, []int{50, 80, 120, 120, 120}},
+	} {
+		for i, want := range c.want {
+			if got := c.curve.XPToLevelUp(i + 1); got != want {
+				t.Errorf("%s: XPToLevelUp(%d) = %d, want %d", name, i+1, got, want)
+			}
+		}
+	}
+}
+
+func TestNewLevelCurveFallsBack(t *testing.T) {
+	if curve := NewLevelCurve("", 0, -5, ""); curve.Type != DefaultLevelCurve.Type || curve.Base != DefaultLevelCurve.Base || curve.Step != DefaultLevelCurve.Step {
+		t.Errorf("NewLevelCurve() with nothing configured = %+v, want %+v", curve, DefaultLevelCurve)
+	}
+	if curve := NewLevelCurve(LEVEL_CURVE_TABLE, 0, 0, "0,-1"); curve.Type != LEVEL_CURVE_LINEAR {
+		t.Errorf("a table without valid levels is %s, want %s", curve.Type, LEVEL_CURVE_LINEAR)
+	}
+}
+
+func TestLevelCurveLevel(t *testing.T) {
+	curve := NewLevelCurve(LEVEL_CURVE_QUADRATIC, 100, 50, "")
+	level, xp, toLevelUp := curve.Level(0)
+	if level != 1 || xp != 0 || toLevelUp != 100 {
+		t.Errorf("Level(0) = %d, %d, %d, want 1, 0, 100", level, xp, toLevelUp)
+	}
+	// 100 + 150 for levels 1 and 2, then 20 into level 3.
+	level, xp, toLevelUp = curve.Level(270)
+	if level != 3 || xp != 20 || toLevelUp != 300 {
+		t.Errorf("Level(270) = %d, %d, %d, want 3, 20, 300", level, xp, toLevelUp)
+	}
+
+	// LifetimeXP undoes Level, so levels can be recomputed under a new curve.
+	for _, lifetimeXP := range []int{0, 99, 100, 1234, 98765} {
+		level, xp, _ := curve.Level(lifetimeXP)
+		if got := curve.LifetimeXP(level, xp); got != lifetimeXP {
+			t.Errorf("LifetimeXP(Level(%d)) = %d", lifetimeXP, got)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DECK_ROLE_OWNER  = "owner"
+	DECK_ROLE_EDITOR = "editor"
+	DECK_ROLE_VIEWER = "viewer"
+)
+
+var deckRoleRanks = map[string]int{
+	DECK_ROLE_VIEWER: 1,
+	DECK_ROLE_EDITOR: 2,
+	DECK_ROLE_OWNER:  3,
+}
+
+// DeckMember gives a user other than the owner access to a deck. The owner is
+// always Deck.UserID and has no membership.
+type DeckMember struct {
+	ID        primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	DeckID    primitive.ObjectID `json:"deck_id" bson:"deck_id"`
+	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Role      string             `json:"role" bson:"role"`
+	InvitedBy primitive.ObjectID `json:"invited_by" bson:"invited_by"`
+}
+
+type DeckMemberInfo struct {
+	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Name      string             `json:"name" bson:"name"`
+	Email     string             `json:"email" bson:"email"`
+	AvatarURL string             `json:"avatar_url" bson:"avatar_url"`
+	Role      string             `json:"role" bson:"role"`
+}
+
+// CardProgress is a learner's own review state of a card of a deck shared
+// with them. The owner's state stays on the card itself.
+type CardProgress struct {
+	ID         primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	UserID     primitive.ObjectID `json:"user_id" bson:"user_id"`
+	DeckID     primitive.ObjectID `json:"deck_id" bson:"deck_id"`
+	CardID     primitive.ObjectID `json:"card_id" bson:"card_id"`
+	LastReview time.Time          `json:"last_review" bson:"last_review"`
+	NextReview time.Time          `json:"next_review" bson:"next_review"`
+	NumReviews int                `json:"num_reviews" bson:"num_reviews"`
+	Sm2N       int                `json:"sm2_n" bson:"sm2_n"`
+	Sm2EF      float64            `json:"sm2_ef" bson:"sm2_ef"`
+	Sm2I       int                `json:"sm2_i" bson:"sm2_i"`
+}
+
+// DeckProgress is a learner's own daily counters of a deck shared with them.
+type DeckProgress struct {
+	ID                primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	UserID            primitive.ObjectID `json:"user_id" bson:"user_id"`
+	DeckID            primitive.ObjectID `json:"deck_id" bson:"deck_id"`
+	TotalLearnedCards int                `json:"total_learned_cards" bson:"total_learned_cards"`
+	LastReview        time.Time          `json:"last_review" bson:"last_review"`
+	CurNewCards       int                `json:"cur_new_cards" bson:"cur_new_cards"`
+	CurReviewCards    int                `json:"cur_review_cards" bson:"cur_review_cards"`
+}
+
+func DeckRoleAtLeast(role string, minRole string) bool {
+	return deckRoleRanks[role] > 0 && deckRoleRanks[role] >= deckRoleRanks[minRole]
+}
+
+// ApplyTo replaces the review state of card with the learner's. A nil
+// progress means the learner hasn't reviewed the card yet.
+func (progress *CardProgress) ApplyTo(card *Card) *Card {
+	if progress == nil {
+		card.LastReview = time.Time{}
+		card.NextReview = card.CreatedAt
+		card.NumReviews = 0
+		card.Sm2N = 0
+		card.Sm2EF = 2.5
+		card.Sm2I = 0
+		return card
+	}
+	card.LastReview = progress.LastReview
+	card.NextReview = progress.NextReview
+	card.NumReviews = progress.NumReviews
+	card.Sm2N = progress.Sm2N
+	card.Sm2EF = progress.Sm2EF
+	card.Sm2I = progress.Sm2I
+	return card
+}
+
+// ApplyCardProgress replaces the review state of every card with the
+// learner's progress on it.
+func ApplyCardProgress(cards *[]Card, progress *[]CardProgress) {
+	progressMap := make(map[primitive.ObjectID]*CardProgress)
+	for i := range *progress {
+		progressMap[(*progress)[i].CardID] = &(*progress)[i]
+	}
+	for i := range *cards {
+		progressMap[(*cards)[i].ID].ApplyTo(&(*cards)[i])
+	}
+}
+
+func NewCardProgress(userID primitive.ObjectID, card *Card) *CardProgress {
+	return &CardProgress{
+		UserID:     userID,
+		DeckID:     card.DeckID,
+		CardID:     card.ID,
+		LastReview: card.LastReview,
+		NextReview: card.NextReview,
+		NumReviews: card.NumReviews,
+		Sm2N:       card.Sm2N,
+		Sm2EF:      card.Sm2EF,
+		Sm2I:       card.Sm2I,
+	}
+}
+
+// ApplyTo replaces the daily counters of deck with the learner's.
+func (progress *DeckProgress) ApplyTo(deck *Deck) *Deck {
+	if progress == nil {
+		deck.TotalLearnedCards = 0
+		deck.LastReview = time.Time{}
+		deck.CurNewCards = 0
+		deck.CurReviewCards = 0
+		return deck
+	}
+	deck.TotalLearnedCards = progress.TotalLearnedCards
+	deck.LastReview = progress.LastReview
+	deck.CurNewCards = progress.CurNewCards
+	deck.CurReviewCards = progress.CurReviewCards
+	return deck
+}
+
+func NewDeckProgress(userID primitive.ObjectID, deck *Deck) *DeckProgress {
+	return &DeckProgress{
+		UserID:            userID,
+		DeckID:            deck.ID,
+		TotalLearnedCards: deck.TotalLearnedCards,
+		LastReview:        deck.LastReview,
+		CurNewCards:       deck.CurNewCards,
+		CurReviewCards:    deck.CurReviewCards,
+	}
+}
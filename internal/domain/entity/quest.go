@@ -0,0 +1,161 @@
+package entity
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"time"
+	"vietcard-backend/pkg/textutil"
+	"vietcard-backend/pkg/timeutil"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	QUEST_PERIOD_DAILY  = "daily"
+	QUEST_PERIOD_WEEKLY = "weekly"
+)
+
+const (
+	QUEST_METRIC_REVIEWS          = "reviews"
+	QUEST_METRIC_NEW_CARDS        = "new_cards"
+	QUEST_METRIC_XP               = "xp"
+	QUEST_METRIC_PERFECT_SESSIONS = "perfect_sessions"
+	QUEST_METRIC_STUDY_TOPIC      = "study_topic"
+	QUEST_METRIC_DECKS_CREATED    = "decks_created"
+)
+
+const (
+	DAILY_QUEST_COUNT  = 3
+	WEEKLY_QUEST_COUNT = 2
+)
+
+// QuestTemplate is a kind of quest users can get. Study topic quests count
+// review sessions of decks tagged with the topic or located in the province
+// of that name, compared without diacritics.
+type QuestTemplate struct {
+	Key         string
+	Period      string
+	Title       string
+	Metric      string
+	Topic       string
+	Target      int
+	RewardXP    int
+	RewardCoins int
+}
+
+// QuestTemplates are the quests rotated through. Keys are stored with the
+// generated quests, so they must not change.
+var QuestTemplates = []QuestTemplate{
+	{Key: "daily_reviews_30", Period: QUEST_PERIOD_DAILY, Title: "Review 30 cards", Metric: QUEST_METRIC_REVIEWS, Target: 30, RewardXP: 20, RewardCoins: 10},
+	{Key: "daily_reviews_60", Period: QUEST_PERIOD_DAILY, Title: "Review 60 cards", Metric: QUEST_METRIC_REVIEWS, Target: 60, RewardXP: 40, RewardCoins: 20},
+	{Key: "daily_new_cards_10", Period: QUEST_PERIOD_DAILY, Title: "Learn 10 new cards", Metric: QUEST_METRIC_NEW_CARDS, Target: 10, RewardXP: 25, RewardCoins: 12},
+	{Key: "daily_xp_100", Period: QUEST_PERIOD_DAILY, Title: "Earn 100 XP", Metric: QUEST_METRIC_XP, Target: 100, RewardXP: 20, RewardCoins: 10},
+	{Key: "daily_perfect_1", Period: QUEST_PERIOD_DAILY, Title: "Finish a perfect review session", Metric: QUEST_METRIC_PERFECT_SESSIONS, Target: 1, RewardXP: 30, RewardCoins: 15},
+	{Key: "daily_topic_hue", Period: QUEST_PERIOD_DAILY, Title: "Study a deck about Huế", Metric: QUEST_METRIC_STUDY_TOPIC, Topic: "hue", Target: 1, RewardXP: 15, RewardCoins: 7},
+	{Key: "daily_topic_ha_noi", Period: QUEST_PERIOD_DAILY, Title: "Study a deck about Hà Nội", Metric: QUEST_METRIC_STUDY_TOPIC, Topic: "ha noi", Target: 1, RewardXP: 15, RewardCoins: 7},
+	{Key: "daily_topic_da_nang", Period: QUEST_PERIOD_DAILY, Title: "Study a deck about Đà Nẵng", Metric: QUEST_METRIC_STUDY_TOPIC, Topic: "da nang", Target: 1, RewardXP: 15, RewardCoins: 7},
+	{Key: "daily_topic_ho_chi_minh", Period: QUEST_PERIOD_DAILY, Title: "Study a deck about Hồ Chí Minh", Metric: QUEST_METRIC_STUDY_TOPIC, Topic: "ho chi minh", Target: 1, RewardXP: 15, RewardCoins: 7},
+	{Key: "weekly_reviews_300", Period: QUEST_PERIOD_WEEKLY, Title: "Review 300 cards", Metric: QUEST_METRIC_REVIEWS, Target: 300, RewardXP: 150, RewardCoins: 75},
+	{Key: "weekly_new_cards_70", Period: QUEST_PERIOD_WEEKLY, Title: "Learn 70 new cards", Metric: QUEST_METRIC_NEW_CARDS, Target: 70, RewardXP: 150, RewardCoins: 75},
+	{Key: "weekly_xp_1000", Period: QUEST_PERIOD_WEEKLY, Title: "Earn 1000 XP", Metric: QUEST_METRIC_XP, Target: 1000, RewardXP: 120, RewardCoins: 60},
+	{Key: "weekly_perfect_5", Period: QUEST_PERIOD_WEEKLY, Title: "Finish 5 perfect review sessions", Metric: QUEST_METRIC_PERFECT_SESSIONS, Target: 5, RewardXP: 120, RewardCoins: 60},
+	{Key: "weekly_decks_created_1", Period: QUEST_PERIOD_WEEKLY, Title: "Create a deck", Metric: QUEST_METRIC_DECKS_CREATED, Target: 1, RewardXP: 50, RewardCoins: 25},
+}
+
+// UserQuest is a quest generated for a user for one day or week.
+type UserQuest struct {
+	ID          primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	UserID      primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Period      string             `json:"period" bson:"period"`
+	PeriodKey   string             `json:"period_key" bson:"period_key"`
+	TemplateKey string             `json:"template_key" bson:"template_key"`
+	Title       string             `json:"title" bson:"title"`
+	Metric      string             `json:"metric" bson:"metric"`
+	Topic       string             `json:"topic,omitempty" bson:"topic,omitempty"`
+	Target      int                `json:"target" bson:"target"`
+	Progress    int                `json:"progress" bson:"progress"`
+	RewardXP    int                `json:"reward_xp" bson:"reward_xp"`
+	RewardCoins int                `json:"reward_coins" bson:"reward_coins"`
+	ExpiresAt   time.Time          `json:"expires_at" bson:"expires_at"`
+	CompletedAt *time.Time         `json:"completed_at" bson:"completed_at"`
+	ClaimedAt   *time.Time         `json:"claimed_at" bson:"claimed_at"`
+}
+
+// QuestEvent is something a user did that quests may count.
+type QuestEvent struct {
+	Reviews         int
+	NewCards        int
+	XP              int
+	PerfectSessions int
+	DecksCreated    int
+	// Deck is the deck studied, for study topic quests.
+	Deck *Deck
+}
+
+// Amount returns how much the event moves the quest.
+func (quest *UserQuest) Amount(event *QuestEvent) int {
+	switch quest.Metric {
+	case QUEST_METRIC_REVIEWS:
+		return event.Reviews
+	case QUEST_METRIC_NEW_CARDS:
+		return event.NewCards
+	case QUEST_METRIC_XP:
+		return event.XP
+	case QUEST_METRIC_PERFECT_SESSIONS:
+		return event.PerfectSessions
+	case QUEST_METRIC_DECKS_CREATED:
+		return event.DecksCreated
+	case QUEST_METRIC_STUDY_TOPIC:
+		if event.Deck != nil && event.Deck.HasTopic(quest.Topic) {
+			return 1
+		}
+	}
+	return 0
+}
+
+// HasTopic tells whether the deck is tagged with the topic or located in the
+// province of that name.
+func (deck *Deck) HasTopic(topic string) bool {
+	if deck.Region != nil && deck.Region.ProvinceKey == topic {
+		return true
+	}
+	for _, tag := range deck.Tags {
+		if textutil.Fold(tag) == topic {
+			return true
+		}
+	}
+	return false
+}
+
+// QuestPeriodKey returns the key and the end of the period containing t.
+// Days follow the server's day like streaks, weeks are the league weeks.
+func QuestPeriodKey(period string, t time.Time) (string, time.Time) {
+	if period == QUEST_PERIOD_WEEKLY {
+		start := timeutil.StartOfWeek(t)
+		return QUEST_PERIOD_WEEKLY + ":" + start.Format("2006-01-02"), start.AddDate(0, 0, 7)
+	}
+	start := timeutil.TruncateToDay(t)
+	return QUEST_PERIOD_DAILY + ":" + start.Format("2006-01-02"), start.AddDate(0, 0, 1)
+}
+
+// PickQuestTemplates picks the templates of a user for a period. The pick
+// only depends on the user and the period, so it is the same however many
+// times the quests are generated, and rotates with every new period.
+func PickQuestTemplates(userID primitive.ObjectID, period string, periodKey string, count int) []QuestTemplate {
+	templates := []QuestTemplate{}
+	for _, template := range QuestTemplates {
+		if template.Period == period {
+			templates = append(templates, template)
+		}
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(userID.Hex() + "/" + periodKey))
+	rng := rand.New(rand.NewSource(int64(hash.Sum64())))
+	rng.Shuffle(len(templates), func(i, j int) {
+		templates[i], templates[j] = templates[j], templates[i]
+	})
+	if count < len(templates) {
+		templates = templates[:count]
+	}
+	return templates
+}
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func pickedQuestKeys(userID primitive.ObjectID, period string, periodKey string, count int) []string {
+	keys := []string{}
+	for _, template := range PickQuestTemplates(userID, period, periodKey, count) {
+		keys = append(keys, template.Key)
+	}
+	return keys
+}
+
+func TestPickQuestTemplatesIsStable(t *testing.T) {
+	userID := primitive.NewObjectID()
+	for period, count := range map[string]int{QUEST_PERIOD_DAILY: DAILY_QUEST_COUNT, QUEST_PERIOD_WEEKLY: WEEKLY_QUEST_COUNT} {
+		periodKey := period + ":2024-05-06"
+		picked := pickedQuestKeys(userID, period, periodKey, count)
+		if len(picked) != count {
+			t.Errorf("%s: picked %d quests, want %d", period, len(picked), count)
+		}
+		if again := pickedQuestKeys(userID, period, periodKey, count); !reflect.DeepEqual(again, picked) {
+			t.Errorf("%s: picked %v, then %v for the same period", period, picked, again)
+		}
+		for _, template := range PickQuestTemplates(userID, period, periodKey, count) {
+			if template.Period != period {
+				t.Errorf("%s: picked %s of period %s", period, template.Key, template.Period)
+			}
+		}
+	}
+}
+
+func TestPickQuestTemplatesNeverRepeats(t *testing.T) {
+	weekly := 0
+	for _, template := range QuestTemplates {
+		if template.Period == QUEST_PERIOD_WEEKLY {
+			weekly++
+		}
+	}
+	picked := pickedQuestKeys(primitive.NewObjectID(), QUEST_PERIOD_WEEKLY, "weekly:2024-05-06", 100)
+	if len(picked) != weekly {
+		t.Errorf("asked for more quests than there are, got %d, want all %d", len(picked), weekly)
+	}
+	seen := make(map[string]bool)
+	for _, key := range picked {
+		if seen[key] {
+			t.Errorf("picked %s twice", key)
+		}
+		seen[key] = true
+	}
+}
+
+func TestPickQuestTemplatesRotates(t *testing.T) {
+	userID, _ := primitive.ObjectIDFromHex("64b7f0c2a1b2c3d4e5f60718")
+	first := pickedQuestKeys(userID, QUEST_PERIOD_DAILY, "daily:2024-05-01", DAILY_QUEST_COUNT)
+	for _, day := range []string{"02", "03", "04", "05", "06", "07", "08"} {
+		if !reflect.DeepEqual(pickedQuestKeys(userID, QUEST_PERIOD_DAILY, "daily:2024-05-"+day, DAILY_QUEST_COUNT), first) {
+			return
+		}
+	}
+	t.Errorf("the same quests %v were picked every day of a week", first)
+}
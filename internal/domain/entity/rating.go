@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	MIN_DECK_RATING = 1
+	MAX_DECK_RATING = 5
+	// A deck with few ratings is pulled towards RATING_PRIOR_MEAN as if it
+	// had RATING_PRIOR_WEIGHT extra ratings of that value.
+	RATING_PRIOR_MEAN   = 3.0
+	RATING_PRIOR_WEIGHT = 5
+)
+
+type DeckRating struct {
+	ID        primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+	DeckID    primitive.ObjectID `json:"deck_id" bson:"deck_id"`
+	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Rating    int                `json:"rating" bson:"rating"`
+}
+
+func BayesianRating(ratingSum int, ratingCount int) float64 {
+	return (RATING_PRIOR_MEAN*RATING_PRIOR_WEIGHT + float64(ratingSum)) / float64(RATING_PRIOR_WEIGHT+ratingCount)
+}
@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	NOTIFY_CHANNEL_EMAIL = "email"
+	NOTIFY_CHANNEL_PUSH  = "push"
+)
+
+const (
+	REMINDER_CLOCK_LAYOUT     = "15:04"
+	DEFAULT_REMINDER_TIME     = "20:00"
+	DEFAULT_REMINDER_TIMEZONE = DEFAULT_ACTIVITY_TIMEZONE
+	// A reminder is sent at most once in REMINDER_MIN_GAP_HOUR hours, even if
+	// its time or time zone changes.
+	REMINDER_MIN_GAP_HOUR = 12
+	// Due reminders are looked for every DEFAULT_REMINDER_INTERVAL_MINUTE
+	// minutes unless configured otherwise.
+	DEFAULT_REMINDER_INTERVAL_MINUTE = 5
+)
+
+// ReminderSettings decide when a user is reminded to study. Times are
+// HH:MM in the user's time zone and weekdays go from 0 (Sunday) to 6, no
+// weekday meaning every day. Nothing is sent in the quiet hours, which may
+// span midnight.
+type ReminderSettings struct {
+	Enabled    bool      `json:"enabled" bson:"enabled"`
+	Time       string    `json:"time" bson:"time"`
+	Weekdays   []int     `json:"weekdays" bson:"weekdays"`
+	QuietStart string    `json:"quiet_start" bson:"quiet_start"`
+	QuietEnd   string    `json:"quiet_end" bson:"quiet_end"`
+	TimeZone   string    `json:"time_zone" bson:"time_zone"`
+	Channels   []string  `json:"channels" bson:"channels"`
+	PushToken  string    `json:"push_token" bson:"push_token"`
+	LastSentAt time.Time `json:"last_sent_at" bson:"last_sent_at"`
+}
+
+// Notification is a message sent to a user through a channel. To is the
+// address on that channel, like an email or a device token.
+type Notification struct {
+	UserID    primitive.ObjectID
+	Channel   string
+	To        string
+	Title     string
+	Body      string
+	CreatedAt time.Time
+}
+
+func (settings ReminderSettings) WithDefaults() ReminderSettings {
+	if _, err := time.Parse(REMINDER_CLOCK_LAYOUT, settings.Time); err != nil {
+		settings.Time = DEFAULT_REMINDER_TIME
+	}
+	if _, err := time.LoadLocation(settings.TimeZone); settings.TimeZone == "" || err != nil {
+		settings.TimeZone = DEFAULT_REMINDER_TIMEZONE
+	}
+	if len(settings.Channels) == 0 {
+		settings.Channels = []string{NOTIFY_CHANNEL_EMAIL}
+	}
+	return settings
+}
+
+// DueAt returns when the reminder of the local day of now is due, or false if
+// no reminder is sent on that day. The settings must have their defaults.
+func (settings ReminderSettings) DueAt(now time.Time) (time.Time, bool) {
+	loc, _ := time.LoadLocation(settings.TimeZone)
+	local := now.In(loc)
+	if len(settings.Weekdays) > 0 {
+		found := false
+		for _, weekday := range settings.Weekdays {
+			if time.Weekday(weekday) == local.Weekday() {
+				found = true
+			}
+		}
+		if !found {
+			return time.Time{}, false
+		}
+	}
+	return atClock(local, settings.Time), true
+}
+
+// InQuietHours returns whether now falls in the quiet hours. The settings
+// must have their defaults.
+func (settings ReminderSettings) InQuietHours(now time.Time) bool {
+	if settings.QuietStart == "" || settings.QuietEnd == "" {
+		return false
+	}
+	loc, _ := time.LoadLocation(settings.TimeZone)
+	local := now.In(loc)
+	start, end := atClock(local, settings.QuietStart), atClock(local, settings.QuietEnd)
+	if start.Before(end) {
+		return !local.Before(start) && local.Before(end)
+	}
+	return !local.Before(start) || local.Before(end)
+}
+
+// atClock returns the time of the day of t at the HH:MM clock, in t's
+// location.
+func atClock(t time.Time, clock string) time.Time {
+	c, err := time.Parse(REMINDER_CLOCK_LAYOUT, clock)
+	if err != nil {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), c.Hour(), c.Minute(), 0, 0, t.Location())
+}
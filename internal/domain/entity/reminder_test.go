@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReminderDueAt(t *testing.T) {
+	loc, err := time.LoadLocation(DEFAULT_REMINDER_TIMEZONE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		settings ReminderSettings
+		now      time.Time
+		want     time.Time
+		wantOK   bool
+	}{
+		{
+			name:     "just after local midnight",
+			settings: ReminderSettings{Time: "23:30"},
+			now:      time.Date(2024, 5, 5, 17, 0, 0, 0, time.UTC),
+			want:     time.Date(2024, 5, 6, 23, 30, 0, 0, loc),
+			wantOK:   true,
+		},
+		{
+			name:     "just before local midnight",
+			settings: ReminderSettings{Time: "23:30"},
+			now:      time.Date(2024, 5, 5, 16, 59, 0, 0, time.UTC),
+			want:     time.Date(2024, 5, 5, 23, 30, 0, 0, loc),
+			wantOK:   true,
+		},
+		{
+			name:     "weekday of the local day",
+			settings: ReminderSettings{Time: "08:00", Weekdays: []int{int(time.Sunday)}},
+			now:      time.Date(2024, 5, 5, 16, 59, 0, 0, time.UTC),
+			want:     time.Date(2024, 5, 5, 8, 0, 0, 0, loc),
+			wantOK:   true,
+		},
+		{
+			name:     "weekday of the UTC day only",
+			settings: ReminderSettings{Time: "08:00", Weekdays: []int{int(time.Sunday)}},
+			now:      time.Date(2024, 5, 5, 17, 0, 0, 0, time.UTC),
+			wantOK:   false,
+		},
+		{
+			name:     "invalid time falls back to the default",
+			settings: ReminderSettings{Time: "25:00"},
+			now:      time.Date(2024, 5, 5, 5, 0, 0, 0, time.UTC),
+			want:     time.Date(2024, 5, 5, 20, 0, 0, 0, loc),
+			wantOK:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.settings.WithDefaults().DueAt(tt.now)
+			if ok != tt.wantOK {
+				t.Fatalf("DueAt() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && !got.Equal(tt.want) {
+				t.Errorf("DueAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReminderInQuietHours(t *testing.T) {
+	loc, err := time.LoadLocation(DEFAULT_REMINDER_TIMEZONE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(hour int, min int) time.Time {
+		return time.Date(2024, 5, 5, hour, min, 0, 0, loc)
+	}
+	overnight := ReminderSettings{QuietStart: "22:00", QuietEnd: "07:00"}.WithDefaults()
+	lunch := ReminderSettings{QuietStart: "12:00", QuietEnd: "13:00"}.WithDefaults()
+	tests := []struct {
+		name     string
+		settings ReminderSettings
+		now      time.Time
+		want     bool
+	}{
+		{"overnight before start", overnight, at(21, 59), false},
+		{"overnight at start", overnight, at(22, 0), true},
+		{"overnight before midnight", overnight, at(23, 59), true},
+		{"overnight at midnight", overnight, at(0, 0), true},
+		{"overnight before end", overnight, at(6, 59), true},
+		{"overnight at end", overnight, at(7, 0), false},
+		{"overnight from UTC", overnight, time.Date(2024, 5, 5, 17, 30, 0, 0, time.UTC), true},
+		{"same day inside", lunch, at(12, 30), true},
+		{"same day at end", lunch, at(13, 0), false},
+		{"same day before start", lunch, at(11, 59), false},
+		{"no quiet hours", ReminderSettings{}.WithDefaults(), at(0, 0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.settings.InQuietHours(tt.now); got != tt.want {
+				t.Errorf("InQuietHours(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
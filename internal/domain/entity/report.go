@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	REPORT_TARGET_DECK    = "deck"
+	REPORT_TARGET_CARD    = "card"
+	REPORT_TARGET_COMMENT = "comment"
+
+	REPORT_STATUS_OPEN      = "open"
+	REPORT_STATUS_RESOLVED  = "resolved"
+	REPORT_STATUS_DISMISSED = "dismissed"
+
+	MODERATION_ACTION_HIDE      = "hide"
+	MODERATION_ACTION_RESTORE   = "restore"
+	MODERATION_ACTION_UNPUBLISH = "unpublish"
+	MODERATION_ACTION_WARN      = "warn"
+	MODERATION_ACTION_BAN       = "ban"
+	MODERATION_ACTION_UNBAN     = "unban"
+	MODERATION_ACTION_DISMISS   = "dismiss"
+
+	DEFAULT_REPORT_HIDE_THRESHOLD = 5
+)
+
+// Report is a user's complaint about a deck, card or comment. A user can
+// report the same target only once.
+type Report struct {
+	ID            primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`
+	ReporterID    primitive.ObjectID `json:"reporter_id" bson:"reporter_id"`
+	TargetType    string             `json:"target_type" bson:"target_type"`
+	TargetID      primitive.ObjectID `json:"target_id" bson:"target_id"`
+	TargetOwnerID primitive.ObjectID `json:"target_owner_id" bson:"target_owner_id"`
+	Reason        string             `json:"reason" bson:"reason"`
+	Details       string             `json:"details" bson:"details"`
+	Status        string             `json:"status" bson:"status"`
+}
+
+// ModerationQueueItem groups the open reports of a target.
+type ModerationQueueItem struct {
+	TargetType      string             `json:"target_type" bson:"target_type"`
+	TargetID        primitive.ObjectID `json:"target_id" bson:"target_id"`
+	TargetOwnerID   primitive.ObjectID `json:"target_owner_id" bson:"target_owner_id"`
+	ReportCount     int                `json:"report_count" bson:"report_count"`
+	Reasons         []string           `json:"reasons" bson:"reasons"`
+	FirstReportedAt time.Time          `json:"first_reported_at" bson:"first_reported_at"`
+	LastReportedAt  time.Time          `json:"last_reported_at" bson:"last_reported_at"`
+}
+
+// ModerationAction records a moderation decision. ModeratorID is nil when the
+// target was hidden automatically.
+type ModerationAction struct {
+	ID            primitive.ObjectID  `json:"id,omitempty" bson:"_id,omitempty"`
+	CreatedAt     time.Time           `json:"created_at" bson:"created_at"`
+	ModeratorID   *primitive.ObjectID `json:"moderator_id" bson:"moderator_id"`
+	TargetType    string              `json:"target_type" bson:"target_type"`
+	TargetID      primitive.ObjectID  `json:"target_id" bson:"target_id"`
+	TargetOwnerID primitive.ObjectID  `json:"target_owner_id" bson:"target_owner_id"`
+	Action        string              `json:"action" bson:"action"`
+	Note          string              `json:"note" bson:"note"`
+	ReportCount   int                 `json:"report_count" bson:"report_count"`
+}
@@ -0,0 +1,170 @@
+package entity
+
+import (
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	REVIEW_FLAG_TOO_FAST          = "too_fast"
+	REVIEW_FLAG_OVER_SESSION_XP   = "over_session_xp"
+	REVIEW_FLAG_CLAIMED_XP_HIGHER = "claimed_xp_higher"
+)
+
+// XPRules decide how much XP a review session is worth.
+type XPRules struct {
+	CorrectNewXP           int
+	CorrectLearningXP      int
+	CorrectReviewXP        int
+	WrongXP                int
+	FirstLearnBonusXP      int
+	StreakBonusPercent     int
+	MaxStreakBonusPercent  int
+	PerfectSessionBonusXP  int
+	PerfectSessionMinCards int
+	MaxReviewsPerSecond    float64
+	MaxSessionXP           int
+}
+
+var DefaultXPRules = XPRules{
+	CorrectNewXP:           10,
+	CorrectLearningXP:      6,
+	CorrectReviewXP:        8,
+	WrongXP:                0,
+	FirstLearnBonusXP:      5,
+	StreakBonusPercent:     2,
+	MaxStreakBonusPercent:  50,
+	PerfectSessionBonusXP:  20,
+	PerfectSessionMinCards: 10,
+	MaxReviewsPerSecond:    1,
+	MaxSessionXP:           2000,
+}
+
+// ReviewAnswer is one graded answer of a session, with the card's state
+// before it was rescheduled.
+type ReviewAnswer struct {
+	CardType   int
+	Correct    bool
+	FirstLearn bool
+}
+
+type XPBreakdown struct {
+	BaseXP         int `json:"base_xp" bson:"base_xp"`
+	FirstLearnXP   int `json:"first_learn_xp" bson:"first_learn_xp"`
+	StreakBonusXP  int `json:"streak_bonus_xp" bson:"streak_bonus_xp"`
+	PerfectBonusXP int `json:"perfect_bonus_xp" bson:"perfect_bonus_xp"`
+	BoostXP        int `json:"boost_xp" bson:"boost_xp"`
+	TotalXP        int `json:"total_xp" bson:"total_xp"`
+}
+
+// ApplyBoost adds an XP boost of the given percent on top of the total.
+func (breakdown *XPBreakdown) ApplyBoost(percent int) {
+	if percent <= 0 {
+		return
+	}
+	breakdown.BoostXP = int(math.Round(float64(breakdown.TotalXP*percent) / 100))
+	breakdown.TotalXP += breakdown.BoostXP
+}
+
+// ReviewLog records a review session and the XP it was awarded.
+type ReviewLog struct {
+	ID         primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+	UserID     primitive.ObjectID `json:"user_id" bson:"user_id"`
+	DeckID     primitive.ObjectID `json:"deck_id" bson:"deck_id"`
+	NumCards   int                `json:"num_cards" bson:"num_cards"`
+	NumCorrect int                `json:"num_correct" bson:"num_correct"`
+	NumNew     int                `json:"num_new" bson:"num_new"`
+	DurationMs int                `json:"duration_ms" bson:"duration_ms"`
+	ClaimedXP  int                `json:"claimed_xp" bson:"claimed_xp"`
+	Breakdown  XPBreakdown        `json:"breakdown" bson:"breakdown"`
+	AwardedXP  int                `json:"awarded_xp" bson:"awarded_xp"`
+	Flags      []string           `json:"flags" bson:"flags"`
+	IsFlagged  bool               `json:"is_flagged" bson:"is_flagged"`
+}
+
+// XPRulesConfig holds the configured XP rules. Unset fields keep the value of
+// DefaultXPRules, so a rule can still be set to 0.
+type XPRulesConfig struct {
+	CorrectNewXP           *int
+	CorrectLearningXP      *int
+	CorrectReviewXP        *int
+	WrongXP                *int
+	FirstLearnBonusXP      *int
+	StreakBonusPercent     *int
+	MaxStreakBonusPercent  *int
+	PerfectSessionBonusXP  *int
+	PerfectSessionMinCards *int
+	MaxReviewsPerSecond    *float64
+	MaxSessionXP           *int
+}
+
+func setIfConfigured[T any](rule *T, value *T) {
+	if value != nil {
+		*rule = *value
+	}
+}
+
+func (config XPRulesConfig) Rules() XPRules {
+	rules := DefaultXPRules
+	setIfConfigured(&rules.CorrectNewXP, config.CorrectNewXP)
+	setIfConfigured(&rules.CorrectLearningXP, config.CorrectLearningXP)
+	setIfConfigured(&rules.CorrectReviewXP, config.CorrectReviewXP)
+	setIfConfigured(&rules.WrongXP, config.WrongXP)
+	setIfConfigured(&rules.FirstLearnBonusXP, config.FirstLearnBonusXP)
+	setIfConfigured(&rules.StreakBonusPercent, config.StreakBonusPercent)
+	setIfConfigured(&rules.MaxStreakBonusPercent, config.MaxStreakBonusPercent)
+	setIfConfigured(&rules.PerfectSessionBonusXP, config.PerfectSessionBonusXP)
+	setIfConfigured(&rules.PerfectSessionMinCards, config.PerfectSessionMinCards)
+	setIfConfigured(&rules.MaxReviewsPerSecond, config.MaxReviewsPerSecond)
+	setIfConfigured(&rules.MaxSessionXP, config.MaxSessionXP)
+	return rules
+}
+
+// ComputeXP scores a session: XP per correct answer by card type, a bonus the
+// first time a card is learned, a streak bonus on top of those and a bonus
+// for long sessions without a wrong answer.
+func (rules XPRules) ComputeXP(answers []ReviewAnswer, streak int) XPBreakdown {
+	var breakdown XPBreakdown
+	perfect := true
+	for _, answer := range answers {
+		if !answer.Correct {
+			perfect = false
+			breakdown.BaseXP += rules.WrongXP
+			continue
+		}
+		switch answer.CardType {
+		case CARD_TYPE_NEW:
+			breakdown.BaseXP += rules.CorrectNewXP
+		case CARD_TYPE_LEARNING:
+			breakdown.BaseXP += rules.CorrectLearningXP
+		default:
+			breakdown.BaseXP += rules.CorrectReviewXP
+		}
+		if answer.FirstLearn {
+			breakdown.FirstLearnXP += rules.FirstLearnBonusXP
+		}
+	}
+	bonusPercent := streak * rules.StreakBonusPercent
+	if bonusPercent > rules.MaxStreakBonusPercent {
+		bonusPercent = rules.MaxStreakBonusPercent
+	}
+	breakdown.StreakBonusXP = int(math.Round(float64((breakdown.BaseXP+breakdown.FirstLearnXP)*bonusPercent) / 100))
+	if perfect && len(answers) >= rules.PerfectSessionMinCards {
+		breakdown.PerfectBonusXP = rules.PerfectSessionBonusXP
+	}
+	breakdown.TotalXP = breakdown.BaseXP + breakdown.FirstLearnXP + breakdown.StreakBonusXP + breakdown.PerfectBonusXP
+	return breakdown
+}
+
+// ReviewResult is the outcome of a review session: the cards left to review
+// in the deck and the session's log.
+type ReviewResult struct {
+	Cards         *[]Card
+	NumBlueCards  int
+	NumRedCards   int
+	NumGreenCards int
+	Log           *ReviewLog
+}
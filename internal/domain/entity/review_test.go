@@ -0,0 +1,93 @@
+package entity
+
+import "testing"
+
+func answers(cardType int, correct bool, firstLearn bool, count int) []ReviewAnswer {
+	result := make([]ReviewAnswer, count)
+	for i := range result {
+		result[i] = ReviewAnswer{CardType: cardType, Correct: correct, FirstLearn: firstLearn}
+	}
+	return result
+}
+
+func TestXPRulesComputeXP(t *testing.T) {
+	tests := []struct {
+		name    string
+		answers []ReviewAnswer
+		streak  int
+		want    XPBreakdown
+	}{
+		{
+			name: "empty session",
+			want: XPBreakdown{},
+		},
+		{
+			name:    "one per card type",
+			answers: append(append(answers(CARD_TYPE_NEW, true, true, 1), answers(CARD_TYPE_LEARNING, true, false, 1)...), answers(CARD_TYPE_REVIEW, true, false, 1)...),
+			want:    XPBreakdown{BaseXP: 24, FirstLearnXP: 5, TotalXP: 29},
+		},
+		{
+			name:    "wrong answers give nothing",
+			answers: answers(CARD_TYPE_REVIEW, false, false, 3),
+			want:    XPBreakdown{},
+		},
+		{
+			name:    "streak bonus",
+			answers: answers(CARD_TYPE_REVIEW, true, false, 5),
+			streak:  10,
+			want:    XPBreakdown{BaseXP: 40, StreakBonusXP: 8, TotalXP: 48},
+		},
+		{
+			name:    "streak bonus is capped",
+			answers: answers(CARD_TYPE_REVIEW, true, false, 5),
+			streak:  100,
+			want:    XPBreakdown{BaseXP: 40, StreakBonusXP: 20, TotalXP: 60},
+		},
+		{
+			name:    "perfect session",
+			answers: answers(CARD_TYPE_REVIEW, true, false, 10),
+			want:    XPBreakdown{BaseXP: 80, PerfectBonusXP: 20, TotalXP: 100},
+		},
+		{
+			name:    "not perfect with a wrong answer",
+			answers: append(answers(CARD_TYPE_REVIEW, true, false, 10), answers(CARD_TYPE_REVIEW, false, false, 1)...),
+			want:    XPBreakdown{BaseXP: 80, TotalXP: 80},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultXPRules.ComputeXP(tt.answers, tt.streak); got != tt.want {
+				t.Errorf("ComputeXP() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXPBreakdownApplyBoost(t *testing.T) {
+	breakdown := XPBreakdown{TotalXP: 15}
+	breakdown.ApplyBoost(0)
+	if breakdown != (XPBreakdown{TotalXP: 15}) {
+		t.Errorf("ApplyBoost(0) = %+v, want no boost", breakdown)
+	}
+	// 10% of 15 XP rounds up to 2.
+	breakdown.ApplyBoost(10)
+	if breakdown.BoostXP != 2 || breakdown.TotalXP != 17 {
+		t.Errorf("ApplyBoost(10) = %+v, want 2 boost XP and 17 in total", breakdown)
+	}
+}
+
+func TestXPRulesConfigKeepsZeroRules(t *testing.T) {
+	if got := (XPRulesConfig{}).Rules(); got != DefaultXPRules {
+		t.Errorf("Rules() of an empty config = %+v, want the defaults", got)
+	}
+
+	zero, five, half := 0, 5, 0.5
+	got := XPRulesConfig{FirstLearnBonusXP: &zero, WrongXP: &five, MaxReviewsPerSecond: &half}.Rules()
+	want := DefaultXPRules
+	want.FirstLearnBonusXP = 0
+	want.WrongXP = 5
+	want.MaxReviewsPerSecond = 0.5
+	if got != want {
+		t.Errorf("Rules() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,25 @@
+package entity
+
+const (
+	SEARCH_SCOPE_MINE   = "mine"
+	SEARCH_SCOPE_PUBLIC = "public"
+)
+
+type SearchHighlight struct {
+	Field   string   `json:"field"`
+	Snippet string   `json:"snippet"`
+	Ranges  [][2]int `json:"ranges"`
+}
+
+type DeckSearchHit struct {
+	DeckSummary `bson:"inline"`
+	Score       float64           `json:"score" bson:"score"`
+	Highlights  []SearchHighlight `json:"highlights" bson:"-"`
+}
+
+type CardSearchHit struct {
+	Card       `bson:"inline"`
+	DeckName   string            `json:"deck_name" bson:"deck_name"`
+	Score      float64           `json:"score" bson:"score"`
+	Highlights []SearchHighlight `json:"highlights" bson:"-"`
+}
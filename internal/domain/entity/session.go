@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// Refresh tokens are REFRESH_TOKEN_BYTES random bytes, hex encoded. Only
+	// their hash is stored.
+	REFRESH_TOKEN_BYTES = 32
+)
+
+const (
+	SESSION_REVOKE_REUSE    = "token_reuse"
+	SESSION_REVOKE_LOGOUT   = "logout"
+	SESSION_REVOKE_USER     = "revoked_by_user"
+	SESSION_REVOKE_PASSWORD = "password_changed"
+	SESSION_REVOKE_BAN      = "banned"
+)
+
+const (
+	// The last seen time of a session is updated at most once every
+	// SESSION_SEEN_INTERVAL_MINUTE minutes.
+	SESSION_SEEN_INTERVAL_MINUTE = 5
+	MAX_SESSION_USER_AGENT_LEN   = 512
+	MAX_SESSION_APP_VERSION_LEN  = 64
+)
+
+// Session is a signed in device and its refresh token family. Every refresh
+// rotates the token of the session, and using a rotated token again revokes
+// the whole session.
+type Session struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`
+	UserID        primitive.ObjectID `json:"user_id" bson:"user_id"`
+	SessionDevice `bson:",inline"`
+	LastSeenAt    time.Time  `json:"last_seen_at" bson:"last_seen_at"`
+	ExpiresAt     time.Time  `json:"expires_at" bson:"expires_at"`
+	RevokedAt     *time.Time `json:"revoked_at" bson:"revoked_at"`
+	RevokeReason  string     `json:"revoke_reason" bson:"revoke_reason,omitempty"`
+	IsCurrent     bool       `json:"is_current" bson:"-"`
+}
+
+// SessionDevice describes the client a session was last seen from.
+type SessionDevice struct {
+	UserAgent  string `json:"user_agent" bson:"user_agent"`
+	IP         string `json:"ip" bson:"ip"`
+	AppVersion string `json:"app_version" bson:"app_version"`
+}
+
+type RefreshToken struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	SessionID primitive.ObjectID `json:"session_id" bson:"session_id"`
+	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`
+	TokenHash string             `json:"-" bson:"token_hash"`
+	ExpiresAt time.Time          `json:"expires_at" bson:"expires_at"`
+	RotatedAt *time.Time         `json:"rotated_at" bson:"rotated_at"`
+}
+
+func (session *Session) IsActive(now time.Time) bool {
+	return session.RevokedAt == nil && now.Before(session.ExpiresAt)
+}
+
+// Truncate cuts the client supplied fields to their max length.
+func (device *SessionDevice) Truncate() *SessionDevice {
+	if len(device.UserAgent) > MAX_SESSION_USER_AGENT_LEN {
+		device.UserAgent = device.UserAgent[:MAX_SESSION_USER_AGENT_LEN]
+	}
+	if len(device.AppVersion) > MAX_SESSION_APP_VERSION_LEN {
+		device.AppVersion = device.AppVersion[:MAX_SESSION_APP_VERSION_LEN]
+	}
+	return device
+}
+
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	SHARE_TOKEN_BYTES = 24
+	// Wrong passwords and unknown tokens count as failed attempts. A link, or
+	// a client IP, with too many of them in the window is locked until the
+	// window ends.
+	SHARE_ATTEMPT_WINDOW_MINUTE  = 15
+	MAX_SHARE_ATTEMPTS_PER_TOKEN = 5
+	MAX_SHARE_ATTEMPTS_PER_IP    = 20
+)
+
+// ShareLink lets anyone holding its token preview and copy a deck, even a
+// private one, without the deck showing up in the public catalog.
+type ShareLink struct {
+	ID             primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
+	DeckID         primitive.ObjectID `json:"deck_id" bson:"deck_id"`
+	UserID         primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Token          string             `json:"token" bson:"token"`
+	HashedPassword string             `json:"-" bson:"hashed_password,omitempty"`
+	HasPassword    bool               `json:"has_password" bson:"has_password"`
+	ExpiresAt      *time.Time         `json:"expires_at" bson:"expires_at,omitempty"`
+	RevokedAt      *time.Time         `json:"revoked_at" bson:"revoked_at,omitempty"`
+}
+
+func (link *ShareLink) IsUsable(now time.Time) bool {
+	if link.RevokedAt != nil {
+		return false
+	}
+	return link.ExpiresAt == nil || now.Before(*link.ExpiresAt)
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	SHOP_ITEM_STREAK_FREEZE = "streak_freeze"
+	SHOP_ITEM_XP_BOOST      = "xp_boost"
+	SHOP_ITEM_COSMETIC      = "cosmetic"
+)
+
+const (
+	PURCHASE_STATUS_PENDING   = "pending"
+	PURCHASE_STATUS_COMPLETED = "completed"
+	PURCHASE_STATUS_FAILED    = "failed"
+)
+
+// A purchase still pending after PENDING_PURCHASE_TIMEOUT_MINUTE was
+// interrupted and is settled when it is retried.
+const PENDING_PURCHASE_TIMEOUT_MINUTE = 5
+
+// ShopItem is an item of the shop catalog. Streak freezes add Quantity
+// freezes, XP boosts add BoostPercent to the XP of review sessions for
+// BoostMinutes, and cosmetics can only be owned once.
+type ShopItem struct {
+	ID           primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at" bson:"updated_at"`
+	Type         string             `json:"type" bson:"type"`
+	Name         string             `json:"name" bson:"name"`
+	Description  string             `json:"description" bson:"description"`
+	ImageURL     string             `json:"image_url" bson:"image_url"`
+	Price        int                `json:"price" bson:"price"`
+	Quantity     int                `json:"quantity" bson:"quantity"`
+	BoostPercent int                `json:"boost_percent" bson:"boost_percent"`
+	BoostMinutes int                `json:"boost_minutes" bson:"boost_minutes"`
+	IsActive     bool               `json:"is_active" bson:"is_active"`
+}
+
+// Purchase is a purchase of a shop item. Retrying a purchase with the same
+// idempotency key returns the first one instead of buying again.
+type Purchase struct {
+	ID             primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
+	UserID         primitive.ObjectID `json:"user_id" bson:"user_id"`
+	ItemID         primitive.ObjectID `json:"item_id" bson:"item_id"`
+	IdempotencyKey string             `json:"idempotency_key" bson:"idempotency_key"`
+	Price          int                `json:"price" bson:"price"`
+	Status         string             `json:"status" bson:"status"`
+	FailReason     string             `json:"fail_reason,omitempty" bson:"fail_reason,omitempty"`
+}
+
+// UserItem is a cosmetic item owned by a user.
+type UserItem struct {
+	ID         primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	UserID     primitive.ObjectID `json:"user_id" bson:"user_id"`
+	ItemID     primitive.ObjectID `json:"item_id" bson:"item_id"`
+	AcquiredAt time.Time          `json:"acquired_at" bson:"acquired_at"`
+}
+
+// XPBoost adds Percent to the XP of review sessions until it expires.
+type XPBoost struct {
+	Percent   int       `json:"percent" bson:"percent"`
+	ExpiresAt time.Time `json:"expires_at" bson:"expires_at"`
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	STREAK_DAY_STUDIED  = "studied"
+	STREAK_DAY_FROZEN   = "frozen"
+	STREAK_DAY_MISSED   = "missed"
+	STREAK_DAY_REPAIRED = "repaired"
+)
+
+const (
+	MAX_STREAK_FREEZES = 2
+	// A streak freeze is earned every STREAK_FREEZE_EARN_DAYS days of streak.
+	STREAK_FREEZE_EARN_DAYS         = 7
+	STREAK_REPAIR_WINDOW_HOUR       = 48
+	STREAK_REPAIR_COOLDOWN_DAY      = 30
+	MAX_STREAK_MISSED_DAYS_RECORDED = 30
+	// Concurrent sessions can update the streak at the same time, the loser
+	// reloads the user and tries again.
+	STREAK_UPDATE_ATTEMPTS = 3
+)
+
+// StreakRepair is a streak lost on the missed days, which can be restored
+// until it expires.
+type StreakRepair struct {
+	Streak     int       `json:"streak" bson:"streak"`
+	MissedFrom time.Time `json:"missed_from" bson:"missed_from"`
+	MissedTo   time.Time `json:"missed_to" bson:"missed_to"`
+	ExpiresAt  time.Time `json:"expires_at" bson:"expires_at"`
+}
+
+// StreakDay records what happened to the streak on a day.
+type StreakDay struct {
+	ID     primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	UserID primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Day    time.Time          `json:"day" bson:"day"`
+	Status string             `json:"status" bson:"status"`
+}
+
+type StreakInfo struct {
+	Streak           int           `json:"streak"`
+	StreakFreezes    int           `json:"streak_freezes"`
+	MaxStreakFreezes int           `json:"max_streak_freezes"`
+	StreakRepair     *StreakRepair `json:"streak_repair"`
+	CanRepair        bool          `json:"can_repair"`
+	History          []StreakDay   `json:"history"`
+}
+
+// missedDays returns the days strictly between last and cur, both truncated
+// to the day.
+func missedDays(last time.Time, cur time.Time) []time.Time {
+	days := []time.Time{}
+	for day := last.AddDate(0, 0, 1); day.Before(cur); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days
+}
@@ -1,62 +1,158 @@
 package entity
 
 import (
+	"errors"
 	"time"
 	"vietcard-backend/pkg/timeutil"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-const (
-	LEVEL_XP_INC = 100
-)
-
 type User struct {
 	ID               primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
 	CreatedAt        time.Time          `json:"created_at" bson:"created_at"`
 	Name             string             `json:"name" bson:"name"`
+	Bio              string             `json:"bio" bson:"bio"`
+	AvatarURL        string             `json:"avatar_url" bson:"avatar_url"`
 	Email            string             `json:"email" bson:"email"`
 	HashedPassword   string             `json:"hashed_password" bson:"hashed_password"`
+	LifetimeXP       int                `json:"lifetime_xp" bson:"lifetime_xp"`
 	XP               int                `json:"xp" bson:"xp"`
 	XPToLevelUp      int                `json:"xp_to_level_up" bson:"xp_to_level_up"`
 	Level            int                `json:"level" bson:"level"`
 	Streak           int                `json:"streak" bson:"streak"`
 	LastStreak       time.Time          `json:"last_streak" bson:"last_streak"`
+	StreakFreezes    int                `json:"streak_freezes" bson:"streak_freezes"`
+	StreakRepair     *StreakRepair      `json:"streak_repair" bson:"streak_repair"`
+	LastStreakRepair time.Time          `json:"last_streak_repair" bson:"last_streak_repair"`
+	XPBoost          *XPBoost           `json:"xp_boost" bson:"xp_boost"`
+	LeagueTier       int                `json:"league_tier" bson:"league_tier"`
+	FollowerCount    int                `json:"follower_count" bson:"follower_count"`
+	FollowingCount   int                `json:"following_count" bson:"following_count"`
+	Privacy          PrivacySettings    `json:"privacy" bson:"privacy"`
+	DailyGoal        DailyGoal          `json:"daily_goal" bson:"daily_goal"`
+	Reminder         ReminderSettings   `json:"reminder" bson:"reminder"`
 	IsAdmin          bool               `json:"is_admin" bson:"is_admin"`
+	IsBanned         bool               `json:"is_banned" bson:"is_banned"`
+	WarningCount     int                `json:"warning_count" bson:"warning_count"`
+}
+
+// PrivacySettings default to their zero values, so users created before the
+// settings existed keep the default behaviour.
+type PrivacySettings struct {
+	HideActivity        bool `json:"hide_activity" bson:"hide_activity"`
+	BlockFriendRequests bool `json:"block_friend_requests" bson:"block_friend_requests"`
 }
 
 func (user *User) SetDefault() *User {
 	user.CreatedAt = time.Now()
+	user.LifetimeXP = 0
 	user.XP = 0
-	user.XPToLevelUp = 100
+	user.XPToLevelUp = DefaultLevelCurve.XPToLevelUp(1)
 	user.Level = 1
 	user.Streak = 1
 	user.LastStreak = user.CreatedAt
+	user.StreakFreezes = 0
+	user.StreakRepair = nil
+	user.LeagueTier = 0
+	user.FollowerCount = 0
+	user.FollowingCount = 0
 	user.IsAdmin = false
+	user.IsBanned = false
+	user.WarningCount = 0
 	return user
 }
 
-func (user *User) UpdateStreak() *User {
-	cur := timeutil.TruncateToDay(time.Now())
+// UpdateStreak counts today in the streak. Days missed since the last one are
+// covered by streak freezes if the user has enough of them, otherwise the
+// streak restarts and the old one can be repaired for a while. It returns the
+// missed days, frozen or not, for the streak history.
+func (user *User) UpdateStreak() []StreakDay {
+	now := time.Now()
+	cur := timeutil.TruncateToDay(now)
 	last := timeutil.TruncateToDay(user.LastStreak)
-	if cur.Equal(last) {
-		return user
+	if !cur.After(last) {
+		return nil
 	}
-	ycur := cur.AddDate(0, 0, -1)
-	if ycur.Equal(last) {
+	gap := missedDays(last, cur)
+	days := make([]StreakDay, 0, len(gap))
+	if len(gap) <= user.StreakFreezes {
+		user.StreakFreezes -= len(gap)
+		for _, day := range gap {
+			days = append(days, StreakDay{UserID: user.ID, Day: day, Status: STREAK_DAY_FROZEN})
+		}
 		user.Streak++
+		if user.Streak%STREAK_FREEZE_EARN_DAYS == 0 && user.StreakFreezes < MAX_STREAK_FREEZES {
+			user.StreakFreezes++
+		}
 	} else {
+		user.StreakRepair = &StreakRepair{
+			Streak:     user.Streak,
+			MissedFrom: gap[0],
+			MissedTo:   gap[len(gap)-1],
+			ExpiresAt:  now.Add(STREAK_REPAIR_WINDOW_HOUR * time.Hour),
+		}
 		user.Streak = 1
+		if len(gap) > MAX_STREAK_MISSED_DAYS_RECORDED {
+			gap = gap[len(gap)-MAX_STREAK_MISSED_DAYS_RECORDED:]
+		}
+		for _, day := range gap {
+			days = append(days, StreakDay{UserID: user.ID, Day: day, Status: STREAK_DAY_MISSED})
+		}
 	}
-	user.LastStreak = time.Now()
-	return user
+	user.LastStreak = now
+	return days
 }
 
-func (user *User) UpdateLevel() *User {
-	for user.XP >= user.XPToLevelUp {
-		user.Level++
-		user.XP -= user.XPToLevelUp
-		user.XPToLevelUp += LEVEL_XP_INC
+// CurrentStreak is the streak as of today, which is 0 if the user didn't study
+// yesterday or today and doesn't have enough streak freezes to cover the
+// missed days.
+func (user *User) CurrentStreak() int {
+	today := timeutil.TruncateToDay(time.Now())
+	if len(missedDays(timeutil.TruncateToDay(user.LastStreak), today)) > user.StreakFreezes {
+		return 0
 	}
+	return user.Streak
+}
+
+func (user *User) checkStreakRepair(now time.Time) error {
+	if user.StreakRepair == nil || now.After(user.StreakRepair.ExpiresAt) {
+		return errors.New("there is no streak to repair")
+	}
+	if !user.LastStreakRepair.IsZero() && now.Before(user.LastStreakRepair.AddDate(0, 0, STREAK_REPAIR_COOLDOWN_DAY)) {
+		return errors.New("streak was repaired recently")
+	}
+	return nil
+}
+
+func (user *User) CanRepairStreak() bool {
+	return user.checkStreakRepair(time.Now()) == nil
+}
+
+// RepairStreak restores the streak lost on the last missed days, if it is
+// still repairable and the user hasn't repaired one recently.
+func (user *User) RepairStreak() error {
+	now := time.Now()
+	if err := user.checkStreakRepair(now); err != nil {
+		return err
+	}
+	user.Streak += user.StreakRepair.Streak
+	user.StreakRepair = nil
+	user.LastStreakRepair = now
+	return nil
+}
+
+// ActiveXPBoostPercent returns the percent of the user's XP boost, or 0 if
+// there is none or it expired.
+func (user *User) ActiveXPBoostPercent() int {
+	if user.XPBoost == nil || time.Now().After(user.XPBoost.ExpiresAt) {
+		return 0
+	}
+	return user.XPBoost.Percent
+}
+
+// UpdateLevel sets the level and the XP in it from the lifetime XP.
+func (user *User) UpdateLevel(curve LevelCurve) *User {
+	user.Level, user.XP, user.XPToLevelUp = curve.Level(user.LifetimeXP)
 	return user
 }
@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUserUpdateStreak(t *testing.T) {
+	now := time.Now()
+	daysAgo := func(days int) time.Time {
+		return now.AddDate(0, 0, -days)
+	}
+	tests := []struct {
+		name        string
+		user        User
+		wantStreak  int
+		wantFreezes int
+		wantFrozen  int
+		wantMissed  int
+		wantRepair  bool
+	}{
+		{"same day", User{Streak: 4, LastStreak: now, StreakFreezes: 1}, 4, 1, 0, 0, false},
+		{"yesterday", User{Streak: 4, LastStreak: daysAgo(1), StreakFreezes: 1}, 5, 1, 0, 0, false},
+		{"earns a freeze", User{Streak: STREAK_FREEZE_EARN_DAYS - 1, LastStreak: daysAgo(1)}, STREAK_FREEZE_EARN_DAYS, 1, 0, 0, false},
+		{"freezes are capped", User{Streak: STREAK_FREEZE_EARN_DAYS - 1, LastStreak: daysAgo(1), StreakFreezes: MAX_STREAK_FREEZES}, STREAK_FREEZE_EARN_DAYS, MAX_STREAK_FREEZES, 0, 0, false},
+		{"missed days frozen", User{Streak: 4, LastStreak: daysAgo(3), StreakFreezes: 2}, 5, 0, 2, 0, false},
+		{"not enough freezes", User{Streak: 4, LastStreak: daysAgo(3), StreakFreezes: 1}, 1, 1, 0, 2, true},
+		{"missed days recorded are capped", User{Streak: 4, LastStreak: daysAgo(MAX_STREAK_MISSED_DAYS_RECORDED + 10)}, 1, 0, 0, MAX_STREAK_MISSED_DAYS_RECORDED, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := tt.user
+			oldStreak := user.Streak
+			days := user.UpdateStreak()
+			if user.Streak != tt.wantStreak {
+				t.Errorf("Streak = %d, want %d", user.Streak, tt.wantStreak)
+			}
+			if user.StreakFreezes != tt.wantFreezes {
+				t.Errorf("StreakFreezes = %d, want %d", user.StreakFreezes, tt.wantFreezes)
+			}
+			frozen, missed := 0, 0
+			for _, day := range days {
+				switch day.Status {
+				case STREAK_DAY_FROZEN:
+					frozen++
+				case STREAK_DAY_MISSED:
+					missed++
+				}
+			}
+			if frozen != tt.wantFrozen || missed != tt.wantMissed {
+				t.Errorf("frozen, missed days = %d, %d, want %d, %d", frozen, missed, tt.wantFrozen, tt.wantMissed)
+			}
+			if (user.StreakRepair != nil) != tt.wantRepair {
+				t.Fatalf("StreakRepair = %v, want one: %v", user.StreakRepair, tt.wantRepair)
+			}
+			if tt.wantRepair && user.StreakRepair.Streak != oldStreak {
+				t.Errorf("StreakRepair.Streak = %d, want %d", user.StreakRepair.Streak, oldStreak)
+			}
+		})
+	}
+}
+
+func TestUserRepairStreak(t *testing.T) {
+	now := time.Now()
+	user := User{Streak: 1, LastStreak: now}
+	if user.CanRepairStreak() || user.RepairStreak() == nil {
+		t.Fatal("repaired a streak that was never lost")
+	}
+
+	user.StreakRepair = &StreakRepair{Streak: 10, MissedFrom: now.AddDate(0, 0, -2), MissedTo: now.AddDate(0, 0, -1), ExpiresAt: now.Add(time.Hour)}
+	if !user.CanRepairStreak() {
+		t.Fatal("CanRepairStreak() = false for a lost streak")
+	}
+	if err := user.RepairStreak(); err != nil {
+		t.Fatalf("RepairStreak() error = %v", err)
+	}
+	if user.Streak != 11 || user.StreakRepair != nil || user.LastStreakRepair.IsZero() {
+		t.Errorf("after repair: streak %d, repair %v, last repair %v", user.Streak, user.StreakRepair, user.LastStreakRepair)
+	}
+
+	// Losing the streak again within the cooldown can't be repaired.
+	user.StreakRepair = &StreakRepair{Streak: 11, ExpiresAt: now.Add(time.Hour)}
+	if err := user.RepairStreak(); err == nil {
+		t.Error("repaired twice within the cooldown")
+	}
+	user.LastStreakRepair = now.AddDate(0, 0, -STREAK_REPAIR_COOLDOWN_DAY-1)
+	user.StreakRepair.ExpiresAt = now.Add(-time.Minute)
+	if err := user.RepairStreak(); err == nil {
+		t.Error("repaired after the repair expired")
+	}
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DEFAULT_VIEW_WINDOW_HOUR = 24
+	// View events expire after a week, so windows can't be longer than that.
+	MAX_VIEW_WINDOW_HOUR = 7 * 24
+	VIEW_DAY_LAYOUT      = "2006-01-02"
+)
+
+// DeckViewEvent records that a viewer has been counted for a deck in the
+// window starting at WindowStart. A unique index on (deck_id, viewer_key,
+// window_start) makes repeated views in the same window no-ops. The client
+// fingerprint can be changed at will, so it is only kept as a hash next to
+// the key to spot abuse.
+type DeckViewEvent struct {
+	ID              primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	CreatedAt       time.Time          `json:"created_at" bson:"created_at"`
+	DeckID          primitive.ObjectID `json:"deck_id" bson:"deck_id"`
+	ViewerKey       string             `json:"viewer_key" bson:"viewer_key"`
+	FingerprintHash string             `json:"fingerprint_hash,omitempty" bson:"fingerprint_hash,omitempty"`
+	WindowStart     time.Time          `json:"window_start" bson:"window_start"`
+}
+
+type DeckViewDaily struct {
+	DeckID primitive.ObjectID `json:"deck_id" bson:"deck_id"`
+	Day    string             `json:"day" bson:"day"`
+	Views  int                `json:"views" bson:"views"`
+}
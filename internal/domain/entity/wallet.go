@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	WALLET_ENTRY_EARN  = "earn"
+	WALLET_ENTRY_SPEND = "spend"
+)
+
+const (
+	WALLET_SOURCE_QUEST       = "quest"
+	WALLET_SOURCE_PURCHASE    = "purchase"
+	WALLET_SOURCE_REFUND      = "refund"
+	WALLET_SOURCE_ADMIN_GRANT = "admin_grant"
+)
+
+// Wallet holds the user's coins. The balance only changes together with a
+// ledger entry, so it always equals the sum of the user's ledger.
+type Wallet struct {
+	ID        primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Balance   int                `json:"balance" bson:"balance"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// LedgerEntry is an earn or spend of coins. Amount is positive for both, and
+// the idempotency key makes sure an entry is only recorded once.
+type LedgerEntry struct {
+	ID             primitive.ObjectID  `json:"id,omitempty" bson:"_id,omitempty"`
+	CreatedAt      time.Time           `json:"created_at" bson:"created_at"`
+	UserID         primitive.ObjectID  `json:"user_id" bson:"user_id"`
+	Type           string              `json:"type" bson:"type"`
+	Amount         int                 `json:"amount" bson:"amount"`
+	Source         string              `json:"source" bson:"source"`
+	RefID          primitive.ObjectID  `json:"ref_id,omitempty" bson:"ref_id,omitempty"`
+	Note           string              `json:"note" bson:"note"`
+	IdempotencyKey string              `json:"idempotency_key" bson:"idempotency_key"`
+	ActorID        *primitive.ObjectID `json:"actor_id" bson:"actor_id"`
+}
+
+// Delta is how much the entry changes the balance.
+func (entry *LedgerEntry) Delta() int {
+	if entry.Type == WALLET_ENTRY_SPEND {
+		return -entry.Amount
+	}
+	return entry.Amount
+}
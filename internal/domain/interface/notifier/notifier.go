@@ -0,0 +1,9 @@
+package notifier
+
+import "vietcard-backend/internal/domain/entity"
+
+// Notifier sends notifications through a single channel.
+type Notifier interface {
+	Channel() string
+	Notify(notification *entity.Notification) error
+}
@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"vietcard-backend/internal/domain/entity"
+)
+
+type AchievementRepository interface {
+	AwardBadge(badge *entity.UserBadge) (bool, error)
+	GetBadgesOfUser(userID *string) (*[]entity.UserBadge, error)
+}
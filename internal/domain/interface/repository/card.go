@@ -1,16 +1,24 @@
 package repository
 
 import (
+	"time"
 	"vietcard-backend/internal/delivery/http/request"
 	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type CardRepository interface {
 	CreateCard(card *entity.Card) (*entity.Card, error)
     CreateManyCards(cards *[]entity.Card) error
 	GetCardByID(id *string) (*entity.Card, error)
+	FindCardPlaces(query *entity.PlaceQuery) (*[]entity.MapPlace, error)
 	GetCardsByDeck(deckID *string) (*[]entity.Card, error)
 	UpdateCard(cardID *string, req *request.UpdateCardRequest) (*entity.Card, error)
 	UpdateCardReview(card *entity.Card) error
+	SetCardHidden(cardID primitive.ObjectID, hidden bool) error
+	CountDueCards(userID primitive.ObjectID, before time.Time) (int, error)
     DeleteCard(cardID *string) error
+	SearchCards(query *string, userID *string, scope string, skip int, limit int) (*[]entity.CardSearchHit, error)
+	StreamCardsByDecks(deckIDs []primitive.ObjectID, fn func(card *entity.Card) error) error
 }
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CommentRepository interface {
+	CreateComment(comment *entity.Comment) (*entity.Comment, error)
+	GetCommentByID(id *string) (*entity.Comment, error)
+	UpdateCommentContent(commentID *string, content *string) (*entity.Comment, error)
+	DeleteComment(commentID *string) (*entity.Comment, error)
+	IncrementReplyCount(commentID *string, delta int) error
+	SetCommentHidden(commentID primitive.ObjectID, hidden bool) error
+	SetUserCommentsHiddenByBan(userID primitive.ObjectID, hidden bool) error
+	GetComments(req *request.GetCommentsRequest) (*[]entity.Comment, string, error)
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"vietcard-backend/internal/delivery/http/request"
 	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type DeckRepository interface {
@@ -10,7 +12,20 @@ type DeckRepository interface {
 	GetDeckByID(id *string) (*entity.Deck, error)
 	UpdateDeck(deckID *string, req *request.UpdateDeckRequest) (*entity.Deck, error)
 	GetCardsAllDecksOfUser(userID *string) (*[]entity.DeckWithCards, error)
-	GetCardsAllDecks(userID *string) (*[]entity.DeckWithCards, error)
     DeleteDeck(deckID *string) error
     GetDeckWithCards(deckID *string) (*entity.DeckWithCards, error)
+	GetPublicDecks(req *request.DeckCatalogRequest) (*[]entity.DeckSummary, string, error)
+	SearchDecks(query *string, userID *string, scope string, skip int, limit int) (*[]entity.DeckSearchHit, error)
+	ApplyDeckRating(deckID *string, sumDelta int, countDelta int) (*entity.Deck, error)
+	IncrementCopies(deckID *string) error
+	IncrementViews(deckID *string) error
+	IncrementCommentCount(deckID *string, delta int) error
+	IncrementTotalCards(deckID *string, delta int) error
+	TouchDeck(deckID *string) error
+	SetDeckHidden(deckID primitive.ObjectID, hidden bool) error
+	SetUserDecksHiddenByBan(userID primitive.ObjectID, hidden bool) error
+	FindDeckPlaces(query *entity.PlaceQuery) (*[]entity.MapPlace, error)
+	GetAuthorDeckStats(userID *string) (int, int, error)
+	GetFeedDecks(authorIDs []primitive.ObjectID, req *request.GetFeedRequest) (*[]entity.DeckSummary, string, error)
+	StreamDecksOfUser(userID *string, fn func(deck *entity.Deck) error) error
 }
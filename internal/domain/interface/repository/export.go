@@ -0,0 +1,12 @@
+package repository
+
+import "vietcard-backend/internal/domain/entity"
+
+type ExportRepository interface {
+	CreateExportJob(job *entity.ExportJob) (*entity.ExportJob, error)
+	GetExportJobByID(id *string) (*entity.ExportJob, error)
+	GetExportJobByToken(token *string) (*entity.ExportJob, error)
+	GetLatestExportJobOfUser(userID *string) (*entity.ExportJob, error)
+	GetExpiredExportJobs() (*[]entity.ExportJob, error)
+	UpdateExportJob(job *entity.ExportJob, fromStatus string) (bool, error)
+}
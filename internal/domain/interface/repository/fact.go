@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"time"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FactRepository interface {
+	CreateFact(fact *entity.Fact) (bool, error)
+	GetFactByID(factID *string) (*entity.Fact, error)
+	GetFactByHash(contentHash string) (*entity.Fact, error)
+	UpdateFact(fact *entity.Fact) (bool, error)
+	DeleteFact(factID *string) (bool, error)
+	GetFacts(req *request.GetFactsRequest) (*[]entity.Fact, string, error)
+	CountFacts(locale string, category string) (int, error)
+	GetNthFact(locale string, category string, n int) (*entity.Fact, error)
+	GetRandomFact(locale string, category string, excludeIDs []primitive.ObjectID) (*entity.Fact, error)
+	MarkFactSeen(userID primitive.ObjectID, factID primitive.ObjectID) error
+	GetSeenFactIDs(userID *string, since time.Time) ([]primitive.ObjectID, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FollowRepository interface {
+	CreateFollow(follow *entity.Follow) (bool, error)
+	DeleteFollow(followerID *string, followeeID *string) (bool, error)
+	IsFollowing(followerID *string, followeeID *string) (bool, error)
+	GetFolloweeIDs(followerID *string) ([]primitive.ObjectID, error)
+}
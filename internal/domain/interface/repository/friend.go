@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FriendRepository interface {
+	CreateFriendship(friendship *entity.Friendship) (bool, error)
+	GetFriendship(userID *string, otherID *string) (*entity.Friendship, error)
+	AcceptFriendship(requesterID *string, addresseeID *string) (*entity.Friendship, error)
+	DeleteFriendship(friendshipID primitive.ObjectID) error
+	GetFriendshipsOfUser(userID *string) (*[]entity.Friendship, error)
+	GetFriendIDs(userID *string) ([]primitive.ObjectID, error)
+}
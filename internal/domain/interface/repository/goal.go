@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"time"
+	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type GoalRepository interface {
+	AddDailyProgress(userID primitive.ObjectID, day time.Time, xp int, reviews int, goal entity.DailyGoal) (*entity.DailyProgress, error)
+	CompleteDailyProgress(progressID primitive.ObjectID, completedAt time.Time) (bool, error)
+	GetDailyProgressSince(userID *string, from time.Time) (*[]entity.DailyProgress, error)
+}
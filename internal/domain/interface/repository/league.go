@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type LeagueRepository interface {
+	JoinLeague(week string, tier int) (*entity.League, error)
+	GetLeagueByID(id *string) (*entity.League, error)
+	GetOpenLeaguesBefore(week string) (*[]entity.League, error)
+	CloseLeague(leagueID primitive.ObjectID) error
+	CreateWeeklyXP(weeklyXP *entity.WeeklyXP) (bool, error)
+	GetWeeklyXP(userID *string, week string) (*entity.WeeklyXP, error)
+	GetWeeklyXPOfUsers(userIDs []primitive.ObjectID, week string) (*[]entity.WeeklyXP, error)
+	IncrementWeeklyXP(userID *string, week string, xp int) error
+	GetLeagueMembers(leagueID primitive.ObjectID) (*[]entity.WeeklyXP, error)
+	SetWeeklyResult(weeklyXPID primitive.ObjectID, rank int, result string) error
+	GetWeeklyRanking(week string, leagueID *primitive.ObjectID, userIDs []primitive.ObjectID, limit int) (*[]entity.LeaderboardEntry, error)
+	CountWeeklyXPAbove(week string, leagueID *primitive.ObjectID, userIDs []primitive.ObjectID, xp int) (int, error)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"time"
+	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MemberRepository interface {
+	UpsertMember(member *entity.DeckMember) (*entity.DeckMember, error)
+	GetMember(deckID *string, userID *string) (*entity.DeckMember, error)
+	GetMembersOfDeck(deckID *string) (*[]entity.DeckMemberInfo, error)
+	GetDeckIDsOfMember(userID *string) ([]primitive.ObjectID, error)
+	RemoveMember(deckID *string, userID *string) (bool, error)
+	DeleteDeckMembership(deckID *string) error
+	GetCardProgress(userID *string, deckID *string) (*[]entity.CardProgress, error)
+	UpsertCardProgress(progress *entity.CardProgress) error
+	DeleteCardProgress(cardID primitive.ObjectID) error
+	CountDueCardProgress(userID primitive.ObjectID, before time.Time) (int, error)
+	GetDeckProgress(userID *string, deckID *string) (*entity.DeckProgress, error)
+	UpsertDeckProgress(progress *entity.DeckProgress) error
+}
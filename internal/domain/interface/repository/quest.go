@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"time"
+	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type QuestRepository interface {
+	EnsureQuests(quests []entity.UserQuest) error
+	GetQuests(userID primitive.ObjectID, periodKeys []string) (*[]entity.UserQuest, error)
+	GetQuestByID(userID primitive.ObjectID, questID primitive.ObjectID) (*entity.UserQuest, error)
+	AddQuestProgress(questID primitive.ObjectID, amount int) (*entity.UserQuest, error)
+	CompleteQuest(questID primitive.ObjectID, completedAt time.Time) (bool, error)
+	ClaimQuest(userID primitive.ObjectID, questID primitive.ObjectID, claimedAt time.Time) (*entity.UserQuest, error)
+}
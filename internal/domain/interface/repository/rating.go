@@ -0,0 +1,8 @@
+package repository
+
+import "vietcard-backend/internal/domain/entity"
+
+type RatingRepository interface {
+	UpsertDeckRating(rating *entity.DeckRating) (*entity.DeckRating, error)
+	GetDeckRating(deckID *string, userID *string) (*entity.DeckRating, error)
+}
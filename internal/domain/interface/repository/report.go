@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ReportRepository interface {
+	CreateReport(report *entity.Report) (bool, error)
+	CountOpenReports(targetType string, targetID primitive.ObjectID) (int, error)
+	CloseReports(targetType string, targetID primitive.ObjectID, status string) error
+	GetModerationQueue(req *request.GetModerationQueueRequest) (*[]entity.ModerationQueueItem, error)
+	CreateModerationAction(action *entity.ModerationAction) (*entity.ModerationAction, error)
+	GetModerationActions(req *request.GetModerationLogRequest) (*[]entity.ModerationAction, string, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"time"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ReviewRepository interface {
+	CreateReviewLog(log *entity.ReviewLog) (*entity.ReviewLog, error)
+	GetLastReviewLog(userID *string) (*entity.ReviewLog, error)
+	StreamReviewLogsOfUser(userID *string, fn func(log *entity.ReviewLog) error) error
+	GetReviewStats(userID *string) (int, int, error)
+	GetActivityDays(userID *string, timeZone string) (*[]entity.ActivityDay, error)
+	GetFlaggedReviewLogs(req *request.GetFlaggedReviewsRequest) (*[]entity.ReviewLog, string, error)
+	CountDecksReviewedOn(userIDs []primitive.ObjectID, day time.Time) (map[primitive.ObjectID]int, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"time"
+	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SessionRepository interface {
+	CreateSession(session *entity.Session) (*entity.Session, error)
+	GetSession(sessionID primitive.ObjectID) (*entity.Session, error)
+	GetUserSessions(userID primitive.ObjectID, now time.Time) (*[]entity.Session, error)
+	TouchSession(sessionID primitive.ObjectID, device *entity.SessionDevice, now time.Time, expiresAt time.Time) error
+	SeeSession(sessionID primitive.ObjectID, ip string, now time.Time) error
+	RevokeSession(sessionID primitive.ObjectID, reason string) error
+	RevokeUserSessions(userID primitive.ObjectID, exceptSessionID *primitive.ObjectID, reason string) (int, error)
+	CreateRefreshToken(token *entity.RefreshToken) (*entity.RefreshToken, error)
+	GetRefreshToken(tokenHash string) (*entity.RefreshToken, error)
+	RotateRefreshToken(tokenID primitive.ObjectID, now time.Time) (bool, error)
+}
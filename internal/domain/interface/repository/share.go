@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"time"
+	"vietcard-backend/internal/domain/entity"
+)
+
+type ShareRepository interface {
+	CreateShareLink(link *entity.ShareLink) (*entity.ShareLink, error)
+	GetShareLinkByID(id *string) (*entity.ShareLink, error)
+	GetShareLinkByToken(token *string) (*entity.ShareLink, error)
+	GetShareLinksOfDeck(deckID *string) (*[]entity.ShareLink, error)
+	RevokeShareLink(id *string) error
+	GetFailedAttempts(keys []string, windowStart time.Time) (map[string]int, error)
+	AddFailedAttempt(keys []string, windowStart time.Time) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"time"
+	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type StreakRepository interface {
+	RecordStreakDays(days []entity.StreakDay) error
+	RepairStreakDays(userID primitive.ObjectID, from time.Time, to time.Time) error
+	GetStreakDays(userID *string, from time.Time) (*[]entity.StreakDay, error)
+}
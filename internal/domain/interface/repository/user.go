@@ -1,16 +1,30 @@
 package repository
 
 import (
+	"time"
 	"vietcard-backend/internal/delivery/http/request"
 	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type UserRepository interface {
 	Create(user *entity.User) (string, error)
 	GetByEmail(email *string) (*entity.User, error)
 	GetByID(id *string) (*entity.User, error)
+	GetByIDs(ids []primitive.ObjectID) (*[]entity.User, error)
 	UpdateUser(userID *string, req *request.UpdateUserRequest) (*entity.User, error)
 	UpdateUserXP(user *entity.User) error
-	CreateFact(*entity.Fact) error
-	GetFact() (*entity.Fact, error)
+	UpdateUserStreak(user *entity.User, lastStreak time.Time, freezeDelta int) (bool, error)
+	IncrementLifetimeXP(userID primitive.ObjectID, XP int) (*entity.User, error)
+	SetLevel(user *entity.User) error
+	ResetLevels(xpToLevelUp int) error
+	GetReminderUsers(sentBefore time.Time) (*[]entity.User, error)
+	ClaimReminder(userID primitive.ObjectID, dueAt time.Time, now time.Time) (bool, error)
+	SetLeagueTier(userID primitive.ObjectID, tier int) error
+	SetBanned(userID primitive.ObjectID, banned bool) error
+	IncrementWarningCount(userID primitive.ObjectID) error
+	AddStreakFreezes(userID primitive.ObjectID, count int, max int) (bool, error)
+	SetXPBoost(userID primitive.ObjectID, boost *entity.XPBoost) error
+	IncrementFollowCounts(followerID *string, followeeID *string, delta int) error
 }
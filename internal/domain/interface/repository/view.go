@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"time"
+	"vietcard-backend/internal/domain/entity"
+)
+
+type ViewRepository interface {
+	CreateViewEvent(event *entity.DeckViewEvent) (bool, error)
+	IncrementDailyViews(deckID *string, day string) error
+	GetDailyViews(deckID *string, from time.Time) (*[]entity.DeckViewDaily, error)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WalletRepository interface {
+	GetWallet(userID *string) (*entity.Wallet, error)
+	ApplyLedgerEntry(entry *entity.LedgerEntry) (bool, bool, error)
+	GetLedgerEntry(userID primitive.ObjectID, idempotencyKey string) (*entity.LedgerEntry, error)
+	GetLedger(userID *string, limit int, cursor string) (*[]entity.LedgerEntry, string, error)
+}
+
+type ShopRepository interface {
+	CreateShopItem(item *entity.ShopItem) (*entity.ShopItem, error)
+	UpdateShopItem(itemID *string, req *request.UpdateShopItemRequest) (*entity.ShopItem, error)
+	GetShopItem(itemID *string) (*entity.ShopItem, error)
+	GetShopItems(activeOnly bool) (*[]entity.ShopItem, error)
+	CreatePurchase(purchase *entity.Purchase) (bool, error)
+	GetPurchase(userID primitive.ObjectID, idempotencyKey string) (*entity.Purchase, error)
+	SetPurchaseStatus(purchaseID primitive.ObjectID, status string, failReason string) (bool, error)
+	AddUserItem(item *entity.UserItem) (bool, error)
+	GetUserItem(userID primitive.ObjectID, itemID primitive.ObjectID) (*entity.UserItem, error)
+	GetUserItems(userID *string) (*[]entity.UserItem, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type XPRepository interface {
+	CreateXPEntry(entry *entity.XPEntry) (bool, error)
+	DeleteXPEntry(entryID primitive.ObjectID) error
+	StreamXPLedgerOfUser(userID *string, fn func(entry *entity.XPEntry) error) error
+	GetXPTotals() (*[]entity.XPTotal, error)
+	GetXPLedger(userID *string, limit int, cursor string) (*[]entity.XPEntry, string, error)
+}
@@ -0,0 +1,10 @@
+package usecase
+
+import (
+	"vietcard-backend/internal/domain/entity"
+)
+
+type AchievementUsecase interface {
+	Evaluate(userID *string, event string) ([]entity.AchievementProgress, error)
+	GetAchievements(userID *string) (*[]entity.AchievementProgress, error)
+}
@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+)
+
+type CommentUsecase interface {
+	CreateComment(userID *string, req *request.CreateCommentRequest) (*entity.Comment, error)
+	UpdateComment(userID *string, req *request.UpdateCommentRequest) (*entity.Comment, error)
+	DeleteComment(userID *string, commentID *string) (*entity.Comment, error)
+	GetComments(viewerUserID string, req *request.GetCommentsRequest) (*[]entity.Comment, string, error)
+}
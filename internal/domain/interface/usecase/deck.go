@@ -13,4 +13,5 @@ type DeckUsecase interface {
 	CopyDeck(userID *string, deckID *string) (*entity.DeckWithCards, *entity.DeckWithReviewCards, error)
 	GetDecksWithCards(userID *string) (*[]entity.DeckWithCards, *[]entity.DeckWithCards, *[]entity.DeckWithReviewCards, error)
 	DeleteDeck(deckID *string) error
+	GetPublicDeckCatalog(req *request.DeckCatalogRequest) (*[]entity.DeckSummary, string, error)
 }
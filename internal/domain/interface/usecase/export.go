@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"io"
+	"vietcard-backend/internal/domain/entity"
+)
+
+type ExportUsecase interface {
+	CreateExportJob(userID *string) (*entity.ExportJob, error)
+	GetExportJob(userID *string, jobID *string) (*entity.ExportJob, error)
+	GetExportJobByToken(token *string) (*entity.ExportJob, error)
+	StreamExport(userID *string, w io.Writer) error
+}
@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+)
+
+type FactUsecase interface {
+	CreateFact(req *request.CreateFactRequest) (*entity.Fact, bool, error)
+	UpdateFact(req *request.UpdateFactRequest) (*entity.Fact, bool, error)
+	DeleteFact(factID *string) (bool, error)
+	GetFacts(req *request.GetFactsRequest) (*[]entity.Fact, string, error)
+	GetFact(userID string, req *request.GetFactRequest) (*entity.Fact, error)
+}
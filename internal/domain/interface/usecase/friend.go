@@ -0,0 +1,12 @@
+package usecase
+
+import "vietcard-backend/internal/domain/entity"
+
+type FriendUsecase interface {
+	SendFriendRequest(userID *string, otherID *string) (*entity.Friendship, error)
+	AcceptFriendRequest(userID *string, requesterID *string) (*entity.Friendship, error)
+	DeclineFriendRequest(userID *string, requesterID *string) error
+	RemoveFriend(userID *string, otherID *string) error
+	GetFriends(userID *string) (*[]entity.Friend, error)
+	GetFriendsActivity(userID *string) (*[]entity.FriendActivity, error)
+}
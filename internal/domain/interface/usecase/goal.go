@@ -0,0 +1,10 @@
+package usecase
+
+import (
+	"vietcard-backend/internal/domain/entity"
+)
+
+type GoalUsecase interface {
+	RecordProgress(userID *string, xp int, reviews int) (*entity.DailyProgress, error)
+	GetDailyGoal(userID *string, days int) (*entity.DailyGoalStats, error)
+}
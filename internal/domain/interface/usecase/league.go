@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"time"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+)
+
+type LeagueUsecase interface {
+	RecordXP(userID *string, xp int) error
+	GetLeaderboard(userID *string, req *request.LeaderboardRequest) (*entity.Leaderboard, error)
+	CloseFinishedWeeks(now time.Time) error
+}
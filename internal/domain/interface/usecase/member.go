@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+)
+
+type MemberUsecase interface {
+	GetDeckRole(userID *string, deckID *string) (string, *entity.Deck, error)
+	InviteMember(userID *string, req *request.InviteMemberRequest) (*entity.DeckMember, error)
+	RemoveMember(userID *string, req *request.RemoveMemberRequest) error
+	GetMembers(userID *string, deckID *string) (*[]entity.DeckMemberInfo, error)
+	DeleteDeckMembership(deckID *string) error
+	GetSharedDecksWithReviewCards(userID *string) (*[]entity.DeckWithReviewCards, error)
+}
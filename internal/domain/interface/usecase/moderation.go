@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+)
+
+type ModerationUsecase interface {
+	ReportContent(userID *string, req *request.ReportContentRequest) (*entity.Report, error)
+	GetModerationQueue(req *request.GetModerationQueueRequest) (*[]entity.ModerationQueueItem, error)
+	ModerateContent(moderatorID *string, req *request.ModerateContentRequest) (*entity.ModerationAction, error)
+	GetModerationLog(req *request.GetModerationLogRequest) (*[]entity.ModerationAction, string, error)
+}
@@ -0,0 +1,7 @@
+package usecase
+
+import "vietcard-backend/internal/domain/entity"
+
+type PlaceUsecase interface {
+	FindPlaces(query *entity.PlaceQuery, kind string) (*[]entity.MapPlace, error)
+}
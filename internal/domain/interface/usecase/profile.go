@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+)
+
+type ProfileUsecase interface {
+	GetAuthorProfile(authorID *string, viewerUserID string) (*entity.AuthorProfile, error)
+	FollowUser(userID *string, authorID *string) (*entity.User, error)
+	UnfollowUser(userID *string, authorID *string) (*entity.User, error)
+	GetFeed(userID *string, req *request.GetFeedRequest) (*[]entity.DeckSummary, string, error)
+}
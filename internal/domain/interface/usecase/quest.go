@@ -0,0 +1,12 @@
+package usecase
+
+import (
+	"vietcard-backend/internal/domain/entity"
+)
+
+type QuestUsecase interface {
+	GetQuests(userID *string) (*[]entity.UserQuest, error)
+	RecordEvent(userID *string, event *entity.QuestEvent) ([]entity.UserQuest, error)
+	GetClaimableQuest(userID *string, questID *string) (*entity.UserQuest, error)
+	ClaimQuest(userID *string, questID *string) (*entity.UserQuest, error)
+}
@@ -0,0 +1,8 @@
+package usecase
+
+import "vietcard-backend/internal/domain/entity"
+
+type RatingUsecase interface {
+	RateDeck(userID *string, deckID *string, rating int) (*entity.Deck, error)
+	GetMyDeckRating(userID *string, deckID *string) (*entity.DeckRating, error)
+}
@@ -4,7 +4,7 @@ import "vietcard-backend/internal/domain/entity"
 
 
 type RefreshTokenUsecase interface {
-	CreateAccessToken(user *entity.User, secret *string, expiry int) (accessToken string, err error)
-	CreateRefreshToken(user *entity.User, secret *string, expiry int) (refreshToken string, err error)
-	ExtractIDFromToken(requestToken *string, secret *string) (string, error)
+	CreateAccessToken(user *entity.User, session *entity.Session, secret *string, expiry int) (accessToken string, err error)
+	CreateSession(user *entity.User, device *entity.SessionDevice, expiry int) (*entity.Session, string, error)
+	RotateRefreshToken(refreshToken *string, device *entity.SessionDevice, expiry int) (*entity.Session, string, error)
 }
@@ -0,0 +1,7 @@
+package usecase
+
+import "time"
+
+type ReminderUsecase interface {
+	SendDueReminders(now time.Time) error
+}
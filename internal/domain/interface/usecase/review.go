@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"time"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+)
+
+type ReviewUsecase interface {
+	SubmitReview(userID *string, deck *entity.Deck, isOwner bool, req *request.UpdateReviewCardsRequest) (*entity.ReviewResult, error)
+	GetFlaggedReviews(req *request.GetFlaggedReviewsRequest) (*[]entity.ReviewLog, string, error)
+	GetActivity(userID *string, from time.Time, to time.Time, loc *time.Location) (*entity.ActivityCalendar, error)
+}
@@ -0,0 +1,10 @@
+package usecase
+
+import (
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+)
+
+type SearchUsecase interface {
+	Search(userID *string, req *request.SearchRequest) (*[]entity.DeckSearchHit, *[]entity.CardSearchHit, error)
+}
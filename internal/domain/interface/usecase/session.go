@@ -0,0 +1,9 @@
+package usecase
+
+import "vietcard-backend/internal/domain/entity"
+
+type SessionUsecase interface {
+	GetSessions(userID *string, currentSessionID *string) (*[]entity.Session, error)
+	RevokeSession(userID *string, sessionID *string, reason string) error
+	RevokeSessions(userID *string, exceptSessionID *string, reason string) (int, error)
+}
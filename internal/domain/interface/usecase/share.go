@@ -0,0 +1,13 @@
+package usecase
+
+import "vietcard-backend/internal/domain/entity"
+
+type ShareUsecase interface {
+	CreateShareLink(userID *string, link *entity.ShareLink) (*entity.ShareLink, error)
+	GetShareLinks(userID *string, deckID *string) (*[]entity.ShareLink, error)
+	RevokeShareLink(userID *string, shareLinkID *string) error
+	GetShareLinkByToken(token *string) (*entity.ShareLink, error)
+	GetSharedDeck(link *entity.ShareLink) (*entity.DeckWithCards, error)
+	IsShareLinkLocked(token string, ip string) (bool, error)
+	RecordFailedShareAttempt(token string, ip string) error
+}
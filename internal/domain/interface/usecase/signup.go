@@ -5,6 +5,5 @@ import "vietcard-backend/internal/domain/entity"
 type SignupUsecase interface {
 	Create(user *entity.User) (string, error)
 	GetUserByEmail(email *string) (*entity.User, error)
-	CreateAccessToken(user *entity.User, secret *string, expiry int) (accessToken string, err error)
-	CreateRefreshToken(user *entity.User, secret *string, expiry int) (refreshToken string, err error)
+	CreateAccessToken(user *entity.User, session *entity.Session, secret *string, expiry int) (accessToken string, err error)
 }
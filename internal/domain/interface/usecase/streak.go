@@ -0,0 +1,10 @@
+package usecase
+
+import (
+	"vietcard-backend/internal/domain/entity"
+)
+
+type StreakUsecase interface {
+	GetStreak(userID *string, days int) (*entity.StreakInfo, error)
+	RepairStreak(userID *string) (*entity.User, error)
+}
@@ -9,7 +9,7 @@ type UserUsecase interface {
 	GetUserByID(id *string) (*entity.User, error)
 	GetUserByEmail(email *string) (*entity.User, error)
 	UpdateUser(userID *string, req *request.UpdateUserRequest) (*entity.User, error)
-	AddXPToUser(userID *string, XP int) (*entity.User, error)
-	CreateFact(*entity.Fact) error
-	GetFact() (*entity.Fact, error)
+	AddXPToUser(userID *string, entry *entity.XPEntry, countStreak bool) (*entity.User, error)
+	GetXPHistory(userID *string, limit int, cursor string) (*[]entity.XPEntry, string, error)
+	RecomputeLevels() (int, error)
 }
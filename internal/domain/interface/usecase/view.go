@@ -0,0 +1,8 @@
+package usecase
+
+import "vietcard-backend/internal/domain/entity"
+
+type ViewUsecase interface {
+	RecordDeckView(deckID *string, viewerUserID string, ip string, userAgent string, fingerprint string) (bool, *entity.Deck, error)
+	GetDeckViewTrend(deckID *string, days int) (*[]entity.DeckViewDaily, error)
+}
@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WalletUsecase interface {
+	GetWallet(userID *string) (*entity.Wallet, *[]entity.UserItem, error)
+	GetLedger(userID *string, limit int, cursor string) (*[]entity.LedgerEntry, string, error)
+	Credit(userID *string, amount int, source string, refID primitive.ObjectID, idempotencyKey string) (*entity.LedgerEntry, error)
+	GrantCoins(adminID *string, req *request.GrantCoinsRequest) (*entity.LedgerEntry, error)
+	GetShopItems(activeOnly bool) (*[]entity.ShopItem, error)
+	CreateShopItem(req *request.CreateShopItemRequest) (*entity.ShopItem, error)
+	UpdateShopItem(req *request.UpdateShopItemRequest) (*entity.ShopItem, error)
+	PurchaseItem(userID *string, req *request.PurchaseItemRequest) (*entity.Purchase, error)
+}
@@ -0,0 +1,43 @@
+package memnotifier
+
+import (
+	"sync"
+	"vietcard-backend/internal/domain/entity"
+)
+
+// MemNotifier keeps the notifications in memory instead of sending them, for
+// tests and local development.
+type MemNotifier struct {
+	channel string
+	mu      sync.Mutex
+	sent    []entity.Notification
+}
+
+func NewMemNotifier(channel string) *MemNotifier {
+	return &MemNotifier{channel: channel}
+}
+
+func (mn *MemNotifier) Channel() string {
+	return mn.channel
+}
+
+func (mn *MemNotifier) Notify(notification *entity.Notification) error {
+	mn.mu.Lock()
+	defer mn.mu.Unlock()
+	mn.sent = append(mn.sent, *notification)
+	return nil
+}
+
+// Sent returns the notifications received so far, oldest first.
+func (mn *MemNotifier) Sent() []entity.Notification {
+	mn.mu.Lock()
+	defer mn.mu.Unlock()
+	return append([]entity.Notification{}, mn.sent...)
+}
+
+// Reset forgets the notifications received so far.
+func (mn *MemNotifier) Reset() {
+	mn.mu.Lock()
+	defer mn.mu.Unlock()
+	mn.sent = nil
+}
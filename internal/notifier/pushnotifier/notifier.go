@@ -0,0 +1,71 @@
+package pushnotifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/notifier"
+)
+
+const PUSH_TIMEOUT_SECOND = 10
+
+type pushNotifier struct {
+	endpoint  string
+	serverKey string
+	client    *http.Client
+}
+
+type pushMessage struct {
+	To           string      `json:"to"`
+	Notification pushContent `json:"notification"`
+}
+
+type pushContent struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// NewPushNotifier sends notifications to device tokens through a push
+// provider's HTTP API, such as the FCM legacy send endpoint.
+func NewPushNotifier(endpoint string, serverKey string) notifier.Notifier {
+	return &pushNotifier{
+		endpoint:  endpoint,
+		serverKey: serverKey,
+		client:    &http.Client{Timeout: PUSH_TIMEOUT_SECOND * time.Second},
+	}
+}
+
+func (pn *pushNotifier) Channel() string {
+	return entity.NOTIFY_CHANNEL_PUSH
+}
+
+func (pn *pushNotifier) Notify(notification *entity.Notification) error {
+	body, err := json.Marshal(pushMessage{
+		To: notification.To,
+		Notification: pushContent{
+			Title: notification.Title,
+			Body:  notification.Body,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, pn.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "key="+pn.serverKey)
+	resp, err := pn.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("push provider responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package smtpnotifier
+
+import (
+	"fmt"
+	"net/smtp"
+	"strings"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/notifier"
+)
+
+type smtpNotifier struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPNotifier sends notifications by email through the SMTP server,
+// authenticating only if a username is given.
+func NewSMTPNotifier(host string, port int, username string, password string, from string) notifier.Notifier {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &smtpNotifier{
+		addr: fmt.Sprintf("%s:%d", host, port),
+		auth: auth,
+		from: from,
+	}
+}
+
+func (sn *smtpNotifier) Channel() string {
+	return entity.NOTIFY_CHANNEL_EMAIL
+}
+
+func (sn *smtpNotifier) Notify(notification *entity.Notification) error {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", sn.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", notification.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", notification.Title)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(notification.Body)
+	msg.WriteString("\r\n")
+	return smtp.SendMail(sn.addr, sn.auth, sn.from, []string{notification.To}, []byte(msg.String()))
+}
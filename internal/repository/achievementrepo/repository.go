@@ -0,0 +1,54 @@
+package achievementrepo
+
+import (
+	"context"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type achievementRepository struct {
+	db      *mongo.Database
+	colName string
+}
+
+func NewAchievementRepository(db *mongo.Database) repository.AchievementRepository {
+	return &achievementRepository{
+		db:      db,
+		colName: "user_badges",
+	}
+}
+
+// AwardBadge returns false if the user already has the badge.
+func (ar *achievementRepository) AwardBadge(badge *entity.UserBadge) (bool, error) {
+	result, err := ar.db.Collection(ar.colName).InsertOne(context.TODO(), badge)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	badge.ID = result.InsertedID.(primitive.ObjectID)
+	return true, nil
+}
+
+func (ar *achievementRepository) GetBadgesOfUser(userID *string) (*[]entity.UserBadge, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	option := options.Find().SetSort(bson.D{{Key: "unlocked_at", Value: 1}})
+	cursor, err := ar.db.Collection(ar.colName).Find(context.TODO(), bson.D{{Key: "user_id", Value: uID}}, option)
+	if err != nil {
+		return nil, err
+	}
+	badges := []entity.UserBadge{}
+	if err = cursor.All(context.TODO(), &badges); err != nil {
+		return nil, err
+	}
+	return &badges, nil
+}
@@ -3,9 +3,12 @@ package cardrepo
 import (
 	"context"
 	"errors"
+	"time"
 	"vietcard-backend/internal/delivery/http/request"
 	"vietcard-backend/internal/domain/entity"
 	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/repository/geoquery"
+	"vietcard-backend/pkg/textutil"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -27,6 +30,7 @@ func NewCardRepository(db *mongo.Database) repository.CardRepository {
 
 func (cr *cardRepository) CreateCard(card *entity.Card) (*entity.Card, error) {
 	card.SetDefault()
+	card.SetSearchText()
 	result, err := cr.db.Collection(cr.colName).InsertOne(context.TODO(), card)
 	if err != nil {
 		return nil, err
@@ -45,6 +49,7 @@ func (cr *cardRepository) CreateCard(card *entity.Card) (*entity.Card, error) {
 func (cr *cardRepository) CreateManyCards(cards *[]entity.Card) error {
 	for i := range *cards {
 		(*cards)[i].SetDefault()
+		(*cards)[i].SetSearchText()
 	}
 	newCards := make([]interface{}, len(*cards))
 	for i := range *cards {
@@ -99,12 +104,23 @@ func (cr *cardRepository) UpdateCard(cardID *string, req *request.UpdateCardRequ
 	}
 	filter := bson.D{{Key: "_id", Value: cID}}
 	update := bson.D{{Key: "$set", Value: *req}}
+	if unset := geoquery.UnsetPlace(req.ClearLocation, req.ClearRegion); len(unset) > 0 {
+		update = append(update, bson.E{Key: "$unset", Value: unset})
+	}
 	option := options.FindOneAndUpdate().SetReturnDocument(options.After)
 	var updatedCard entity.Card
 	err = cr.db.Collection(cr.colName).FindOneAndUpdate(context.TODO(), filter, update, option).Decode(&updatedCard)
 	if err != nil {
 		return nil, err
 	}
+	if req.Question != nil || req.Answer != nil {
+		updatedCard.SetSearchText()
+		update = bson.D{{Key: "$set", Value: bson.D{{Key: "search_text", Value: updatedCard.SearchText}}}}
+		_, err = cr.db.Collection(cr.colName).UpdateOne(context.TODO(), filter, update)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &updatedCard, nil
 }
 
@@ -129,6 +145,18 @@ func (cr *cardRepository) GetCardsByDeck(deckID *string) (*[]entity.Card, error)
 	return &cards, nil
 }
 
+// CountDueCards returns how many visible cards of the user's own decks are
+// due for review by before.
+func (cr *cardRepository) CountDueCards(userID primitive.ObjectID, before time.Time) (int, error) {
+	filter := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "next_review", Value: bson.D{{Key: "$lte", Value: before}}},
+		{Key: "is_hidden", Value: bson.D{{Key: "$ne", Value: true}}},
+	}
+	count, err := cr.db.Collection(cr.colName).CountDocuments(context.TODO(), filter)
+	return int(count), err
+}
+
 func (cr *cardRepository) DeleteCard(cardID *string) error {
 	cID, err := primitive.ObjectIDFromHex(*cardID)
 	if err != nil {
@@ -142,3 +170,114 @@ func (cr *cardRepository) DeleteCard(cardID *string) error {
 
 	return nil
 }
+
+func (cr *cardRepository) StreamCardsByDecks(deckIDs []primitive.ObjectID, fn func(card *entity.Card) error) error {
+	if len(deckIDs) == 0 {
+		return nil
+	}
+	filter := bson.D{{Key: "deck_id", Value: bson.D{{Key: "$in", Value: deckIDs}}}}
+	option := options.Find().SetSort(bson.D{{Key: "deck_id", Value: 1}, {Key: "created_at", Value: 1}})
+	cursor, err := cr.db.Collection(cr.colName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.TODO())
+	for cursor.Next(context.TODO()) {
+		var card entity.Card
+		if err = cursor.Decode(&card); err != nil {
+			return err
+		}
+		if err = fn(&card); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// publicCardUnset are the fields of the owner's review state, which public
+// search hits leave out.
+var publicCardUnset = bson.A{"deck", "last_review", "next_review", "num_reviews", "sm2_n", "sm2_ef", "sm2_i"}
+
+func (cr *cardRepository) SearchCards(query *string, userID *string, scope string, skip int, limit int) (*[]entity.CardSearchHit, error) {
+	deckFilter := bson.D{{Key: "deck.is_public", Value: true}, {Key: "is_hidden", Value: bson.D{{Key: "$ne", Value: true}}}}
+	var unset interface{} = publicCardUnset
+	if scope == entity.SEARCH_SCOPE_MINE {
+		uID, err := primitive.ObjectIDFromHex(*userID)
+		if err != nil {
+			return nil, err
+		}
+		deckFilter = bson.D{{Key: "deck.user_id", Value: uID}}
+		unset = "deck"
+	}
+
+	pipeline := bson.A{
+		bson.D{{Key: "$match", Value: bson.D{{Key: "$text", Value: bson.D{{Key: "$search", Value: textutil.TextSearch(*query)}}}}}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "score", Value: bson.D{{Key: "$meta", Value: "textScore"}}}}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "score", Value: -1}, {Key: "_id", Value: -1}}}},
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: "decks"},
+			{Key: "localField", Value: "deck_id"},
+			{Key: "foreignField", Value: "_id"},
+			{Key: "as", Value: "deck"},
+		}}},
+		bson.D{{Key: "$unwind", Value: "$deck"}},
+		bson.D{{Key: "$match", Value: deckFilter}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "deck_name", Value: "$deck.name"}}}},
+		bson.D{{Key: "$unset", Value: unset}},
+		bson.D{{Key: "$skip", Value: skip}},
+		bson.D{{Key: "$limit", Value: limit}},
+	}
+	cursor, err := cr.db.Collection(cr.colName).Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+	hits := []entity.CardSearchHit{}
+	if err = cursor.All(context.TODO(), &hits); err != nil {
+		return nil, err
+	}
+	return &hits, nil
+}
+
+// FindCardPlaces returns the geolocated cards of public decks selected by
+// query.
+func (cr *cardRepository) FindCardPlaces(query *entity.PlaceQuery) (*[]entity.MapPlace, error) {
+	pipeline := geoquery.Pipeline(query, bson.D{})
+	pipeline = append(pipeline,
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: "decks"},
+			{Key: "localField", Value: "deck_id"},
+			{Key: "foreignField", Value: "_id"},
+			{Key: "as", Value: "deck"},
+		}}},
+		bson.D{{Key: "$unwind", Value: "$deck"}},
+		bson.D{{Key: "$match", Value: bson.D{{Key: "deck.is_public", Value: true}, {Key: "is_hidden", Value: bson.D{{Key: "$ne", Value: true}}}}}},
+		bson.D{{Key: "$limit", Value: query.Limit}},
+		bson.D{{Key: "$project", Value: bson.D{
+			{Key: "kind", Value: entity.PLACE_KIND_CARD},
+			{Key: "deck_id", Value: 1},
+			{Key: "deck_name", Value: "$deck.name"},
+			{Key: "title", Value: "$question"},
+			{Key: "image_url", Value: "$question_img_url"},
+			{Key: "location", Value: 1},
+			{Key: "region", Value: 1},
+			{Key: "distance_m", Value: 1},
+		}}},
+	)
+	cursor, err := cr.db.Collection(cr.colName).Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	places := []entity.MapPlace{}
+	if err = cursor.All(context.TODO(), &places); err != nil {
+		return nil, err
+	}
+	return &places, nil
+}
+
+func (cr *cardRepository) SetCardHidden(cardID primitive.ObjectID, hidden bool) error {
+	filter := bson.D{{Key: "_id", Value: cardID}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "is_hidden", Value: hidden}}}}
+	_, err := cr.db.Collection(cr.colName).UpdateOne(context.TODO(), filter, update)
+	return err
+}
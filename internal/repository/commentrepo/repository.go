@@ -0,0 +1,193 @@
+package commentrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/pkg/pagination"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commentRepository struct {
+	db      *mongo.Database
+	colName string
+}
+
+func NewCommentRepository(db *mongo.Database) repository.CommentRepository {
+	return &commentRepository{
+		db:      db,
+		colName: "comments",
+	}
+}
+
+func (cr *commentRepository) CreateComment(comment *entity.Comment) (*entity.Comment, error) {
+	comment.SetDefault()
+	result, err := cr.db.Collection(cr.colName).InsertOne(context.TODO(), comment)
+	if err != nil {
+		return nil, err
+	}
+
+	insertedID, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return nil, errors.New("failed to get inserted ID")
+	}
+
+	comment.ID = insertedID
+
+	return comment, nil
+}
+
+func (cr *commentRepository) GetCommentByID(id *string) (*entity.Comment, error) {
+	oID, err := primitive.ObjectIDFromHex(*id)
+	if err != nil {
+		return nil, err
+	}
+	var comment entity.Comment
+	err = cr.db.Collection(cr.colName).FindOne(context.TODO(), bson.D{{Key: "_id", Value: oID}}).Decode(&comment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (cr *commentRepository) UpdateCommentContent(commentID *string, content *string) (*entity.Comment, error) {
+	cID, err := primitive.ObjectIDFromHex(*commentID)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{{Key: "_id", Value: cID}, {Key: "is_deleted", Value: false}}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "content", Value: *content},
+		{Key: "is_edited", Value: true},
+		{Key: "updated_at", Value: time.Now()},
+	}}}
+	option := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var updatedComment entity.Comment
+	err = cr.db.Collection(cr.colName).FindOneAndUpdate(context.TODO(), filter, update, option).Decode(&updatedComment)
+	if err != nil {
+		return nil, err
+	}
+	return &updatedComment, nil
+}
+
+// DeleteComment soft deletes a comment so its replies keep their thread. It
+// returns nil if the comment was already deleted.
+func (cr *commentRepository) DeleteComment(commentID *string) (*entity.Comment, error) {
+	cID, err := primitive.ObjectIDFromHex(*commentID)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{{Key: "_id", Value: cID}, {Key: "is_deleted", Value: false}}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "content", Value: ""},
+		{Key: "is_deleted", Value: true},
+		{Key: "updated_at", Value: time.Now()},
+	}}}
+	option := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var deletedComment entity.Comment
+	err = cr.db.Collection(cr.colName).FindOneAndUpdate(context.TODO(), filter, update, option).Decode(&deletedComment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &deletedComment, nil
+}
+
+func (cr *commentRepository) IncrementReplyCount(commentID *string, delta int) error {
+	cID, err := primitive.ObjectIDFromHex(*commentID)
+	if err != nil {
+		return err
+	}
+	filter := bson.D{{Key: "_id", Value: cID}}
+	update := bson.D{{Key: "$inc", Value: bson.D{{Key: "reply_count", Value: delta}}}}
+	_, err = cr.db.Collection(cr.colName).UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+// GetComments returns one page of the comments directly under a deck, a card
+// or a parent comment, oldest first.
+func (cr *commentRepository) GetComments(req *request.GetCommentsRequest) (*[]entity.Comment, string, error) {
+	dID, err := primitive.ObjectIDFromHex(req.DeckID)
+	if err != nil {
+		return nil, "", err
+	}
+	filter := bson.D{{Key: "deck_id", Value: dID}}
+	for _, field := range []struct {
+		key   string
+		value string
+	}{{"card_id", req.CardID}, {"parent_id", req.ParentID}} {
+		if field.value == "" {
+			filter = append(filter, bson.E{Key: field.key, Value: nil})
+			continue
+		}
+		oID, err := primitive.ObjectIDFromHex(field.value)
+		if err != nil {
+			return nil, "", err
+		}
+		filter = append(filter, bson.E{Key: field.key, Value: oID})
+	}
+	if req.Cursor != "" {
+		cursor, err := pagination.DecodeCursor(req.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$gt", Value: cursor.ID}}})
+	}
+
+	limit := pagination.NormalizeLimit(req.Limit)
+	option := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit + 1))
+	cursor, err := cr.db.Collection(cr.colName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, "", err
+	}
+	comments := []entity.Comment{}
+	if err = cursor.All(context.TODO(), &comments); err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(comments) > limit {
+		comments = comments[:limit]
+		nextCursor = pagination.EncodeCursor(nil, comments[limit-1].ID)
+	}
+	return &comments, nextCursor, nil
+}
+
+func (cr *commentRepository) SetCommentHidden(commentID primitive.ObjectID, hidden bool) error {
+	filter := bson.D{{Key: "_id", Value: commentID}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "is_hidden", Value: hidden}}}}
+	_, err := cr.db.Collection(cr.colName).UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+// SetUserCommentsHiddenByBan hides the banned user's comments, or brings back
+// the comments hidden by the ban.
+func (cr *commentRepository) SetUserCommentsHiddenByBan(userID primitive.ObjectID, hidden bool) error {
+	var filter, update bson.D
+	if hidden {
+		filter = bson.D{{Key: "user_id", Value: userID}, {Key: "is_hidden", Value: false}, {Key: "is_deleted", Value: false}}
+		update = bson.D{{Key: "$set", Value: bson.D{{Key: "is_hidden", Value: true}, {Key: "hidden_by_ban", Value: true}}}}
+	} else {
+		filter = bson.D{{Key: "user_id", Value: userID}, {Key: "hidden_by_ban", Value: true}}
+		update = bson.D{
+			{Key: "$set", Value: bson.D{{Key: "is_hidden", Value: false}}},
+			{Key: "$unset", Value: bson.D{{Key: "hidden_by_ban", Value: ""}}},
+		}
+	}
+	_, err := cr.db.Collection(cr.colName).UpdateMany(context.TODO(), filter, update)
+	return err
+}
@@ -2,9 +2,16 @@ package deckrepo
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"time"
 	"vietcard-backend/internal/delivery/http/request"
 	"vietcard-backend/internal/domain/entity"
 	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/repository/geoquery"
+	"vietcard-backend/pkg/helpers"
+	"vietcard-backend/pkg/pagination"
+	"vietcard-backend/pkg/textutil"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -26,6 +33,7 @@ func NewDeckRepository(db *mongo.Database) repository.DeckRepository {
 
 func (dr *deckRepository) CreateDeck(deck *entity.Deck) (*entity.Deck, error) {
 	deck.SetDefault()
+	deck.SetSearchText()
 	result, err := dr.db.Collection(dr.colName).InsertOne(context.TODO(), deck)
 	if err != nil {
 		return nil, err
@@ -57,68 +65,37 @@ func (dr *deckRepository) UpdateDeck(deckID *string, req *request.UpdateDeckRequ
 	}
 	filter := bson.D{{Key: "_id", Value: dID}}
 	update := bson.D{{Key: "$set", Value: *req}}
+	if unset := geoquery.UnsetPlace(req.ClearLocation, req.ClearRegion); len(unset) > 0 {
+		update = append(update, bson.E{Key: "$unset", Value: unset})
+	}
 	option := options.FindOneAndUpdate().SetReturnDocument(options.After)
 	var updatedDeck entity.Deck
 	err = dr.db.Collection(dr.colName).FindOneAndUpdate(context.TODO(), filter, update, option).Decode(&updatedDeck)
 	if err != nil {
 		return nil, err
 	}
-	return &updatedDeck, nil
-}
-
-func (dr *deckRepository) GetCardsAllDecksOfUser(userID *string) (*[]entity.DeckWithCards, error) {
-	ctx := context.TODO()
-
-	uID, err := primitive.ObjectIDFromHex(*userID)
-	if err != nil {
-		return nil, err
+	derived := bson.D{}
+	if req.Name != nil || req.Description != nil {
+		updatedDeck.SetSearchText()
+		derived = append(derived, bson.E{Key: "search_text", Value: updatedDeck.SearchText})
 	}
-
-	// Open an aggregation cursor
-	coll := dr.db.Collection(dr.colName)
-	cursor, err := coll.Aggregate(ctx, bson.A{
-		bson.D{{"$match", bson.D{{"user_id", uID}}}},
-		bson.D{
-			{"$lookup",
-				bson.D{
-					{"from", "cards"},
-					{"localField", "_id"},
-					{"foreignField", "deck_id"},
-					{"as", "cards"},
-				},
-			},
-		},
-		bson.D{
-			{"$set",
-				bson.D{
-					{"cards",
-						bson.D{
-							{"$sortArray",
-								bson.D{
-									{"input", "$cards"},
-									{"sortBy", bson.D{{"created_at", 1}}},
-								},
-							},
-						},
-					},
-				},
-			},
-		},
-	})
-	if err != nil {
-		return nil, err
+	// Only content changes count as an update for followers' feeds, not the
+	// study progress that is also saved through here.
+	if req.IsPublic != nil || req.Name != nil || req.Description != nil || req.DescriptionImageURL != nil || req.Tags != nil {
+		updatedDeck.UpdatedAt = time.Now()
+		derived = append(derived, bson.E{Key: "updated_at", Value: updatedDeck.UpdatedAt})
 	}
-	defer cursor.Close(context.TODO())
-	var deckWithCards []entity.DeckWithCards
-	if err = cursor.All(context.TODO(), &deckWithCards); err != nil {
-		return nil, err
+	if len(derived) > 0 {
+		update = bson.D{{Key: "$set", Value: derived}}
+		_, err = dr.db.Collection(dr.colName).UpdateOne(context.TODO(), filter, update)
+		if err != nil {
+			return nil, err
+		}
 	}
-	return &deckWithCards, nil
+	return &updatedDeck, nil
 }
 
-func (dr *deckRepository) GetCardsAllDecks(userID *string) (*[]entity.DeckWithCards, error) {
-	// Requires the MongoDB Go Driver
-	// https://go.mongodb.org/mongo-driver
+func (dr *deckRepository) GetCardsAllDecksOfUser(userID *string) (*[]entity.DeckWithCards, error) {
 	ctx := context.TODO()
 
 	uID, err := primitive.ObjectIDFromHex(*userID)
@@ -129,18 +106,7 @@ func (dr *deckRepository) GetCardsAllDecks(userID *string) (*[]entity.DeckWithCa
 	// Open an aggregation cursor
 	coll := dr.db.Collection(dr.colName)
 	cursor, err := coll.Aggregate(ctx, bson.A{
-		bson.D{
-			{"$match",
-				bson.D{
-					{"$or",
-						bson.A{
-							bson.D{{"user_id", uID}},
-							bson.D{{"is_public", true}},
-						},
-					},
-				},
-			},
-		},
+		bson.D{{"$match", bson.D{{"user_id", uID}}}},
 		bson.D{
 			{"$lookup",
 				bson.D{
@@ -255,3 +221,408 @@ func (dr *deckRepository) GetDeckWithCards(deckID *string) (*entity.DeckWithCard
 	// Handle case where no documents are found
 	return nil, mongo.ErrNoDocuments
 }
+
+func (dr *deckRepository) StreamDecksOfUser(userID *string, fn func(deck *entity.Deck) error) error {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return err
+	}
+	option := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := dr.db.Collection(dr.colName).Find(context.TODO(), bson.D{{Key: "user_id", Value: uID}}, option)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.TODO())
+	for cursor.Next(context.TODO()) {
+		var deck entity.Deck
+		if err = cursor.Decode(&deck); err != nil {
+			return err
+		}
+		if err = fn(&deck); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+var deckSummaryProjection = bson.D{
+	{Key: "created_at", Value: 1},
+	{Key: "updated_at", Value: 1},
+	{Key: "user_id", Value: 1},
+	{Key: "name", Value: 1},
+	{Key: "description", Value: 1},
+	{Key: "description_img_url", Value: 1},
+	{Key: "position", Value: 1},
+	{Key: "location", Value: 1},
+	{Key: "region", Value: 1},
+	{Key: "tags", Value: 1},
+	{Key: "views", Value: 1},
+	{Key: "rating", Value: 1},
+	{Key: "rating_count", Value: 1},
+	{Key: "bayesian_rating", Value: 1},
+	{Key: "copies", Value: 1},
+	{Key: "comment_count", Value: 1},
+	{Key: "total_cards", Value: 1},
+}
+
+var catalogSortFields = map[string]string{
+	"views":  "views",
+	"rating": "bayesian_rating",
+	"newest": "created_at",
+	"copies": "copies",
+}
+
+func (dr *deckRepository) GetPublicDecks(req *request.DeckCatalogRequest) (*[]entity.DeckSummary, string, error) {
+	sortBy := req.Sort
+	if sortBy == "" {
+		sortBy = "newest"
+	}
+	sortField := catalogSortFields[sortBy]
+	limit := pagination.NormalizeLimit(req.Limit)
+
+	filter := bson.D{{Key: "is_public", Value: true}}
+	if req.Tag != "" {
+		filter = append(filter, bson.E{Key: "tags", Value: helpers.NormalizeTag(req.Tag)})
+	}
+	if req.AuthorID != "" {
+		aID, err := primitive.ObjectIDFromHex(req.AuthorID)
+		if err != nil {
+			return nil, "", err
+		}
+		filter = append(filter, bson.E{Key: "user_id", Value: aID})
+	}
+	if req.MinCards != nil || req.MaxCards != nil {
+		cardsRange := bson.D{}
+		if req.MinCards != nil {
+			cardsRange = append(cardsRange, bson.E{Key: "$gte", Value: *req.MinCards})
+		}
+		if req.MaxCards != nil {
+			cardsRange = append(cardsRange, bson.E{Key: "$lte", Value: *req.MaxCards})
+		}
+		filter = append(filter, bson.E{Key: "total_cards", Value: cardsRange})
+	}
+	if req.Cursor != "" {
+		cursor, err := pagination.DecodeCursor(req.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		value, err := catalogCursorValue(sortField, cursor.Value)
+		if err != nil {
+			return nil, "", err
+		}
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: sortField, Value: bson.D{{Key: "$lt", Value: value}}}},
+			bson.D{{Key: sortField, Value: value}, {Key: "_id", Value: bson.D{{Key: "$lt", Value: cursor.ID}}}},
+		}})
+	}
+
+	option := options.Find().
+		SetSort(bson.D{{Key: sortField, Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit + 1)).
+		SetProjection(deckSummaryProjection)
+	cursor, err := dr.db.Collection(dr.colName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, "", err
+	}
+	decks := []entity.DeckSummary{}
+	if err = cursor.All(context.TODO(), &decks); err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(decks) > limit {
+		decks = decks[:limit]
+		last := decks[limit-1]
+		var value interface{}
+		switch sortField {
+		case "views":
+			value = last.Views
+		case "bayesian_rating":
+			value = last.BayesianRating
+		case "copies":
+			value = last.Copies
+		default:
+			value = last.CreatedAt
+		}
+		nextCursor = pagination.EncodeCursor(value, last.ID)
+	}
+	return &decks, nextCursor, nil
+}
+
+func catalogCursorValue(sortField string, raw interface{}) (interface{}, error) {
+	switch sortField {
+	case "created_at", "updated_at":
+		s, ok := raw.(string)
+		if !ok {
+			return nil, errors.New("invalid cursor")
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	default:
+		n, ok := raw.(json.Number)
+		if !ok {
+			return nil, errors.New("invalid cursor")
+		}
+		return n.Float64()
+	}
+}
+
+func (dr *deckRepository) IncrementCopies(deckID *string) error {
+	dID, err := primitive.ObjectIDFromHex(*deckID)
+	if err != nil {
+		return err
+	}
+	filter := bson.D{{Key: "_id", Value: dID}}
+	update := bson.D{{Key: "$inc", Value: bson.D{{Key: "copies", Value: 1}}}}
+	_, err = dr.db.Collection(dr.colName).UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+func (dr *deckRepository) IncrementViews(deckID *string) error {
+	dID, err := primitive.ObjectIDFromHex(*deckID)
+	if err != nil {
+		return err
+	}
+	filter := bson.D{{Key: "_id", Value: dID}}
+	update := bson.D{{Key: "$inc", Value: bson.D{{Key: "views", Value: 1}}}}
+	_, err = dr.db.Collection(dr.colName).UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+func (dr *deckRepository) IncrementCommentCount(deckID *string, delta int) error {
+	dID, err := primitive.ObjectIDFromHex(*deckID)
+	if err != nil {
+		return err
+	}
+	filter := bson.D{{Key: "_id", Value: dID}}
+	update := bson.D{{Key: "$inc", Value: bson.D{{Key: "comment_count", Value: delta}}}}
+	_, err = dr.db.Collection(dr.colName).UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+func (dr *deckRepository) TouchDeck(deckID *string) error {
+	dID, err := primitive.ObjectIDFromHex(*deckID)
+	if err != nil {
+		return err
+	}
+	filter := bson.D{{Key: "_id", Value: dID}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "updated_at", Value: time.Now()}}}}
+	_, err = dr.db.Collection(dr.colName).UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+// FindDeckPlaces returns the geolocated public decks selected by query.
+func (dr *deckRepository) FindDeckPlaces(query *entity.PlaceQuery) (*[]entity.MapPlace, error) {
+	pipeline := geoquery.Pipeline(query, bson.D{{Key: "is_public", Value: true}})
+	pipeline = append(pipeline,
+		bson.D{{Key: "$limit", Value: query.Limit}},
+		bson.D{{Key: "$project", Value: bson.D{
+			{Key: "kind", Value: entity.PLACE_KIND_DECK},
+			{Key: "deck_id", Value: "$_id"},
+			{Key: "deck_name", Value: "$name"},
+			{Key: "title", Value: "$name"},
+			{Key: "image_url", Value: "$description_img_url"},
+			{Key: "location", Value: 1},
+			{Key: "region", Value: 1},
+			{Key: "distance_m", Value: 1},
+		}}},
+	)
+	cursor, err := dr.db.Collection(dr.colName).Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	places := []entity.MapPlace{}
+	if err = cursor.All(context.TODO(), &places); err != nil {
+		return nil, err
+	}
+	return &places, nil
+}
+
+// GetAuthorDeckStats returns how many public decks an author has and how many
+// times they were copied in total.
+func (dr *deckRepository) GetAuthorDeckStats(userID *string) (int, int, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return 0, 0, err
+	}
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.D{{Key: "user_id", Value: uID}, {Key: "is_public", Value: true}}}},
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: nil},
+			{Key: "decks", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "copies", Value: bson.D{{Key: "$sum", Value: "$copies"}}},
+		}}},
+	}
+	cursor, err := dr.db.Collection(dr.colName).Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return 0, 0, err
+	}
+	var stats []struct {
+		Decks  int `bson:"decks"`
+		Copies int `bson:"copies"`
+	}
+	if err = cursor.All(context.TODO(), &stats); err != nil {
+		return 0, 0, err
+	}
+	if len(stats) == 0 {
+		return 0, 0, nil
+	}
+	return stats[0].Decks, stats[0].Copies, nil
+}
+
+// GetFeedDecks returns the public decks of the given authors, most recently
+// published or updated first.
+func (dr *deckRepository) GetFeedDecks(authorIDs []primitive.ObjectID, req *request.GetFeedRequest) (*[]entity.DeckSummary, string, error) {
+	limit := pagination.NormalizeLimit(req.Limit)
+	filter := bson.D{
+		{Key: "is_public", Value: true},
+		{Key: "user_id", Value: bson.D{{Key: "$in", Value: authorIDs}}},
+	}
+	if req.Cursor != "" {
+		cursor, err := pagination.DecodeCursor(req.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		value, err := catalogCursorValue("updated_at", cursor.Value)
+		if err != nil {
+			return nil, "", err
+		}
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: "updated_at", Value: bson.D{{Key: "$lt", Value: value}}}},
+			bson.D{{Key: "updated_at", Value: value}, {Key: "_id", Value: bson.D{{Key: "$lt", Value: cursor.ID}}}},
+		}})
+	}
+
+	option := options.Find().
+		SetSort(bson.D{{Key: "updated_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit + 1)).
+		SetProjection(deckSummaryProjection)
+	cursor, err := dr.db.Collection(dr.colName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, "", err
+	}
+	decks := []entity.DeckSummary{}
+	if err = cursor.All(context.TODO(), &decks); err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(decks) > limit {
+		decks = decks[:limit]
+		last := decks[limit-1]
+		nextCursor = pagination.EncodeCursor(last.UpdatedAt, last.ID)
+	}
+	return &decks, nextCursor, nil
+}
+
+func (dr *deckRepository) IncrementTotalCards(deckID *string, delta int) error {
+	dID, err := primitive.ObjectIDFromHex(*deckID)
+	if err != nil {
+		return err
+	}
+	filter := bson.D{{Key: "_id", Value: dID}}
+	update := bson.D{
+		{Key: "$inc", Value: bson.D{{Key: "total_cards", Value: delta}}},
+		{Key: "$set", Value: bson.D{{Key: "updated_at", Value: time.Now()}}},
+	}
+	_, err = dr.db.Collection(dr.colName).UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+func (dr *deckRepository) SearchDecks(query *string, userID *string, scope string, skip int, limit int) (*[]entity.DeckSearchHit, error) {
+	filter := bson.D{{Key: "$text", Value: bson.D{{Key: "$search", Value: textutil.TextSearch(*query)}}}}
+	if scope == entity.SEARCH_SCOPE_MINE {
+		uID, err := primitive.ObjectIDFromHex(*userID)
+		if err != nil {
+			return nil, err
+		}
+		filter = append(filter, bson.E{Key: "user_id", Value: uID})
+	} else {
+		filter = append(filter, bson.E{Key: "is_public", Value: true})
+	}
+
+	projection := append(bson.D{{Key: "score", Value: bson.D{{Key: "$meta", Value: "textScore"}}}}, deckSummaryProjection...)
+	option := options.Find().
+		SetProjection(projection).
+		SetSort(bson.D{{Key: "score", Value: bson.D{{Key: "$meta", Value: "textScore"}}}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(skip)).
+		SetLimit(int64(limit))
+	cursor, err := dr.db.Collection(dr.colName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, err
+	}
+	hits := []entity.DeckSearchHit{}
+	if err = cursor.All(context.TODO(), &hits); err != nil {
+		return nil, err
+	}
+	return &hits, nil
+}
+
+// ApplyDeckRating adjusts the rating totals of a deck and recomputes its
+// average and Bayesian rating in a single atomic update.
+func (dr *deckRepository) ApplyDeckRating(deckID *string, sumDelta int, countDelta int) (*entity.Deck, error) {
+	dID, err := primitive.ObjectIDFromHex(*deckID)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{{Key: "_id", Value: dID}}
+	update := bson.A{
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "rating_sum", Value: bson.D{{Key: "$add", Value: bson.A{bson.D{{Key: "$ifNull", Value: bson.A{"$rating_sum", 0}}}, sumDelta}}}},
+			{Key: "rating_count", Value: bson.D{{Key: "$add", Value: bson.A{bson.D{{Key: "$ifNull", Value: bson.A{"$rating_count", 0}}}, countDelta}}}},
+		}}},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "rating", Value: bson.D{{Key: "$cond", Value: bson.A{
+				bson.D{{Key: "$gt", Value: bson.A{"$rating_count", 0}}},
+				bson.D{{Key: "$divide", Value: bson.A{"$rating_sum", "$rating_count"}}},
+				0,
+			}}}},
+			{Key: "bayesian_rating", Value: bson.D{{Key: "$divide", Value: bson.A{
+				bson.D{{Key: "$add", Value: bson.A{entity.RATING_PRIOR_MEAN * entity.RATING_PRIOR_WEIGHT, "$rating_sum"}}},
+				bson.D{{Key: "$add", Value: bson.A{entity.RATING_PRIOR_WEIGHT, "$rating_count"}}},
+			}}}},
+		}}},
+	}
+	option := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var updatedDeck entity.Deck
+	err = dr.db.Collection(dr.colName).FindOneAndUpdate(context.TODO(), filter, update, option).Decode(&updatedDeck)
+	if err != nil {
+		return nil, err
+	}
+	return &updatedDeck, nil
+}
+
+// SetDeckHidden hides a deck from everyone but its members, which also
+// unpublishes it. Restoring it doesn't publish it again.
+func (dr *deckRepository) SetDeckHidden(deckID primitive.ObjectID, hidden bool) error {
+	set := bson.D{{Key: "is_hidden", Value: hidden}}
+	if hidden {
+		set = append(set, bson.E{Key: "is_public", Value: false})
+	}
+	filter := bson.D{{Key: "_id", Value: deckID}}
+	update := bson.D{{Key: "$set", Value: set}}
+	_, err := dr.db.Collection(dr.colName).UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+// SetUserDecksHiddenByBan hides the banned user's public decks, or brings
+// back the decks hidden by the ban. Decks that were hidden before the ban
+// stay hidden.
+func (dr *deckRepository) SetUserDecksHiddenByBan(userID primitive.ObjectID, hidden bool) error {
+	var filter, update bson.D
+	if hidden {
+		filter = bson.D{{Key: "user_id", Value: userID}, {Key: "is_public", Value: true}, {Key: "is_hidden", Value: false}}
+		update = bson.D{{Key: "$set", Value: bson.D{
+			{Key: "is_hidden", Value: true},
+			{Key: "is_public", Value: false},
+			{Key: "hidden_by_ban", Value: true},
+		}}}
+	} else {
+		filter = bson.D{{Key: "user_id", Value: userID}, {Key: "hidden_by_ban", Value: true}}
+		update = bson.D{
+			{Key: "$set", Value: bson.D{{Key: "is_hidden", Value: false}, {Key: "is_public", Value: true}}},
+			{Key: "$unset", Value: bson.D{{Key: "hidden_by_ban", Value: ""}}},
+		}
+	}
+	_, err := dr.db.Collection(dr.colName).UpdateMany(context.TODO(), filter, update)
+	return err
+}
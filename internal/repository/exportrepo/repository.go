@@ -0,0 +1,127 @@
+package exportrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type exportRepository struct {
+	db      *mongo.Database
+	colName string
+}
+
+func NewExportRepository(db *mongo.Database) repository.ExportRepository {
+	return &exportRepository{
+		db:      db,
+		colName: "export_jobs",
+	}
+}
+
+func (er *exportRepository) CreateExportJob(job *entity.ExportJob) (*entity.ExportJob, error) {
+	job.SetDefault()
+	result, err := er.db.Collection(er.colName).InsertOne(context.TODO(), job)
+	if err != nil {
+		return nil, err
+	}
+
+	insertedID, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return nil, errors.New("failed to get inserted ID")
+	}
+
+	job.ID = insertedID
+
+	return job, nil
+}
+
+func (er *exportRepository) GetExportJobByID(id *string) (*entity.ExportJob, error) {
+	oID, err := primitive.ObjectIDFromHex(*id)
+	if err != nil {
+		return nil, err
+	}
+	var job entity.ExportJob
+	err = er.db.Collection(er.colName).FindOne(context.TODO(), bson.D{{Key: "_id", Value: oID}}).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (er *exportRepository) GetExportJobByToken(token *string) (*entity.ExportJob, error) {
+	var job entity.ExportJob
+	err := er.db.Collection(er.colName).FindOne(context.TODO(), bson.D{{Key: "token", Value: *token}}).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (er *exportRepository) GetLatestExportJobOfUser(userID *string) (*entity.ExportJob, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	option := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	var job entity.ExportJob
+	err = er.db.Collection(er.colName).FindOne(context.TODO(), bson.D{{Key: "user_id", Value: uID}}, option).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (er *exportRepository) GetExpiredExportJobs() (*[]entity.ExportJob, error) {
+	filter := bson.D{
+		{Key: "status", Value: entity.EXPORT_STATUS_READY},
+		{Key: "expires_at", Value: bson.D{{Key: "$lt", Value: time.Now()}}},
+	}
+	cursor, err := er.db.Collection(er.colName).Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []entity.ExportJob
+	if err = cursor.All(context.TODO(), &jobs); err != nil {
+		return nil, err
+	}
+
+	return &jobs, nil
+}
+
+// UpdateExportJob saves the job only if it is still in fromStatus, so a job
+// that was timed out or expired meanwhile is not brought back.
+func (er *exportRepository) UpdateExportJob(job *entity.ExportJob, fromStatus string) (bool, error) {
+	filter := bson.D{{Key: "_id", Value: job.ID}, {Key: "status", Value: fromStatus}}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "status", Value: job.Status},
+			{Key: "error", Value: job.Error},
+			{Key: "token", Value: job.Token},
+			{Key: "file_path", Value: job.FilePath},
+			{Key: "size", Value: job.Size},
+			{Key: "completed_at", Value: job.CompletedAt},
+			{Key: "expires_at", Value: job.ExpiresAt},
+		}}}
+	result, err := er.db.Collection(er.colName).UpdateOne(context.TODO(), &filter, &update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
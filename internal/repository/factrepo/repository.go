@@ -0,0 +1,227 @@
+package factrepo
+
+import (
+	"context"
+	"time"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/pkg/pagination"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type factRepository struct {
+	db           *mongo.Database
+	colName      string
+	viewsColName string
+}
+
+func NewFactRepository(db *mongo.Database) repository.FactRepository {
+	return &factRepository{
+		db:           db,
+		colName:      "fun_facts",
+		viewsColName: "fact_views",
+	}
+}
+
+// CreateFact returns false if a fact with the same content hash exists.
+func (fr *factRepository) CreateFact(fact *entity.Fact) (bool, error) {
+	fact.CreatedAt = time.Now()
+	fact.UpdatedAt = fact.CreatedAt
+	result, err := fr.db.Collection(fr.colName).InsertOne(context.TODO(), fact)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	fact.ID = result.InsertedID.(primitive.ObjectID)
+	return true, nil
+}
+
+func (fr *factRepository) GetFactByID(factID *string) (*entity.Fact, error) {
+	fID, err := primitive.ObjectIDFromHex(*factID)
+	if err != nil {
+		return nil, err
+	}
+	return fr.findOne(bson.D{{Key: "_id", Value: fID}})
+}
+
+func (fr *factRepository) GetFactByHash(contentHash string) (*entity.Fact, error) {
+	return fr.findOne(bson.D{{Key: "content_hash", Value: contentHash}})
+}
+
+func (fr *factRepository) findOne(filter bson.D, opts ...*options.FindOneOptions) (*entity.Fact, error) {
+	var fact entity.Fact
+	err := fr.db.Collection(fr.colName).FindOne(context.TODO(), filter, opts...).Decode(&fact)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &fact, nil
+}
+
+// UpdateFact saves every field of the fact. It returns false if another fact
+// has the same content hash.
+func (fr *factRepository) UpdateFact(fact *entity.Fact) (bool, error) {
+	fact.UpdatedAt = time.Now()
+	filter := bson.D{{Key: "_id", Value: fact.ID}}
+	set := bson.D{
+		{Key: "updated_at", Value: fact.UpdatedAt},
+		{Key: "content", Value: fact.Content},
+		{Key: "category", Value: fact.Category},
+		{Key: "source", Value: fact.Source},
+		{Key: "source_url", Value: fact.SourceURL},
+		{Key: "locale", Value: fact.Locale},
+		{Key: "content_hash", Value: fact.ContentHash},
+	}
+	update := bson.D{}
+	if fact.DeckID != nil {
+		set = append(set, bson.E{Key: "deck_id", Value: fact.DeckID})
+	} else {
+		update = append(update, bson.E{Key: "$unset", Value: bson.D{{Key: "deck_id", Value: ""}}})
+	}
+	update = append(update, bson.E{Key: "$set", Value: set})
+	_, err := fr.db.Collection(fr.colName).UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteFact returns false if the fact does not exist.
+func (fr *factRepository) DeleteFact(factID *string) (bool, error) {
+	fID, err := primitive.ObjectIDFromHex(*factID)
+	if err != nil {
+		return false, err
+	}
+	result, err := fr.db.Collection(fr.colName).DeleteOne(context.TODO(), bson.D{{Key: "_id", Value: fID}})
+	if err != nil {
+		return false, err
+	}
+	if result.DeletedCount == 0 {
+		return false, nil
+	}
+	_, err = fr.db.Collection(fr.viewsColName).DeleteMany(context.TODO(), bson.D{{Key: "fact_id", Value: fID}})
+	return true, err
+}
+
+// GetFacts returns the facts, newest first, optionally of a locale and a
+// category.
+func (fr *factRepository) GetFacts(req *request.GetFactsRequest) (*[]entity.Fact, string, error) {
+	filter := factFilter(req.Locale, req.Category)
+	if req.Cursor != "" {
+		c, err := pagination.DecodeCursor(req.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$lt", Value: c.ID}}})
+	}
+
+	limit := pagination.NormalizeLimit(req.Limit)
+	option := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetLimit(int64(limit + 1))
+	cur, err := fr.db.Collection(fr.colName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, "", err
+	}
+	facts := []entity.Fact{}
+	if err = cur.All(context.TODO(), &facts); err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(facts) > limit {
+		facts = facts[:limit]
+		nextCursor = pagination.EncodeCursor(nil, facts[limit-1].ID)
+	}
+	return &facts, nextCursor, nil
+}
+
+func (fr *factRepository) CountFacts(locale string, category string) (int, error) {
+	count, err := fr.db.Collection(fr.colName).CountDocuments(context.TODO(), factFilter(locale, category))
+	return int(count), err
+}
+
+// GetNthFact returns the fact at index n in creation order.
+func (fr *factRepository) GetNthFact(locale string, category string, n int) (*entity.Fact, error) {
+	option := options.FindOne().SetSort(bson.D{{Key: "_id", Value: 1}}).SetSkip(int64(n))
+	return fr.findOne(factFilter(locale, category), option)
+}
+
+// GetRandomFact returns a random fact other than the excluded ones, or nil if
+// there is none.
+func (fr *factRepository) GetRandomFact(locale string, category string, excludeIDs []primitive.ObjectID) (*entity.Fact, error) {
+	filter := factFilter(locale, category)
+	if len(excludeIDs) > 0 {
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$nin", Value: excludeIDs}}})
+	}
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: filter}},
+		bson.D{{Key: "$sample", Value: bson.D{{Key: "size", Value: 1}}}},
+	}
+	opts := options.Aggregate().SetMaxTime(2 * time.Second)
+	cursor, err := fr.db.Collection(fr.colName).Aggregate(context.TODO(), pipeline, opts)
+	if err != nil {
+		return nil, err
+	}
+	facts := []entity.Fact{}
+	if err = cursor.All(context.TODO(), &facts); err != nil {
+		return nil, err
+	}
+	if len(facts) == 0 {
+		return nil, nil
+	}
+	return &facts[0], nil
+}
+
+func (fr *factRepository) MarkFactSeen(userID primitive.ObjectID, factID primitive.ObjectID) error {
+	filter := bson.D{{Key: "user_id", Value: userID}, {Key: "fact_id", Value: factID}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "seen_at", Value: time.Now()}}}}
+	_, err := fr.db.Collection(fr.viewsColName).UpdateOne(context.TODO(), filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// GetSeenFactIDs returns the facts the user saw since the time.
+func (fr *factRepository) GetSeenFactIDs(userID *string, since time.Time) ([]primitive.ObjectID, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{{Key: "user_id", Value: uID}, {Key: "seen_at", Value: bson.D{{Key: "$gte", Value: since}}}}
+	option := options.Find().SetProjection(bson.D{{Key: "fact_id", Value: 1}})
+	cursor, err := fr.db.Collection(fr.viewsColName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, err
+	}
+	views := []entity.FactView{}
+	if err = cursor.All(context.TODO(), &views); err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, 0, len(views))
+	for _, view := range views {
+		ids = append(ids, view.FactID)
+	}
+	return ids, nil
+}
+
+func factFilter(locale string, category string) bson.D {
+	filter := bson.D{}
+	if locale != "" {
+		filter = append(filter, bson.E{Key: "locale", Value: locale})
+	}
+	if category != "" {
+		filter = append(filter, bson.E{Key: "category", Value: category})
+	}
+	return filter
+}
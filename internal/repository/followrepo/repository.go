@@ -0,0 +1,96 @@
+package followrepo
+
+import (
+	"context"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type followRepository struct {
+	db      *mongo.Database
+	colName string
+}
+
+func NewFollowRepository(db *mongo.Database) repository.FollowRepository {
+	return &followRepository{
+		db:      db,
+		colName: "follows",
+	}
+}
+
+// CreateFollow returns false if the follower already follows the followee.
+func (fr *followRepository) CreateFollow(follow *entity.Follow) (bool, error) {
+	follow.CreatedAt = time.Now()
+	_, err := fr.db.Collection(fr.colName).InsertOne(context.TODO(), follow)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func followFilter(followerID *string, followeeID *string) (bson.D, error) {
+	frID, err := primitive.ObjectIDFromHex(*followerID)
+	if err != nil {
+		return nil, err
+	}
+	feID, err := primitive.ObjectIDFromHex(*followeeID)
+	if err != nil {
+		return nil, err
+	}
+	return bson.D{{Key: "follower_id", Value: frID}, {Key: "followee_id", Value: feID}}, nil
+}
+
+func (fr *followRepository) DeleteFollow(followerID *string, followeeID *string) (bool, error) {
+	filter, err := followFilter(followerID, followeeID)
+	if err != nil {
+		return false, err
+	}
+	result, err := fr.db.Collection(fr.colName).DeleteOne(context.TODO(), filter)
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+func (fr *followRepository) IsFollowing(followerID *string, followeeID *string) (bool, error) {
+	filter, err := followFilter(followerID, followeeID)
+	if err != nil {
+		return false, err
+	}
+	count, err := fr.db.Collection(fr.colName).CountDocuments(context.TODO(), filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (fr *followRepository) GetFolloweeIDs(followerID *string) ([]primitive.ObjectID, error) {
+	frID, err := primitive.ObjectIDFromHex(*followerID)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{{Key: "follower_id", Value: frID}}
+	option := options.Find().SetProjection(bson.D{{Key: "followee_id", Value: 1}})
+	cursor, err := fr.db.Collection(fr.colName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, err
+	}
+	var follows []entity.Follow
+	if err = cursor.All(context.TODO(), &follows); err != nil {
+		return nil, err
+	}
+	followeeIDs := make([]primitive.ObjectID, 0, len(follows))
+	for _, follow := range follows {
+		followeeIDs = append(followeeIDs, follow.FolloweeID)
+	}
+	return followeeIDs, nil
+}
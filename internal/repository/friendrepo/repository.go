@@ -0,0 +1,148 @@
+package friendrepo
+
+import (
+	"context"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type friendRepository struct {
+	db      *mongo.Database
+	colName string
+}
+
+func NewFriendRepository(db *mongo.Database) repository.FriendRepository {
+	return &friendRepository{
+		db:      db,
+		colName: "friendships",
+	}
+}
+
+// CreateFriendship returns false if the two users already have a friendship
+// or a pending request in either direction.
+func (fr *friendRepository) CreateFriendship(friendship *entity.Friendship) (bool, error) {
+	friendship.CreatedAt = time.Now()
+	friendship.UpdatedAt = friendship.CreatedAt
+	friendship.PairKey = entity.FriendPairKey(friendship.RequesterID, friendship.AddresseeID)
+	result, err := fr.db.Collection(fr.colName).InsertOne(context.TODO(), friendship)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	friendship.ID = result.InsertedID.(primitive.ObjectID)
+	return true, nil
+}
+
+func (fr *friendRepository) GetFriendship(userID *string, otherID *string) (*entity.Friendship, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	oID, err := primitive.ObjectIDFromHex(*otherID)
+	if err != nil {
+		return nil, err
+	}
+	var friendship entity.Friendship
+	filter := bson.D{{Key: "pair_key", Value: entity.FriendPairKey(uID, oID)}}
+	err = fr.db.Collection(fr.colName).FindOne(context.TODO(), filter).Decode(&friendship)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &friendship, nil
+}
+
+// AcceptFriendship returns nil if there is no pending request from the
+// requester to the addressee.
+func (fr *friendRepository) AcceptFriendship(requesterID *string, addresseeID *string) (*entity.Friendship, error) {
+	rID, err := primitive.ObjectIDFromHex(*requesterID)
+	if err != nil {
+		return nil, err
+	}
+	aID, err := primitive.ObjectIDFromHex(*addresseeID)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{
+		{Key: "requester_id", Value: rID},
+		{Key: "addressee_id", Value: aID},
+		{Key: "status", Value: entity.FRIENDSHIP_STATUS_PENDING},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "status", Value: entity.FRIENDSHIP_STATUS_ACCEPTED},
+		{Key: "updated_at", Value: time.Now()},
+	}}}
+	option := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var friendship entity.Friendship
+	err = fr.db.Collection(fr.colName).FindOneAndUpdate(context.TODO(), filter, update, option).Decode(&friendship)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &friendship, nil
+}
+
+func (fr *friendRepository) DeleteFriendship(friendshipID primitive.ObjectID) error {
+	_, err := fr.db.Collection(fr.colName).DeleteOne(context.TODO(), bson.D{{Key: "_id", Value: friendshipID}})
+	return err
+}
+
+func (fr *friendRepository) GetFriendshipsOfUser(userID *string) (*[]entity.Friendship, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "requester_id", Value: uID}},
+		bson.D{{Key: "addressee_id", Value: uID}},
+	}}}
+	option := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}})
+	cursor, err := fr.db.Collection(fr.colName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, err
+	}
+	friendships := []entity.Friendship{}
+	if err = cursor.All(context.TODO(), &friendships); err != nil {
+		return nil, err
+	}
+	return &friendships, nil
+}
+
+func (fr *friendRepository) GetFriendIDs(userID *string) ([]primitive.ObjectID, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{
+		{Key: "status", Value: entity.FRIENDSHIP_STATUS_ACCEPTED},
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "requester_id", Value: uID}},
+			bson.D{{Key: "addressee_id", Value: uID}},
+		}},
+	}
+	cursor, err := fr.db.Collection(fr.colName).Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+	var friendships []entity.Friendship
+	if err = cursor.All(context.TODO(), &friendships); err != nil {
+		return nil, err
+	}
+	friendIDs := make([]primitive.ObjectID, 0, len(friendships))
+	for i := range friendships {
+		friendIDs = append(friendIDs, friendships[i].OtherUser(uID))
+	}
+	return friendIDs, nil
+}
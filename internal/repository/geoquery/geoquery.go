@@ -0,0 +1,62 @@
+// Package geoquery builds the aggregation stages and updates shared by the
+// deck and card map queries.
+package geoquery
+
+import (
+	"vietcard-backend/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Pipeline returns the stages that select the places of query among the
+// documents matching filter. Near queries come back sorted by distance.
+func Pipeline(query *entity.PlaceQuery, filter bson.D) mongo.Pipeline {
+	switch {
+	case query.Near != nil:
+		return mongo.Pipeline{
+			bson.D{{Key: "$geoNear", Value: bson.D{
+				{Key: "near", Value: query.Near},
+				{Key: "key", Value: "location"},
+				{Key: "distanceField", Value: "distance_m"},
+				{Key: "maxDistance", Value: query.MaxDistanceMeters},
+				{Key: "spherical", Value: true},
+				{Key: "query", Value: filter},
+			}}},
+		}
+	case query.Box != nil:
+		minLng, minLat, maxLng, maxLat := query.Box[0], query.Box[1], query.Box[2], query.Box[3]
+		polygon := bson.D{
+			{Key: "type", Value: "Polygon"},
+			{Key: "coordinates", Value: bson.A{bson.A{
+				bson.A{minLng, minLat},
+				bson.A{maxLng, minLat},
+				bson.A{maxLng, maxLat},
+				bson.A{minLng, maxLat},
+				bson.A{minLng, minLat},
+			}}},
+		}
+		filter = append(filter, bson.E{Key: "location", Value: bson.D{
+			{Key: "$geoWithin", Value: bson.D{{Key: "$geometry", Value: polygon}}},
+		}})
+	default:
+		filter = append(filter,
+			bson.E{Key: "region.province_key", Value: query.ProvinceKey},
+			bson.E{Key: "location", Value: bson.D{{Key: "$exists", Value: true}}},
+		)
+	}
+	return mongo.Pipeline{bson.D{{Key: "$match", Value: filter}}}
+}
+
+// UnsetPlace returns the fields to $unset to clear the location and the
+// region of a deck or a card.
+func UnsetPlace(clearLocation bool, clearRegion bool) bson.D {
+	unset := bson.D{}
+	if clearLocation {
+		unset = append(unset, bson.E{Key: "location", Value: ""})
+	}
+	if clearRegion {
+		unset = append(unset, bson.E{Key: "region", Value: ""})
+	}
+	return unset
+}
@@ -0,0 +1,73 @@
+package goalrepo
+
+import (
+	"context"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type goalRepository struct {
+	db      *mongo.Database
+	colName string
+}
+
+func NewGoalRepository(db *mongo.Database) repository.GoalRepository {
+	return &goalRepository{
+		db:      db,
+		colName: "daily_progress",
+	}
+}
+
+// AddDailyProgress adds to the user's progress of the day, creating it if
+// needed, and returns the updated progress.
+func (gr *goalRepository) AddDailyProgress(userID primitive.ObjectID, day time.Time, xp int, reviews int, goal entity.DailyGoal) (*entity.DailyProgress, error) {
+	filter := bson.D{{Key: "user_id", Value: userID}, {Key: "day", Value: day}}
+	update := bson.D{
+		{Key: "$inc", Value: bson.D{{Key: "xp", Value: xp}, {Key: "reviews", Value: reviews}}},
+		{Key: "$set", Value: bson.D{{Key: "goal", Value: goal}}},
+		{Key: "$setOnInsert", Value: bson.D{{Key: "completed", Value: false}, {Key: "completed_at", Value: nil}}},
+	}
+	option := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var progress entity.DailyProgress
+	err := gr.db.Collection(gr.colName).FindOneAndUpdate(context.TODO(), filter, update, option).Decode(&progress)
+	if err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+// CompleteDailyProgress returns false if the progress was already completed.
+func (gr *goalRepository) CompleteDailyProgress(progressID primitive.ObjectID, completedAt time.Time) (bool, error) {
+	filter := bson.D{{Key: "_id", Value: progressID}, {Key: "completed", Value: false}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "completed", Value: true}, {Key: "completed_at", Value: completedAt}}}}
+	result, err := gr.db.Collection(gr.colName).UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// GetDailyProgressSince returns the user's progress since from, oldest first.
+func (gr *goalRepository) GetDailyProgressSince(userID *string, from time.Time) (*[]entity.DailyProgress, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{{Key: "user_id", Value: uID}, {Key: "day", Value: bson.D{{Key: "$gte", Value: from}}}}
+	option := options.Find().SetSort(bson.D{{Key: "day", Value: 1}})
+	cursor, err := gr.db.Collection(gr.colName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, err
+	}
+	progress := []entity.DailyProgress{}
+	if err = cursor.All(context.TODO(), &progress); err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
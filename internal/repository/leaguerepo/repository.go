@@ -0,0 +1,244 @@
+package leaguerepo
+
+import (
+	"context"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type leagueRepository struct {
+	db            *mongo.Database
+	colName       string
+	weeklyColName string
+}
+
+func NewLeagueRepository(db *mongo.Database) repository.LeagueRepository {
+	return &leagueRepository{
+		db:            db,
+		colName:       "leagues",
+		weeklyColName: "weekly_xp",
+	}
+}
+
+// JoinLeague takes a seat in an open league of the tier for the week,
+// creating a new league when all existing ones are full.
+func (lr *leagueRepository) JoinLeague(week string, tier int) (*entity.League, error) {
+	filter := bson.D{
+		{Key: "week", Value: week},
+		{Key: "tier", Value: tier},
+		{Key: "closed_at", Value: nil},
+		{Key: "size", Value: bson.D{{Key: "$lt", Value: entity.LEAGUE_GROUP_SIZE}}},
+	}
+	update := bson.D{{Key: "$inc", Value: bson.D{{Key: "size", Value: 1}}}}
+	option := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+	var league entity.League
+	err := lr.db.Collection(lr.colName).FindOneAndUpdate(context.TODO(), filter, update, option).Decode(&league)
+	if err == nil {
+		return &league, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
+	league = entity.League{
+		CreatedAt: time.Now(),
+		Week:      week,
+		Tier:      tier,
+		Size:      1,
+	}
+	result, err := lr.db.Collection(lr.colName).InsertOne(context.TODO(), league)
+	if err != nil {
+		return nil, err
+	}
+	league.ID = result.InsertedID.(primitive.ObjectID)
+	return &league, nil
+}
+
+func (lr *leagueRepository) GetLeagueByID(id *string) (*entity.League, error) {
+	oID, err := primitive.ObjectIDFromHex(*id)
+	if err != nil {
+		return nil, err
+	}
+	var league entity.League
+	err = lr.db.Collection(lr.colName).FindOne(context.TODO(), bson.D{{Key: "_id", Value: oID}}).Decode(&league)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &league, nil
+}
+
+func (lr *leagueRepository) GetOpenLeaguesBefore(week string) (*[]entity.League, error) {
+	filter := bson.D{
+		{Key: "week", Value: bson.D{{Key: "$lt", Value: week}}},
+		{Key: "closed_at", Value: nil},
+	}
+	option := options.Find().SetSort(bson.D{{Key: "week", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := lr.db.Collection(lr.colName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, err
+	}
+	leagues := []entity.League{}
+	if err = cursor.All(context.TODO(), &leagues); err != nil {
+		return nil, err
+	}
+	return &leagues, nil
+}
+
+func (lr *leagueRepository) CloseLeague(leagueID primitive.ObjectID) error {
+	filter := bson.D{{Key: "_id", Value: leagueID}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "closed_at", Value: time.Now()}}}}
+	_, err := lr.db.Collection(lr.colName).UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+// CreateWeeklyXP returns false if the user already has a ledger for the week.
+func (lr *leagueRepository) CreateWeeklyXP(weeklyXP *entity.WeeklyXP) (bool, error) {
+	weeklyXP.UpdatedAt = time.Now()
+	_, err := lr.db.Collection(lr.weeklyColName).InsertOne(context.TODO(), weeklyXP)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (lr *leagueRepository) GetWeeklyXP(userID *string, week string) (*entity.WeeklyXP, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	var weeklyXP entity.WeeklyXP
+	filter := bson.D{{Key: "user_id", Value: uID}, {Key: "week", Value: week}}
+	err = lr.db.Collection(lr.weeklyColName).FindOne(context.TODO(), filter).Decode(&weeklyXP)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &weeklyXP, nil
+}
+
+func (lr *leagueRepository) GetWeeklyXPOfUsers(userIDs []primitive.ObjectID, week string) (*[]entity.WeeklyXP, error) {
+	filter := bson.D{
+		{Key: "user_id", Value: bson.D{{Key: "$in", Value: userIDs}}},
+		{Key: "week", Value: week},
+	}
+	cursor, err := lr.db.Collection(lr.weeklyColName).Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+	weeklyXPs := []entity.WeeklyXP{}
+	if err = cursor.All(context.TODO(), &weeklyXPs); err != nil {
+		return nil, err
+	}
+	return &weeklyXPs, nil
+}
+
+func (lr *leagueRepository) IncrementWeeklyXP(userID *string, week string, xp int) error {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return err
+	}
+	filter := bson.D{{Key: "user_id", Value: uID}, {Key: "week", Value: week}}
+	update := bson.D{
+		{Key: "$inc", Value: bson.D{{Key: "xp", Value: xp}}},
+		{Key: "$set", Value: bson.D{{Key: "updated_at", Value: time.Now()}}},
+	}
+	_, err = lr.db.Collection(lr.weeklyColName).UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+// GetLeagueMembers returns the members of a league in final standing order.
+// Ties on XP go to whoever reached it first.
+func (lr *leagueRepository) GetLeagueMembers(leagueID primitive.ObjectID) (*[]entity.WeeklyXP, error) {
+	filter := bson.D{{Key: "league_id", Value: leagueID}}
+	option := options.Find().SetSort(bson.D{{Key: "xp", Value: -1}, {Key: "updated_at", Value: 1}, {Key: "user_id", Value: 1}})
+	cursor, err := lr.db.Collection(lr.weeklyColName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, err
+	}
+	members := []entity.WeeklyXP{}
+	if err = cursor.All(context.TODO(), &members); err != nil {
+		return nil, err
+	}
+	return &members, nil
+}
+
+func (lr *leagueRepository) SetWeeklyResult(weeklyXPID primitive.ObjectID, rank int, result string) error {
+	filter := bson.D{{Key: "_id", Value: weeklyXPID}}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "rank", Value: rank},
+		{Key: "result", Value: result},
+	}}}
+	_, err := lr.db.Collection(lr.weeklyColName).UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+func weeklyRankingFilter(week string, leagueID *primitive.ObjectID, userIDs []primitive.ObjectID) bson.D {
+	filter := bson.D{{Key: "week", Value: week}}
+	if leagueID != nil {
+		filter = append(filter, bson.E{Key: "league_id", Value: *leagueID})
+	}
+	if userIDs != nil {
+		filter = append(filter, bson.E{Key: "user_id", Value: bson.D{{Key: "$in", Value: userIDs}}})
+	}
+	return filter
+}
+
+// GetWeeklyRanking returns the top weekly XP of the week, optionally limited
+// to one league or to the given users. Ranks are left for the caller.
+func (lr *leagueRepository) GetWeeklyRanking(week string, leagueID *primitive.ObjectID, userIDs []primitive.ObjectID, limit int) (*[]entity.LeaderboardEntry, error) {
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: weeklyRankingFilter(week, leagueID, userIDs)}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "xp", Value: -1}, {Key: "updated_at", Value: 1}, {Key: "user_id", Value: 1}}}},
+		bson.D{{Key: "$limit", Value: limit}},
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: "users"},
+			{Key: "localField", Value: "user_id"},
+			{Key: "foreignField", Value: "_id"},
+			{Key: "as", Value: "user"},
+		}}},
+		bson.D{{Key: "$unwind", Value: "$user"}},
+		bson.D{{Key: "$project", Value: bson.D{
+			{Key: "_id", Value: 0},
+			{Key: "user_id", Value: 1},
+			{Key: "xp", Value: 1},
+			{Key: "name", Value: "$user.name"},
+			{Key: "avatar_url", Value: "$user.avatar_url"},
+			{Key: "level", Value: "$user.level"},
+		}}},
+	}
+	cursor, err := lr.db.Collection(lr.weeklyColName).Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	entries := []entity.LeaderboardEntry{}
+	if err = cursor.All(context.TODO(), &entries); err != nil {
+		return nil, err
+	}
+	return &entries, nil
+}
+
+func (lr *leagueRepository) CountWeeklyXPAbove(week string, leagueID *primitive.ObjectID, userIDs []primitive.ObjectID, xp int) (int, error) {
+	filter := weeklyRankingFilter(week, leagueID, userIDs)
+	filter = append(filter, bson.E{Key: "xp", Value: bson.D{{Key: "$gt", Value: xp}}})
+	count, err := lr.db.Collection(lr.weeklyColName).CountDocuments(context.TODO(), filter)
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
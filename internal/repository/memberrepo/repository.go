@@ -0,0 +1,241 @@
+package memberrepo
+
+import (
+	"context"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type memberRepository struct {
+	db                  *mongo.Database
+	colName             string
+	cardProgressColName string
+	deckProgressColName string
+}
+
+func NewMemberRepository(db *mongo.Database) repository.MemberRepository {
+	return &memberRepository{
+		db:                  db,
+		colName:             "deck_members",
+		cardProgressColName: "card_progress",
+		deckProgressColName: "deck_progress",
+	}
+}
+
+func deckUserFilter(deckID *string, userID *string) (bson.D, error) {
+	dID, err := primitive.ObjectIDFromHex(*deckID)
+	if err != nil {
+		return nil, err
+	}
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	return bson.D{{Key: "deck_id", Value: dID}, {Key: "user_id", Value: uID}}, nil
+}
+
+// UpsertMember adds the user to the deck, or changes their role if they are
+// already a member.
+func (mr *memberRepository) UpsertMember(member *entity.DeckMember) (*entity.DeckMember, error) {
+	filter := bson.D{{Key: "deck_id", Value: member.DeckID}, {Key: "user_id", Value: member.UserID}}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "role", Value: member.Role},
+			{Key: "invited_by", Value: member.InvitedBy},
+		}},
+		{Key: "$setOnInsert", Value: bson.D{{Key: "created_at", Value: time.Now()}}},
+	}
+	option := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var upserted entity.DeckMember
+	err := mr.db.Collection(mr.colName).FindOneAndUpdate(context.TODO(), filter, update, option).Decode(&upserted)
+	if err != nil {
+		return nil, err
+	}
+	return &upserted, nil
+}
+
+func (mr *memberRepository) GetMember(deckID *string, userID *string) (*entity.DeckMember, error) {
+	filter, err := deckUserFilter(deckID, userID)
+	if err != nil {
+		return nil, err
+	}
+	var member entity.DeckMember
+	err = mr.db.Collection(mr.colName).FindOne(context.TODO(), filter).Decode(&member)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (mr *memberRepository) GetMembersOfDeck(deckID *string) (*[]entity.DeckMemberInfo, error) {
+	dID, err := primitive.ObjectIDFromHex(*deckID)
+	if err != nil {
+		return nil, err
+	}
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.D{{Key: "deck_id", Value: dID}}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: 1}}}},
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: "users"},
+			{Key: "localField", Value: "user_id"},
+			{Key: "foreignField", Value: "_id"},
+			{Key: "as", Value: "user"},
+		}}},
+		bson.D{{Key: "$unwind", Value: "$user"}},
+		bson.D{{Key: "$project", Value: bson.D{
+			{Key: "_id", Value: 0},
+			{Key: "user_id", Value: 1},
+			{Key: "role", Value: 1},
+			{Key: "name", Value: "$user.name"},
+			{Key: "email", Value: "$user.email"},
+			{Key: "avatar_url", Value: "$user.avatar_url"},
+		}}},
+	}
+	cursor, err := mr.db.Collection(mr.colName).Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	members := []entity.DeckMemberInfo{}
+	if err = cursor.All(context.TODO(), &members); err != nil {
+		return nil, err
+	}
+	return &members, nil
+}
+
+func (mr *memberRepository) GetDeckIDsOfMember(userID *string) ([]primitive.ObjectID, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := mr.db.Collection(mr.colName).Find(context.TODO(), bson.D{{Key: "user_id", Value: uID}})
+	if err != nil {
+		return nil, err
+	}
+	var members []entity.DeckMember
+	if err = cursor.All(context.TODO(), &members); err != nil {
+		return nil, err
+	}
+	deckIDs := make([]primitive.ObjectID, 0, len(members))
+	for _, member := range members {
+		deckIDs = append(deckIDs, member.DeckID)
+	}
+	return deckIDs, nil
+}
+
+// RemoveMember removes the user from the deck along with their progress.
+func (mr *memberRepository) RemoveMember(deckID *string, userID *string) (bool, error) {
+	filter, err := deckUserFilter(deckID, userID)
+	if err != nil {
+		return false, err
+	}
+	result, err := mr.db.Collection(mr.colName).DeleteOne(context.TODO(), filter)
+	if err != nil {
+		return false, err
+	}
+	for _, colName := range []string{mr.cardProgressColName, mr.deckProgressColName} {
+		if _, err = mr.db.Collection(colName).DeleteMany(context.TODO(), filter); err != nil {
+			return false, err
+		}
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// DeleteDeckMembership removes every member of a deck and their progress.
+func (mr *memberRepository) DeleteDeckMembership(deckID *string) error {
+	dID, err := primitive.ObjectIDFromHex(*deckID)
+	if err != nil {
+		return err
+	}
+	filter := bson.D{{Key: "deck_id", Value: dID}}
+	for _, colName := range []string{mr.colName, mr.cardProgressColName, mr.deckProgressColName} {
+		if _, err = mr.db.Collection(colName).DeleteMany(context.TODO(), filter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (mr *memberRepository) GetCardProgress(userID *string, deckID *string) (*[]entity.CardProgress, error) {
+	filter, err := deckUserFilter(deckID, userID)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := mr.db.Collection(mr.cardProgressColName).Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+	progress := []entity.CardProgress{}
+	if err = cursor.All(context.TODO(), &progress); err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+func (mr *memberRepository) UpsertCardProgress(progress *entity.CardProgress) error {
+	filter := bson.D{{Key: "user_id", Value: progress.UserID}, {Key: "card_id", Value: progress.CardID}}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "deck_id", Value: progress.DeckID},
+		{Key: "last_review", Value: progress.LastReview},
+		{Key: "next_review", Value: progress.NextReview},
+		{Key: "num_reviews", Value: progress.NumReviews},
+		{Key: "sm2_n", Value: progress.Sm2N},
+		{Key: "sm2_ef", Value: progress.Sm2EF},
+		{Key: "sm2_i", Value: progress.Sm2I},
+	}}}
+	_, err := mr.db.Collection(mr.cardProgressColName).UpdateOne(context.TODO(), filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// DeleteCardProgress deletes every member's progress on the card.
+func (mr *memberRepository) DeleteCardProgress(cardID primitive.ObjectID) error {
+	_, err := mr.db.Collection(mr.cardProgressColName).DeleteMany(context.TODO(), bson.D{{Key: "card_id", Value: cardID}})
+	return err
+}
+
+// CountDueCardProgress returns how many cards of decks shared with the user
+// are due for their review by before.
+func (mr *memberRepository) CountDueCardProgress(userID primitive.ObjectID, before time.Time) (int, error) {
+	filter := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "next_review", Value: bson.D{{Key: "$lte", Value: before}}},
+	}
+	count, err := mr.db.Collection(mr.cardProgressColName).CountDocuments(context.TODO(), filter)
+	return int(count), err
+}
+
+func (mr *memberRepository) GetDeckProgress(userID *string, deckID *string) (*entity.DeckProgress, error) {
+	filter, err := deckUserFilter(deckID, userID)
+	if err != nil {
+		return nil, err
+	}
+	var progress entity.DeckProgress
+	err = mr.db.Collection(mr.deckProgressColName).FindOne(context.TODO(), filter).Decode(&progress)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &progress, nil
+}
+
+func (mr *memberRepository) UpsertDeckProgress(progress *entity.DeckProgress) error {
+	filter := bson.D{{Key: "deck_id", Value: progress.DeckID}, {Key: "user_id", Value: progress.UserID}}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "total_learned_cards", Value: progress.TotalLearnedCards},
+		{Key: "last_review", Value: progress.LastReview},
+		{Key: "cur_new_cards", Value: progress.CurNewCards},
+		{Key: "cur_review_cards", Value: progress.CurReviewCards},
+	}}}
+	_, err := mr.db.Collection(mr.deckProgressColName).UpdateOne(context.TODO(), filter, update, options.Update().SetUpsert(true))
+	return err
+}
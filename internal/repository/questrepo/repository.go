@@ -0,0 +1,124 @@
+package questrepo
+
+import (
+	"context"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type questRepository struct {
+	db      *mongo.Database
+	colName string
+}
+
+func NewQuestRepository(db *mongo.Database) repository.QuestRepository {
+	return &questRepository{
+		db:      db,
+		colName: "user_quests",
+	}
+}
+
+// EnsureQuests inserts the quests that weren't generated yet and leaves the
+// others, and their progress, untouched.
+func (qr *questRepository) EnsureQuests(quests []entity.UserQuest) error {
+	if len(quests) == 0 {
+		return nil
+	}
+	models := make([]mongo.WriteModel, 0, len(quests))
+	for _, quest := range quests {
+		filter := bson.D{
+			{Key: "user_id", Value: quest.UserID},
+			{Key: "period_key", Value: quest.PeriodKey},
+			{Key: "template_key", Value: quest.TemplateKey},
+		}
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(filter).
+			SetUpdate(bson.D{{Key: "$setOnInsert", Value: quest}}).
+			SetUpsert(true))
+	}
+	_, err := qr.db.Collection(qr.colName).BulkWrite(context.TODO(), models, options.BulkWrite().SetOrdered(false))
+	if err != nil && mongo.IsDuplicateKeyError(err) {
+		// Another request generated them at the same time.
+		return nil
+	}
+	return err
+}
+
+func (qr *questRepository) GetQuestByID(userID primitive.ObjectID, questID primitive.ObjectID) (*entity.UserQuest, error) {
+	filter := bson.D{{Key: "_id", Value: questID}, {Key: "user_id", Value: userID}}
+	var quest entity.UserQuest
+	err := qr.db.Collection(qr.colName).FindOne(context.TODO(), filter).Decode(&quest)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &quest, nil
+}
+
+func (qr *questRepository) GetQuests(userID primitive.ObjectID, periodKeys []string) (*[]entity.UserQuest, error) {
+	filter := bson.D{{Key: "user_id", Value: userID}, {Key: "period_key", Value: bson.D{{Key: "$in", Value: periodKeys}}}}
+	option := options.Find().SetSort(bson.D{{Key: "period", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := qr.db.Collection(qr.colName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, err
+	}
+	quests := []entity.UserQuest{}
+	if err = cursor.All(context.TODO(), &quests); err != nil {
+		return nil, err
+	}
+	return &quests, nil
+}
+
+func (qr *questRepository) AddQuestProgress(questID primitive.ObjectID, amount int) (*entity.UserQuest, error) {
+	filter := bson.D{{Key: "_id", Value: questID}}
+	update := bson.D{{Key: "$inc", Value: bson.D{{Key: "progress", Value: amount}}}}
+	option := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var quest entity.UserQuest
+	err := qr.db.Collection(qr.colName).FindOneAndUpdate(context.TODO(), filter, update, option).Decode(&quest)
+	if err != nil {
+		return nil, err
+	}
+	return &quest, nil
+}
+
+// CompleteQuest returns false if the quest was already completed.
+func (qr *questRepository) CompleteQuest(questID primitive.ObjectID, completedAt time.Time) (bool, error) {
+	filter := bson.D{{Key: "_id", Value: questID}, {Key: "completed_at", Value: nil}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "completed_at", Value: completedAt}}}}
+	result, err := qr.db.Collection(qr.colName).UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// ClaimQuest marks a completed quest of the user as claimed. It returns nil
+// if there is no such quest or it was already claimed, so the reward is only
+// given once.
+func (qr *questRepository) ClaimQuest(userID primitive.ObjectID, questID primitive.ObjectID, claimedAt time.Time) (*entity.UserQuest, error) {
+	filter := bson.D{
+		{Key: "_id", Value: questID},
+		{Key: "user_id", Value: userID},
+		{Key: "completed_at", Value: bson.D{{Key: "$ne", Value: nil}}},
+		{Key: "claimed_at", Value: nil},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "claimed_at", Value: claimedAt}}}}
+	option := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var quest entity.UserQuest
+	err := qr.db.Collection(qr.colName).FindOneAndUpdate(context.TODO(), filter, update, option).Decode(&quest)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &quest, nil
+}
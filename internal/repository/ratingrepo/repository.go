@@ -0,0 +1,78 @@
+package ratingrepo
+
+import (
+	"context"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ratingRepository struct {
+	db      *mongo.Database
+	colName string
+}
+
+func NewRatingRepository(db *mongo.Database) repository.RatingRepository {
+	return &ratingRepository{
+		db:      db,
+		colName: "deck_ratings",
+	}
+}
+
+// UpsertDeckRating stores the user's rating of a deck and returns the rating
+// it replaced, or nil if this is the user's first rating of the deck.
+func (rr *ratingRepository) UpsertDeckRating(rating *entity.DeckRating) (*entity.DeckRating, error) {
+	now := time.Now()
+	filter := bson.D{
+		{Key: "deck_id", Value: rating.DeckID},
+		{Key: "user_id", Value: rating.UserID},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "rating", Value: rating.Rating},
+			{Key: "updated_at", Value: now},
+		}},
+		{Key: "$setOnInsert", Value: bson.D{
+			{Key: "created_at", Value: now},
+		}},
+	}
+	option := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+	var previous entity.DeckRating
+	err := rr.db.Collection(rr.colName).FindOneAndUpdate(context.TODO(), filter, update, option).Decode(&previous)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &previous, nil
+}
+
+func (rr *ratingRepository) GetDeckRating(deckID *string, userID *string) (*entity.DeckRating, error) {
+	dID, err := primitive.ObjectIDFromHex(*deckID)
+	if err != nil {
+		return nil, err
+	}
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{
+		{Key: "deck_id", Value: dID},
+		{Key: "user_id", Value: uID},
+	}
+	var rating entity.DeckRating
+	err = rr.db.Collection(rr.colName).FindOne(context.TODO(), filter).Decode(&rating)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rating, nil
+}
@@ -0,0 +1,156 @@
+package reportrepo
+
+import (
+	"context"
+	"time"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/pkg/pagination"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type reportRepository struct {
+	db            *mongo.Database
+	colName       string
+	actionColName string
+}
+
+func NewReportRepository(db *mongo.Database) repository.ReportRepository {
+	return &reportRepository{
+		db:            db,
+		colName:       "reports",
+		actionColName: "moderation_actions",
+	}
+}
+
+// CreateReport returns false if the reporter already reported the target.
+func (rr *reportRepository) CreateReport(report *entity.Report) (bool, error) {
+	report.CreatedAt = time.Now()
+	report.Status = entity.REPORT_STATUS_OPEN
+	result, err := rr.db.Collection(rr.colName).InsertOne(context.TODO(), report)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	report.ID = result.InsertedID.(primitive.ObjectID)
+	return true, nil
+}
+
+func (rr *reportRepository) CountOpenReports(targetType string, targetID primitive.ObjectID) (int, error) {
+	filter := bson.D{
+		{Key: "target_type", Value: targetType},
+		{Key: "target_id", Value: targetID},
+		{Key: "status", Value: entity.REPORT_STATUS_OPEN},
+	}
+	count, err := rr.db.Collection(rr.colName).CountDocuments(context.TODO(), filter)
+	return int(count), err
+}
+
+func (rr *reportRepository) CloseReports(targetType string, targetID primitive.ObjectID, status string) error {
+	filter := bson.D{
+		{Key: "target_type", Value: targetType},
+		{Key: "target_id", Value: targetID},
+		{Key: "status", Value: entity.REPORT_STATUS_OPEN},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "status", Value: status}}}}
+	_, err := rr.db.Collection(rr.colName).UpdateMany(context.TODO(), filter, update)
+	return err
+}
+
+// GetModerationQueue returns the targets with open reports, most reported
+// first.
+func (rr *reportRepository) GetModerationQueue(req *request.GetModerationQueueRequest) (*[]entity.ModerationQueueItem, error) {
+	match := bson.D{{Key: "status", Value: entity.REPORT_STATUS_OPEN}}
+	if req.TargetType != "" {
+		match = append(match, bson.E{Key: "target_type", Value: req.TargetType})
+	}
+	limit := pagination.NormalizeLimit(req.Limit)
+	skip := 0
+	if req.Page > 1 {
+		skip = (req.Page - 1) * limit
+	}
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: match}},
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "target_type", Value: "$target_type"}, {Key: "target_id", Value: "$target_id"}}},
+			{Key: "target_owner_id", Value: bson.D{{Key: "$first", Value: "$target_owner_id"}}},
+			{Key: "report_count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "reasons", Value: bson.D{{Key: "$addToSet", Value: "$reason"}}},
+			{Key: "first_reported_at", Value: bson.D{{Key: "$min", Value: "$created_at"}}},
+			{Key: "last_reported_at", Value: bson.D{{Key: "$max", Value: "$created_at"}}},
+		}}},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "target_type", Value: "$_id.target_type"},
+			{Key: "target_id", Value: "$_id.target_id"},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "report_count", Value: -1}, {Key: "first_reported_at", Value: 1}}}},
+		bson.D{{Key: "$skip", Value: skip}},
+		bson.D{{Key: "$limit", Value: limit}},
+	}
+	cursor, err := rr.db.Collection(rr.colName).Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	items := []entity.ModerationQueueItem{}
+	if err = cursor.All(context.TODO(), &items); err != nil {
+		return nil, err
+	}
+	return &items, nil
+}
+
+func (rr *reportRepository) CreateModerationAction(action *entity.ModerationAction) (*entity.ModerationAction, error) {
+	action.CreatedAt = time.Now()
+	result, err := rr.db.Collection(rr.actionColName).InsertOne(context.TODO(), action)
+	if err != nil {
+		return nil, err
+	}
+	action.ID = result.InsertedID.(primitive.ObjectID)
+	return action, nil
+}
+
+// GetModerationActions returns the moderation log, newest first, optionally
+// of a single target.
+func (rr *reportRepository) GetModerationActions(req *request.GetModerationLogRequest) (*[]entity.ModerationAction, string, error) {
+	filter := bson.D{}
+	if req.TargetID != "" {
+		tID, err := primitive.ObjectIDFromHex(req.TargetID)
+		if err != nil {
+			return nil, "", err
+		}
+		filter = append(filter, bson.E{Key: "target_id", Value: tID})
+	}
+	if req.Cursor != "" {
+		cursor, err := pagination.DecodeCursor(req.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$lt", Value: cursor.ID}}})
+	}
+
+	limit := pagination.NormalizeLimit(req.Limit)
+	option := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetLimit(int64(limit + 1))
+	cursor, err := rr.db.Collection(rr.actionColName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, "", err
+	}
+	actions := []entity.ModerationAction{}
+	if err = cursor.All(context.TODO(), &actions); err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(actions) > limit {
+		actions = actions[:limit]
+		nextCursor = pagination.EncodeCursor(nil, actions[limit-1].ID)
+	}
+	return &actions, nextCursor, nil
+}
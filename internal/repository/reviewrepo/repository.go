@@ -0,0 +1,217 @@
+package reviewrepo
+
+import (
+	"context"
+	"time"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/pkg/pagination"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type reviewRepository struct {
+	db      *mongo.Database
+	colName string
+}
+
+func NewReviewRepository(db *mongo.Database) repository.ReviewRepository {
+	return &reviewRepository{
+		db:      db,
+		colName: "review_logs",
+	}
+}
+
+func (rr *reviewRepository) CreateReviewLog(log *entity.ReviewLog) (*entity.ReviewLog, error) {
+	result, err := rr.db.Collection(rr.colName).InsertOne(context.TODO(), log)
+	if err != nil {
+		return nil, err
+	}
+	log.ID = result.InsertedID.(primitive.ObjectID)
+	return log, nil
+}
+
+func (rr *reviewRepository) GetLastReviewLog(userID *string) (*entity.ReviewLog, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	option := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	var log entity.ReviewLog
+	err = rr.db.Collection(rr.colName).FindOne(context.TODO(), bson.D{{Key: "user_id", Value: uID}}, option).Decode(&log)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &log, nil
+}
+
+// StreamReviewLogsOfUser calls fn with every session of the user, oldest first.
+func (rr *reviewRepository) StreamReviewLogsOfUser(userID *string, fn func(log *entity.ReviewLog) error) error {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return err
+	}
+	option := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := rr.db.Collection(rr.colName).Find(context.TODO(), bson.D{{Key: "user_id", Value: uID}}, option)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.TODO())
+	for cursor.Next(context.TODO()) {
+		var log entity.ReviewLog
+		if err = cursor.Decode(&log); err != nil {
+			return err
+		}
+		if err = fn(&log); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// GetReviewStats returns how many cards the user reviewed in total and how
+// many of their sessions earned the perfect session bonus.
+func (rr *reviewRepository) GetReviewStats(userID *string) (int, int, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return 0, 0, err
+	}
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.D{{Key: "user_id", Value: uID}}}},
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: nil},
+			{Key: "reviews", Value: bson.D{{Key: "$sum", Value: "$num_cards"}}},
+			{Key: "perfect_sessions", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$cond", Value: bson.A{
+				bson.D{{Key: "$gt", Value: bson.A{"$breakdown.perfect_bonus_xp", 0}}}, 1, 0,
+			}}}}}},
+		}}},
+	}
+	cursor, err := rr.db.Collection(rr.colName).Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return 0, 0, err
+	}
+	var stats []struct {
+		Reviews         int `bson:"reviews"`
+		PerfectSessions int `bson:"perfect_sessions"`
+	}
+	if err = cursor.All(context.TODO(), &stats); err != nil {
+		return 0, 0, err
+	}
+	if len(stats) == 0 {
+		return 0, 0, nil
+	}
+	return stats[0].Reviews, stats[0].PerfectSessions, nil
+}
+
+// GetActivityDays sums up the user's review sessions per day in the time
+// zone, oldest first. Only days with a session are returned.
+func (rr *reviewRepository) GetActivityDays(userID *string, timeZone string) (*[]entity.ActivityDay, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.D{{Key: "user_id", Value: uID}}}},
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "$dateToString", Value: bson.D{
+				{Key: "format", Value: "%Y-%m-%d"},
+				{Key: "date", Value: "$created_at"},
+				{Key: "timezone", Value: timeZone},
+			}}}},
+			{Key: "reviews", Value: bson.D{{Key: "$sum", Value: "$num_cards"}}},
+			{Key: "new_cards", Value: bson.D{{Key: "$sum", Value: "$num_new"}}},
+			{Key: "xp", Value: bson.D{{Key: "$sum", Value: "$awarded_xp"}}},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+	cursor, err := rr.db.Collection(rr.colName).Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	days := []entity.ActivityDay{}
+	if err = cursor.All(context.TODO(), &days); err != nil {
+		return nil, err
+	}
+	return &days, nil
+}
+
+// GetFlaggedReviewLogs returns the flagged sessions, newest first, optionally
+// of a single user.
+func (rr *reviewRepository) GetFlaggedReviewLogs(req *request.GetFlaggedReviewsRequest) (*[]entity.ReviewLog, string, error) {
+	filter := bson.D{{Key: "is_flagged", Value: true}}
+	if req.UserID != "" {
+		uID, err := primitive.ObjectIDFromHex(req.UserID)
+		if err != nil {
+			return nil, "", err
+		}
+		filter = append(filter, bson.E{Key: "user_id", Value: uID})
+	}
+	if req.Cursor != "" {
+		cursor, err := pagination.DecodeCursor(req.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$lt", Value: cursor.ID}}})
+	}
+
+	limit := pagination.NormalizeLimit(req.Limit)
+	option := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetLimit(int64(limit + 1))
+	cursor, err := rr.db.Collection(rr.colName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, "", err
+	}
+	logs := []entity.ReviewLog{}
+	if err = cursor.All(context.TODO(), &logs); err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(logs) > limit {
+		logs = logs[:limit]
+		nextCursor = pagination.EncodeCursor(nil, logs[limit-1].ID)
+	}
+	return &logs, nextCursor, nil
+}
+
+// CountDecksReviewedOn counts, per user, the distinct decks they reviewed in
+// a session logged on the day starting at day, shared decks included.
+func (rr *reviewRepository) CountDecksReviewedOn(userIDs []primitive.ObjectID, day time.Time) (map[primitive.ObjectID]int, error) {
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.D{
+			{Key: "user_id", Value: bson.D{{Key: "$in", Value: userIDs}}},
+			{Key: "created_at", Value: bson.D{{Key: "$gte", Value: day}, {Key: "$lt", Value: day.AddDate(0, 0, 1)}}},
+		}}},
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "user_id", Value: "$user_id"}, {Key: "deck_id", Value: "$deck_id"}}},
+		}}},
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$_id.user_id"},
+			{Key: "decks", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	}
+	cursor, err := rr.db.Collection(rr.colName).Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var counts []struct {
+		UserID primitive.ObjectID `bson:"_id"`
+		Decks  int                `bson:"decks"`
+	}
+	if err = cursor.All(context.TODO(), &counts); err != nil {
+		return nil, err
+	}
+	result := make(map[primitive.ObjectID]int, len(counts))
+	for _, count := range counts {
+		result[count.UserID] = count.Decks
+	}
+	return result, nil
+}
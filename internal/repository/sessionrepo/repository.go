@@ -0,0 +1,165 @@
+package sessionrepo
+
+import (
+	"context"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type sessionRepository struct {
+	db           *mongo.Database
+	colName      string
+	tokenColName string
+}
+
+func NewSessionRepository(db *mongo.Database) repository.SessionRepository {
+	return &sessionRepository{
+		db:           db,
+		colName:      "sessions",
+		tokenColName: "refresh_tokens",
+	}
+}
+
+func (sr *sessionRepository) CreateSession(session *entity.Session) (*entity.Session, error) {
+	result, err := sr.db.Collection(sr.colName).InsertOne(context.TODO(), session)
+	if err != nil {
+		return nil, err
+	}
+	session.ID = result.InsertedID.(primitive.ObjectID)
+	return session, nil
+}
+
+func (sr *sessionRepository) GetSession(sessionID primitive.ObjectID) (*entity.Session, error) {
+	var session entity.Session
+	err := sr.db.Collection(sr.colName).FindOne(context.TODO(), bson.D{{Key: "_id", Value: sessionID}}).Decode(&session)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetUserSessions returns the user's sessions that are neither revoked nor
+// expired, most recently seen first.
+func (sr *sessionRepository) GetUserSessions(userID primitive.ObjectID, now time.Time) (*[]entity.Session, error) {
+	filter := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "revoked_at", Value: nil},
+		{Key: "expires_at", Value: bson.D{{Key: "$gt", Value: now}}},
+	}
+	option := options.Find().SetSort(bson.D{{Key: "last_seen_at", Value: -1}})
+	cursor, err := sr.db.Collection(sr.colName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, err
+	}
+	sessions := []entity.Session{}
+	if err = cursor.All(context.TODO(), &sessions); err != nil {
+		return nil, err
+	}
+	return &sessions, nil
+}
+
+// TouchSession records a refresh of the session from the device and extends
+// it until expiresAt.
+func (sr *sessionRepository) TouchSession(sessionID primitive.ObjectID, device *entity.SessionDevice, now time.Time, expiresAt time.Time) error {
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "user_agent", Value: device.UserAgent},
+		{Key: "ip", Value: device.IP},
+		{Key: "app_version", Value: device.AppVersion},
+		{Key: "last_seen_at", Value: now},
+		{Key: "expires_at", Value: expiresAt},
+	}}}
+	_, err := sr.db.Collection(sr.colName).UpdateByID(context.TODO(), sessionID, update)
+	return err
+}
+
+func (sr *sessionRepository) SeeSession(sessionID primitive.ObjectID, ip string, now time.Time) error {
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "ip", Value: ip},
+		{Key: "last_seen_at", Value: now},
+	}}}
+	_, err := sr.db.Collection(sr.colName).UpdateByID(context.TODO(), sessionID, update)
+	return err
+}
+
+// RevokeSession revokes the session if it isn't revoked yet and deletes its
+// refresh tokens.
+func (sr *sessionRepository) RevokeSession(sessionID primitive.ObjectID, reason string) error {
+	filter := bson.D{{Key: "_id", Value: sessionID}, {Key: "revoked_at", Value: nil}}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "revoked_at", Value: time.Now()},
+		{Key: "revoke_reason", Value: reason},
+	}}}
+	_, err := sr.db.Collection(sr.colName).UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return err
+	}
+	_, err = sr.db.Collection(sr.tokenColName).DeleteMany(context.TODO(), bson.D{{Key: "session_id", Value: sessionID}})
+	return err
+}
+
+// RevokeUserSessions revokes all of the user's sessions but the excepted one,
+// if any, and deletes their refresh tokens. It returns how many sessions were
+// revoked.
+func (sr *sessionRepository) RevokeUserSessions(userID primitive.ObjectID, exceptSessionID *primitive.ObjectID, reason string) (int, error) {
+	filter := bson.D{{Key: "user_id", Value: userID}, {Key: "revoked_at", Value: nil}}
+	tokenFilter := bson.D{{Key: "user_id", Value: userID}}
+	if exceptSessionID != nil {
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$ne", Value: *exceptSessionID}}})
+		tokenFilter = append(tokenFilter, bson.E{Key: "session_id", Value: bson.D{{Key: "$ne", Value: *exceptSessionID}}})
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "revoked_at", Value: time.Now()},
+		{Key: "revoke_reason", Value: reason},
+	}}}
+	result, err := sr.db.Collection(sr.colName).UpdateMany(context.TODO(), filter, update)
+	if err != nil {
+		return 0, err
+	}
+	_, err = sr.db.Collection(sr.tokenColName).DeleteMany(context.TODO(), tokenFilter)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.ModifiedCount), nil
+}
+
+func (sr *sessionRepository) CreateRefreshToken(token *entity.RefreshToken) (*entity.RefreshToken, error) {
+	result, err := sr.db.Collection(sr.tokenColName).InsertOne(context.TODO(), token)
+	if err != nil {
+		return nil, err
+	}
+	token.ID = result.InsertedID.(primitive.ObjectID)
+	return token, nil
+}
+
+func (sr *sessionRepository) GetRefreshToken(tokenHash string) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	err := sr.db.Collection(sr.tokenColName).FindOne(context.TODO(), bson.D{{Key: "token_hash", Value: tokenHash}}).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken marks the token as rotated. It returns false if the token
+// was already rotated, so only one of concurrent refreshes wins.
+func (sr *sessionRepository) RotateRefreshToken(tokenID primitive.ObjectID, now time.Time) (bool, error) {
+	filter := bson.D{{Key: "_id", Value: tokenID}, {Key: "rotated_at", Value: nil}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "rotated_at", Value: now}}}}
+	result, err := sr.db.Collection(sr.tokenColName).UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
@@ -0,0 +1,127 @@
+package sharerepo
+
+import (
+	"context"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type shareRepository struct {
+	db             *mongo.Database
+	colName        string
+	attemptColName string
+}
+
+func NewShareRepository(db *mongo.Database) repository.ShareRepository {
+	return &shareRepository{
+		db:             db,
+		colName:        "share_links",
+		attemptColName: "share_link_attempts",
+	}
+}
+
+func (sr *shareRepository) CreateShareLink(link *entity.ShareLink) (*entity.ShareLink, error) {
+	link.CreatedAt = time.Now()
+	result, err := sr.db.Collection(sr.colName).InsertOne(context.TODO(), link)
+	if err != nil {
+		return nil, err
+	}
+	link.ID = result.InsertedID.(primitive.ObjectID)
+	return link, nil
+}
+
+func (sr *shareRepository) getShareLink(filter bson.D) (*entity.ShareLink, error) {
+	var link entity.ShareLink
+	err := sr.db.Collection(sr.colName).FindOne(context.TODO(), filter).Decode(&link)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (sr *shareRepository) GetShareLinkByID(id *string) (*entity.ShareLink, error) {
+	oID, err := primitive.ObjectIDFromHex(*id)
+	if err != nil {
+		return nil, err
+	}
+	return sr.getShareLink(bson.D{{Key: "_id", Value: oID}})
+}
+
+func (sr *shareRepository) GetShareLinkByToken(token *string) (*entity.ShareLink, error) {
+	return sr.getShareLink(bson.D{{Key: "token", Value: *token}})
+}
+
+func (sr *shareRepository) GetShareLinksOfDeck(deckID *string) (*[]entity.ShareLink, error) {
+	dID, err := primitive.ObjectIDFromHex(*deckID)
+	if err != nil {
+		return nil, err
+	}
+	option := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := sr.db.Collection(sr.colName).Find(context.TODO(), bson.D{{Key: "deck_id", Value: dID}}, option)
+	if err != nil {
+		return nil, err
+	}
+	links := []entity.ShareLink{}
+	if err = cursor.All(context.TODO(), &links); err != nil {
+		return nil, err
+	}
+	return &links, nil
+}
+
+// RevokeShareLink keeps the time of the first revocation if called again.
+func (sr *shareRepository) RevokeShareLink(id *string) error {
+	oID, err := primitive.ObjectIDFromHex(*id)
+	if err != nil {
+		return err
+	}
+	filter := bson.D{{Key: "_id", Value: oID}, {Key: "revoked_at", Value: bson.D{{Key: "$exists", Value: false}}}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "revoked_at", Value: time.Now()}}}}
+	_, err = sr.db.Collection(sr.colName).UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+// GetFailedAttempts returns the number of failed attempts of each key in the
+// window starting at windowStart. Keys without any are left out.
+func (sr *shareRepository) GetFailedAttempts(keys []string, windowStart time.Time) (map[string]int, error) {
+	filter := bson.D{
+		{Key: "key", Value: bson.D{{Key: "$in", Value: keys}}},
+		{Key: "window_start", Value: windowStart},
+	}
+	cursor, err := sr.db.Collection(sr.attemptColName).Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+	var attempts []struct {
+		Key   string `bson:"key"`
+		Count int    `bson:"count"`
+	}
+	if err = cursor.All(context.TODO(), &attempts); err != nil {
+		return nil, err
+	}
+	result := make(map[string]int, len(attempts))
+	for _, attempt := range attempts {
+		result[attempt.Key] = attempt.Count
+	}
+	return result, nil
+}
+
+func (sr *shareRepository) AddFailedAttempt(keys []string, windowStart time.Time) error {
+	for _, key := range keys {
+		filter := bson.D{{Key: "key", Value: key}, {Key: "window_start", Value: windowStart}}
+		update := bson.D{{Key: "$inc", Value: bson.D{{Key: "count", Value: 1}}}}
+		_, err := sr.db.Collection(sr.attemptColName).UpdateOne(context.TODO(), filter, update, options.Update().SetUpsert(true))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
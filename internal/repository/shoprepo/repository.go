@@ -0,0 +1,181 @@
+package shoprepo
+
+import (
+	"context"
+	"time"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type shopRepository struct {
+	db              *mongo.Database
+	colName         string
+	purchaseColName string
+	userItemColName string
+}
+
+func NewShopRepository(db *mongo.Database) repository.ShopRepository {
+	return &shopRepository{
+		db:              db,
+		colName:         "shop_items",
+		purchaseColName: "purchases",
+		userItemColName: "user_items",
+	}
+}
+
+func (sr *shopRepository) CreateShopItem(item *entity.ShopItem) (*entity.ShopItem, error) {
+	item.CreatedAt = time.Now()
+	item.UpdatedAt = item.CreatedAt
+	result, err := sr.db.Collection(sr.colName).InsertOne(context.TODO(), item)
+	if err != nil {
+		return nil, err
+	}
+	item.ID = result.InsertedID.(primitive.ObjectID)
+	return item, nil
+}
+
+func (sr *shopRepository) UpdateShopItem(itemID *string, req *request.UpdateShopItemRequest) (*entity.ShopItem, error) {
+	iID, err := primitive.ObjectIDFromHex(*itemID)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{{Key: "_id", Value: iID}}
+	update := bson.D{
+		{Key: "$set", Value: *req},
+		{Key: "$currentDate", Value: bson.D{{Key: "updated_at", Value: true}}},
+	}
+	option := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var item entity.ShopItem
+	err = sr.db.Collection(sr.colName).FindOneAndUpdate(context.TODO(), filter, update, option).Decode(&item)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (sr *shopRepository) GetShopItem(itemID *string) (*entity.ShopItem, error) {
+	iID, err := primitive.ObjectIDFromHex(*itemID)
+	if err != nil {
+		return nil, err
+	}
+	var item entity.ShopItem
+	err = sr.db.Collection(sr.colName).FindOne(context.TODO(), bson.D{{Key: "_id", Value: iID}}).Decode(&item)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetShopItems returns the catalog, cheapest first.
+func (sr *shopRepository) GetShopItems(activeOnly bool) (*[]entity.ShopItem, error) {
+	filter := bson.D{}
+	if activeOnly {
+		filter = append(filter, bson.E{Key: "is_active", Value: true})
+	}
+	option := options.Find().SetSort(bson.D{{Key: "price", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := sr.db.Collection(sr.colName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, err
+	}
+	items := []entity.ShopItem{}
+	if err = cursor.All(context.TODO(), &items); err != nil {
+		return nil, err
+	}
+	return &items, nil
+}
+
+// CreatePurchase returns false if the user already made a purchase with the
+// same idempotency key.
+func (sr *shopRepository) CreatePurchase(purchase *entity.Purchase) (bool, error) {
+	purchase.CreatedAt = time.Now()
+	result, err := sr.db.Collection(sr.purchaseColName).InsertOne(context.TODO(), purchase)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	purchase.ID = result.InsertedID.(primitive.ObjectID)
+	return true, nil
+}
+
+func (sr *shopRepository) GetPurchase(userID primitive.ObjectID, idempotencyKey string) (*entity.Purchase, error) {
+	filter := bson.D{{Key: "user_id", Value: userID}, {Key: "idempotency_key", Value: idempotencyKey}}
+	var purchase entity.Purchase
+	err := sr.db.Collection(sr.purchaseColName).FindOne(context.TODO(), filter).Decode(&purchase)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &purchase, nil
+}
+
+// SetPurchaseStatus settles a pending purchase. It returns false if the
+// purchase was settled already.
+func (sr *shopRepository) SetPurchaseStatus(purchaseID primitive.ObjectID, status string, failReason string) (bool, error) {
+	filter := bson.D{{Key: "_id", Value: purchaseID}, {Key: "status", Value: entity.PURCHASE_STATUS_PENDING}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "status", Value: status}, {Key: "fail_reason", Value: failReason}}}}
+	result, err := sr.db.Collection(sr.purchaseColName).UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// AddUserItem returns false if the user already owns the item.
+func (sr *shopRepository) AddUserItem(item *entity.UserItem) (bool, error) {
+	item.AcquiredAt = time.Now()
+	result, err := sr.db.Collection(sr.userItemColName).InsertOne(context.TODO(), item)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	item.ID = result.InsertedID.(primitive.ObjectID)
+	return true, nil
+}
+
+func (sr *shopRepository) GetUserItem(userID primitive.ObjectID, itemID primitive.ObjectID) (*entity.UserItem, error) {
+	filter := bson.D{{Key: "user_id", Value: userID}, {Key: "item_id", Value: itemID}}
+	var item entity.UserItem
+	err := sr.db.Collection(sr.userItemColName).FindOne(context.TODO(), filter).Decode(&item)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (sr *shopRepository) GetUserItems(userID *string) (*[]entity.UserItem, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	option := options.Find().SetSort(bson.D{{Key: "acquired_at", Value: 1}})
+	cursor, err := sr.db.Collection(sr.userItemColName).Find(context.TODO(), bson.D{{Key: "user_id", Value: uID}}, option)
+	if err != nil {
+		return nil, err
+	}
+	items := []entity.UserItem{}
+	if err = cursor.All(context.TODO(), &items); err != nil {
+		return nil, err
+	}
+	return &items, nil
+}
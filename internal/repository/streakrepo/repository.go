@@ -0,0 +1,74 @@
+package streakrepo
+
+import (
+	"context"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type streakRepository struct {
+	db      *mongo.Database
+	colName string
+}
+
+func NewStreakRepository(db *mongo.Database) repository.StreakRepository {
+	return &streakRepository{
+		db:      db,
+		colName: "streak_days",
+	}
+}
+
+// RecordStreakDays sets the status of each day, so recording a day twice
+// keeps a single entry.
+func (sr *streakRepository) RecordStreakDays(days []entity.StreakDay) error {
+	if len(days) == 0 {
+		return nil
+	}
+	models := make([]mongo.WriteModel, 0, len(days))
+	for _, day := range days {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: "user_id", Value: day.UserID}, {Key: "day", Value: day.Day}}).
+			SetUpdate(bson.D{{Key: "$set", Value: bson.D{{Key: "status", Value: day.Status}}}}).
+			SetUpsert(true))
+	}
+	_, err := sr.db.Collection(sr.colName).BulkWrite(context.TODO(), models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// RepairStreakDays marks the missed days in [from, to] as repaired.
+func (sr *streakRepository) RepairStreakDays(userID primitive.ObjectID, from time.Time, to time.Time) error {
+	filter := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "day", Value: bson.D{{Key: "$gte", Value: from}, {Key: "$lte", Value: to}}},
+		{Key: "status", Value: entity.STREAK_DAY_MISSED},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "status", Value: entity.STREAK_DAY_REPAIRED}}}}
+	_, err := sr.db.Collection(sr.colName).UpdateMany(context.TODO(), filter, update)
+	return err
+}
+
+// GetStreakDays returns the recorded days of the user since from, oldest
+// first.
+func (sr *streakRepository) GetStreakDays(userID *string, from time.Time) (*[]entity.StreakDay, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{{Key: "user_id", Value: uID}, {Key: "day", Value: bson.D{{Key: "$gte", Value: from}}}}
+	option := options.Find().SetSort(bson.D{{Key: "day", Value: 1}})
+	cursor, err := sr.db.Collection(sr.colName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, err
+	}
+	days := []entity.StreakDay{}
+	if err = cursor.All(context.TODO(), &days); err != nil {
+		return nil, err
+	}
+	return &days, nil
+}
@@ -69,6 +69,19 @@ func (ur *userRepository) GetByID(id *string) (*entity.User, error) {
 	return &user, nil
 }
 
+func (ur *userRepository) GetByIDs(ids []primitive.ObjectID) (*[]entity.User, error) {
+	filter := bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}}
+	cursor, err := ur.db.Collection(ur.colName).Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+	users := []entity.User{}
+	if err = cursor.All(context.TODO(), &users); err != nil {
+		return nil, err
+	}
+	return &users, nil
+}
+
 func (ur *userRepository) UpdateUser(userID *string, req *request.UpdateUserRequest) (*entity.User, error) {
 	uID, err := primitive.ObjectIDFromHex(*userID)
 	if err != nil {
@@ -92,8 +105,6 @@ func (ur *userRepository) UpdateUserXP(user *entity.User) error {
 			{Key: "xp", Value: user.XP},
 			{Key: "xp_to_level_up", Value: user.XPToLevelUp},
 			{Key: "level", Value: user.Level},
-			{Key: "streak", Value: user.Streak},
-			{Key: "last_streak", Value: user.LastStreak},
 		}},
 	}
 	_, err := ur.db.Collection(ur.colName).UpdateOne(context.TODO(), &filter, &update)
@@ -103,37 +114,159 @@ func (ur *userRepository) UpdateUserXP(user *entity.User) error {
 	return nil
 }
 
-func (ur *userRepository) CreateFact(fact *entity.Fact) error {
-	_, err := ur.db.Collection("fun_facts").InsertOne(context.TODO(), *fact)
+// UpdateUserStreak stores the streak of the user read with the given last
+// streak time and adds freezeDelta to their streak freezes. Freezes bought in
+// the meantime are kept. It returns false if the streak was updated since it
+// was read or the user no longer has the freezes to spend.
+func (ur *userRepository) UpdateUserStreak(user *entity.User, lastStreak time.Time, freezeDelta int) (bool, error) {
+	filter := bson.D{{Key: "_id", Value: user.ID}, {Key: "last_streak", Value: lastStreak}}
+	if freezeDelta < 0 {
+		filter = append(filter, bson.E{Key: "streak_freezes", Value: bson.D{{Key: "$gte", Value: -freezeDelta}}})
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "streak", Value: user.Streak},
+		{Key: "last_streak", Value: user.LastStreak},
+		{Key: "streak_repair", Value: user.StreakRepair},
+		{Key: "last_streak_repair", Value: user.LastStreakRepair},
+	}}}
+	if freezeDelta != 0 {
+		update = append(update, bson.E{Key: "$inc", Value: bson.D{{Key: "streak_freezes", Value: freezeDelta}}})
+	}
+	result, err := ur.db.Collection(ur.colName).UpdateOne(context.TODO(), filter, update)
 	if err != nil {
-		return err
+		return false, err
 	}
-
-	return nil
+	return result.MatchedCount > 0, nil
 }
 
-func (ur *userRepository) GetFact() (*entity.Fact, error) {
-	pipeline := bson.A{
-		bson.D{{"$sample", bson.D{{"size", 1}}}},
+// IncrementLifetimeXP adds the XP atomically and returns the updated user.
+func (ur *userRepository) IncrementLifetimeXP(userID primitive.ObjectID, XP int) (*entity.User, error) {
+	filter := bson.D{{Key: "_id", Value: userID}}
+	update := bson.D{{Key: "$inc", Value: bson.D{{Key: "lifetime_xp", Value: XP}}}}
+	option := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var user entity.User
+	err := ur.db.Collection(ur.colName).FindOneAndUpdate(context.TODO(), filter, update, option).Decode(&user)
+	if err != nil {
+		return nil, err
 	}
+	return &user, nil
+}
 
-	opts := options.Aggregate().SetMaxTime(2 * time.Second) // You can adjust the timeout as needed
+// SetLevel stores the lifetime XP of the user and the level derived from it.
+func (ur *userRepository) SetLevel(user *entity.User) error {
+	filter := bson.D{{Key: "_id", Value: user.ID}}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "lifetime_xp", Value: user.LifetimeXP},
+		{Key: "xp", Value: user.XP},
+		{Key: "xp_to_level_up", Value: user.XPToLevelUp},
+		{Key: "level", Value: user.Level},
+	}}}
+	_, err := ur.db.Collection(ur.colName).UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+// ResetLevels puts every user without lifetime XP back to the start of the
+// first level.
+func (ur *userRepository) ResetLevels(xpToLevelUp int) error {
+	filter := bson.D{{Key: "lifetime_xp", Value: bson.D{{Key: "$lte", Value: 0}}}}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "lifetime_xp", Value: 0},
+		{Key: "xp", Value: 0},
+		{Key: "xp_to_level_up", Value: xpToLevelUp},
+		{Key: "level", Value: 1},
+	}}}
+	_, err := ur.db.Collection(ur.colName).UpdateMany(context.TODO(), filter, update)
+	return err
+}
 
-	cursor, err := ur.db.Collection("fun_facts").Aggregate(context.TODO(), pipeline, opts)
+// GetReminderUsers returns the users with reminders enabled that were not
+// reminded since sentBefore.
+func (ur *userRepository) GetReminderUsers(sentBefore time.Time) (*[]entity.User, error) {
+	filter := bson.D{
+		{Key: "reminder.enabled", Value: true},
+		{Key: "reminder.last_sent_at", Value: bson.D{{Key: "$not", Value: bson.D{{Key: "$gte", Value: sentBefore}}}}},
+	}
+	cursor, err := ur.db.Collection(ur.colName).Find(context.TODO(), filter)
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(context.TODO())
+	users := []entity.User{}
+	if err = cursor.All(context.TODO(), &users); err != nil {
+		return nil, err
+	}
+	return &users, nil
+}
 
-	if !cursor.Next(context.TODO()) {
-		return nil, errors.New("no facts found")
+// ClaimReminder marks the user as reminded at now. It returns false if the
+// user was already reminded since dueAt, so that a reminder is sent once even
+// when several instances look for due reminders.
+func (ur *userRepository) ClaimReminder(userID primitive.ObjectID, dueAt time.Time, now time.Time) (bool, error) {
+	filter := bson.D{
+		{Key: "_id", Value: userID},
+		{Key: "reminder.last_sent_at", Value: bson.D{{Key: "$not", Value: bson.D{{Key: "$gte", Value: dueAt}}}}},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "reminder.last_sent_at", Value: now}}}}
+	result, err := ur.db.Collection(ur.colName).UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
 	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (ur *userRepository) SetLeagueTier(userID primitive.ObjectID, tier int) error {
+	filter := bson.D{{Key: "_id", Value: userID}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "league_tier", Value: tier}}}}
+	_, err := ur.db.Collection(ur.colName).UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+func (ur *userRepository) SetBanned(userID primitive.ObjectID, banned bool) error {
+	filter := bson.D{{Key: "_id", Value: userID}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "is_banned", Value: banned}}}}
+	_, err := ur.db.Collection(ur.colName).UpdateOne(context.TODO(), filter, update)
+	return err
+}
 
-	var result entity.Fact
-	err = cursor.Decode(&result)
+// AddStreakFreezes returns false if the user would have more than max
+// streak freezes.
+func (ur *userRepository) AddStreakFreezes(userID primitive.ObjectID, count int, max int) (bool, error) {
+	filter := bson.D{{Key: "_id", Value: userID}, {Key: "streak_freezes", Value: bson.D{{Key: "$lte", Value: max - count}}}}
+	update := bson.D{{Key: "$inc", Value: bson.D{{Key: "streak_freezes", Value: count}}}}
+	result, err := ur.db.Collection(ur.colName).UpdateOne(context.TODO(), filter, update)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (ur *userRepository) SetXPBoost(userID primitive.ObjectID, boost *entity.XPBoost) error {
+	filter := bson.D{{Key: "_id", Value: userID}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "xp_boost", Value: boost}}}}
+	_, err := ur.db.Collection(ur.colName).UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+func (ur *userRepository) IncrementWarningCount(userID primitive.ObjectID) error {
+	filter := bson.D{{Key: "_id", Value: userID}}
+	update := bson.D{{Key: "$inc", Value: bson.D{{Key: "warning_count", Value: 1}}}}
+	_, err := ur.db.Collection(ur.colName).UpdateOne(context.TODO(), filter, update)
+	return err
+}
 
-	return &result, nil
+func (ur *userRepository) IncrementFollowCounts(followerID *string, followeeID *string, delta int) error {
+	for _, count := range []struct {
+		userID *string
+		field  string
+	}{{followerID, "following_count"}, {followeeID, "follower_count"}} {
+		uID, err := primitive.ObjectIDFromHex(*count.userID)
+		if err != nil {
+			return err
+		}
+		filter := bson.D{{Key: "_id", Value: uID}}
+		update := bson.D{{Key: "$inc", Value: bson.D{{Key: count.field, Value: delta}}}}
+		if _, err = ur.db.Collection(ur.colName).UpdateOne(context.TODO(), filter, update); err != nil {
+			return err
+		}
+	}
+	return nil
 }
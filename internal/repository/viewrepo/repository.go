@@ -0,0 +1,73 @@
+package viewrepo
+
+import (
+	"context"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type viewRepository struct {
+	db           *mongo.Database
+	colName      string
+	dailyColName string
+}
+
+func NewViewRepository(db *mongo.Database) repository.ViewRepository {
+	return &viewRepository{
+		db:           db,
+		colName:      "deck_view_events",
+		dailyColName: "deck_view_daily",
+	}
+}
+
+// CreateViewEvent returns false if the viewer was already counted in the
+// event's window.
+func (vr *viewRepository) CreateViewEvent(event *entity.DeckViewEvent) (bool, error) {
+	event.CreatedAt = time.Now()
+	_, err := vr.db.Collection(vr.colName).InsertOne(context.TODO(), event)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (vr *viewRepository) IncrementDailyViews(deckID *string, day string) error {
+	dID, err := primitive.ObjectIDFromHex(*deckID)
+	if err != nil {
+		return err
+	}
+	filter := bson.D{{Key: "deck_id", Value: dID}, {Key: "day", Value: day}}
+	update := bson.D{{Key: "$inc", Value: bson.D{{Key: "views", Value: 1}}}}
+	_, err = vr.db.Collection(vr.dailyColName).UpdateOne(context.TODO(), filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (vr *viewRepository) GetDailyViews(deckID *string, from time.Time) (*[]entity.DeckViewDaily, error) {
+	dID, err := primitive.ObjectIDFromHex(*deckID)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{
+		{Key: "deck_id", Value: dID},
+		{Key: "day", Value: bson.D{{Key: "$gte", Value: from.Format(entity.VIEW_DAY_LAYOUT)}}},
+	}
+	option := options.Find().SetSort(bson.D{{Key: "day", Value: 1}})
+	cursor, err := vr.db.Collection(vr.dailyColName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, err
+	}
+	days := []entity.DeckViewDaily{}
+	if err = cursor.All(context.TODO(), &days); err != nil {
+		return nil, err
+	}
+	return &days, nil
+}
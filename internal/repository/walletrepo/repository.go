@@ -0,0 +1,151 @@
+package walletrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/pkg/pagination"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type walletRepository struct {
+	db            *mongo.Database
+	colName       string
+	ledgerColName string
+}
+
+func NewWalletRepository(db *mongo.Database) repository.WalletRepository {
+	return &walletRepository{
+		db:            db,
+		colName:       "wallets",
+		ledgerColName: "wallet_ledger",
+	}
+}
+
+func (wr *walletRepository) GetWallet(userID *string) (*entity.Wallet, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	var wallet entity.Wallet
+	err = wr.db.Collection(wr.colName).FindOne(context.TODO(), bson.D{{Key: "user_id", Value: uID}}).Decode(&wallet)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+var (
+	errEntryRecorded = errors.New("ledger entry was already recorded")
+	errNotCovered    = errors.New("balance doesn't cover the spend")
+)
+
+// ApplyLedgerEntry records the entry and moves the balance by it in one
+// transaction, so the balance always matches the ledger. It returns whether
+// the entry was recorded, which it isn't if the user already has an entry with
+// the same idempotency key, and whether the balance covered it. A spend the
+// balance doesn't cover is not recorded. Transactions need MongoDB to run as
+// a replica set.
+func (wr *walletRepository) ApplyLedgerEntry(entry *entity.LedgerEntry) (bool, bool, error) {
+	session, err := wr.db.Client().StartSession()
+	if err != nil {
+		return false, false, err
+	}
+	defer session.EndSession(context.TODO())
+
+	entry.CreatedAt = time.Now()
+	delta := entry.Delta()
+	filter := bson.D{{Key: "user_id", Value: entry.UserID}}
+	if delta < 0 {
+		filter = append(filter, bson.E{Key: "balance", Value: bson.D{{Key: "$gte", Value: -delta}}})
+	}
+	update := bson.D{
+		{Key: "$inc", Value: bson.D{{Key: "balance", Value: delta}}},
+		{Key: "$set", Value: bson.D{{Key: "updated_at", Value: entry.CreatedAt}}},
+	}
+	_, err = session.WithTransaction(context.TODO(), func(ctx mongo.SessionContext) (interface{}, error) {
+		result, err := wr.db.Collection(wr.ledgerColName).InsertOne(ctx, entry)
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return nil, errEntryRecorded
+			}
+			return nil, err
+		}
+		updated, err := wr.db.Collection(wr.colName).UpdateOne(ctx, filter, update, options.Update().SetUpsert(delta >= 0))
+		if err != nil {
+			return nil, err
+		}
+		if updated.MatchedCount == 0 && updated.UpsertedCount == 0 {
+			return nil, errNotCovered
+		}
+		entry.ID = result.InsertedID.(primitive.ObjectID)
+		return nil, nil
+	})
+	switch err {
+	case nil:
+		return true, true, nil
+	case errEntryRecorded:
+		return false, true, nil
+	case errNotCovered:
+		return false, false, nil
+	}
+	return false, false, err
+}
+
+func (wr *walletRepository) GetLedgerEntry(userID primitive.ObjectID, idempotencyKey string) (*entity.LedgerEntry, error) {
+	filter := bson.D{{Key: "user_id", Value: userID}, {Key: "idempotency_key", Value: idempotencyKey}}
+	var entry entity.LedgerEntry
+	err := wr.db.Collection(wr.ledgerColName).FindOne(context.TODO(), filter).Decode(&entry)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetLedger returns the user's ledger, newest first.
+func (wr *walletRepository) GetLedger(userID *string, limit int, cursor string) (*[]entity.LedgerEntry, string, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, "", err
+	}
+	filter := bson.D{{Key: "user_id", Value: uID}}
+	if cursor != "" {
+		c, err := pagination.DecodeCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$lt", Value: c.ID}}})
+	}
+
+	limit = pagination.NormalizeLimit(limit)
+	option := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetLimit(int64(limit + 1))
+	cur, err := wr.db.Collection(wr.ledgerColName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, "", err
+	}
+	entries := []entity.LedgerEntry{}
+	if err = cur.All(context.TODO(), &entries); err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(entries) > limit {
+		entries = entries[:limit]
+		nextCursor = pagination.EncodeCursor(nil, entries[limit-1].ID)
+	}
+	return &entries, nextCursor, nil
+}
@@ -0,0 +1,126 @@
+package xprepo
+
+import (
+	"context"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/pkg/pagination"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type xpRepository struct {
+	db      *mongo.Database
+	colName string
+}
+
+func NewXPRepository(db *mongo.Database) repository.XPRepository {
+	return &xpRepository{
+		db:      db,
+		colName: "xp_ledger",
+	}
+}
+
+// CreateXPEntry appends the entry to the ledger. It returns false if an entry
+// with the same source and reference already exists, so the same review or
+// quest is never counted twice.
+func (xr *xpRepository) CreateXPEntry(entry *entity.XPEntry) (bool, error) {
+	entry.CreatedAt = time.Now()
+	result, err := xr.db.Collection(xr.colName).InsertOne(context.TODO(), entry)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	entry.ID = result.InsertedID.(primitive.ObjectID)
+	return true, nil
+}
+
+func (xr *xpRepository) DeleteXPEntry(entryID primitive.ObjectID) error {
+	_, err := xr.db.Collection(xr.colName).DeleteOne(context.TODO(), bson.D{{Key: "_id", Value: entryID}})
+	return err
+}
+
+// StreamXPLedgerOfUser calls fn with every entry of the user, oldest first.
+func (xr *xpRepository) StreamXPLedgerOfUser(userID *string, fn func(entry *entity.XPEntry) error) error {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return err
+	}
+	option := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := xr.db.Collection(xr.colName).Find(context.TODO(), bson.D{{Key: "user_id", Value: uID}}, option)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.TODO())
+	for cursor.Next(context.TODO()) {
+		var entry entity.XPEntry
+		if err = cursor.Decode(&entry); err != nil {
+			return err
+		}
+		if err = fn(&entry); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// GetXPTotals returns the lifetime XP of every user with ledger entries.
+func (xr *xpRepository) GetXPTotals() (*[]entity.XPTotal, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$user_id"},
+			{Key: "total", Value: bson.D{{Key: "$sum", Value: "$amount"}}},
+		}}},
+	}
+	cur, err := xr.db.Collection(xr.colName).Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	totals := []entity.XPTotal{}
+	if err = cur.All(context.TODO(), &totals); err != nil {
+		return nil, err
+	}
+	return &totals, nil
+}
+
+// GetXPLedger returns the user's ledger, newest first.
+func (xr *xpRepository) GetXPLedger(userID *string, limit int, cursor string) (*[]entity.XPEntry, string, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, "", err
+	}
+	filter := bson.D{{Key: "user_id", Value: uID}}
+	if cursor != "" {
+		c, err := pagination.DecodeCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$lt", Value: c.ID}}})
+	}
+
+	limit = pagination.NormalizeLimit(limit)
+	option := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetLimit(int64(limit + 1))
+	cur, err := xr.db.Collection(xr.colName).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, "", err
+	}
+	entries := []entity.XPEntry{}
+	if err = cur.All(context.TODO(), &entries); err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(entries) > limit {
+		entries = entries[:limit]
+		nextCursor = pagination.EncodeCursor(nil, entries[limit-1].ID)
+	}
+	return &entries, nextCursor, nil
+}
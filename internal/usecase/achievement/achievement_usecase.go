@@ -0,0 +1,145 @@
+package achievement
+
+import (
+	"errors"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type achievementUsecase struct {
+	achievementRepository repository.AchievementRepository
+	reviewRepository      repository.ReviewRepository
+	deckRepository        repository.DeckRepository
+	userRepository        repository.UserRepository
+}
+
+func NewAchievementUsecase(ar repository.AchievementRepository, rr repository.ReviewRepository, dr repository.DeckRepository, ur repository.UserRepository) usecase.AchievementUsecase {
+	return &achievementUsecase{
+		achievementRepository: ar,
+		reviewRepository:      rr,
+		deckRepository:        dr,
+		userRepository:        ur,
+	}
+}
+
+// getMetrics reads the current value of the given metrics for the user.
+func (uc *achievementUsecase) getMetrics(userID *string, metrics []string) (map[string]int, error) {
+	values := make(map[string]int)
+	for _, metric := range metrics {
+		if _, done := values[metric]; done {
+			continue
+		}
+		switch metric {
+		case entity.ACHIEVEMENT_METRIC_REVIEWS, entity.ACHIEVEMENT_METRIC_PERFECT_SESSIONS:
+			reviews, perfectSessions, err := uc.reviewRepository.GetReviewStats(userID)
+			if err != nil {
+				return nil, err
+			}
+			values[entity.ACHIEVEMENT_METRIC_REVIEWS] = reviews
+			values[entity.ACHIEVEMENT_METRIC_PERFECT_SESSIONS] = perfectSessions
+		case entity.ACHIEVEMENT_METRIC_STREAK:
+			user, err := uc.userRepository.GetByID(userID)
+			if err != nil {
+				return nil, err
+			}
+			if user == nil {
+				return nil, errors.New("user not found")
+			}
+			values[metric] = user.CurrentStreak()
+		case entity.ACHIEVEMENT_METRIC_DECKS_PUBLISHED, entity.ACHIEVEMENT_METRIC_COPIES_RECEIVED:
+			decks, copies, err := uc.deckRepository.GetAuthorDeckStats(userID)
+			if err != nil {
+				return nil, err
+			}
+			values[entity.ACHIEVEMENT_METRIC_DECKS_PUBLISHED] = decks
+			values[entity.ACHIEVEMENT_METRIC_COPIES_RECEIVED] = copies
+		}
+	}
+	return values, nil
+}
+
+// Evaluate awards the badges the event may have unlocked and returns the ones
+// unlocked just now. Badges the user already has are skipped, so evaluating
+// the same event twice awards nothing the second time.
+func (uc *achievementUsecase) Evaluate(userID *string, event string) ([]entity.AchievementProgress, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	metrics, exists := entity.AchievementEventMetrics[event]
+	if !exists {
+		return nil, errors.New("unknown achievement event")
+	}
+	values, err := uc.getMetrics(userID, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	unlocked := []entity.AchievementProgress{}
+	for _, achievement := range entity.Achievements {
+		value, exists := values[achievement.Metric]
+		if !exists || value < achievement.Threshold {
+			continue
+		}
+		badge := &entity.UserBadge{
+			UserID:     uID,
+			Key:        achievement.Key,
+			UnlockedAt: time.Now(),
+		}
+		awarded, err := uc.achievementRepository.AwardBadge(badge)
+		if err != nil {
+			return nil, err
+		}
+		if awarded {
+			unlocked = append(unlocked, entity.AchievementProgress{
+				Achievement: achievement,
+				Progress:    value,
+				Unlocked:    true,
+				UnlockedAt:  &badge.UnlockedAt,
+			})
+		}
+	}
+	return unlocked, nil
+}
+
+// GetAchievements returns every achievement with the user's progress toward
+// it, unlocked ones included.
+func (uc *achievementUsecase) GetAchievements(userID *string) (*[]entity.AchievementProgress, error) {
+	badges, err := uc.achievementRepository.GetBadgesOfUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	badgeMap := make(map[string]*entity.UserBadge)
+	for i := range *badges {
+		badgeMap[(*badges)[i].Key] = &(*badges)[i]
+	}
+	metrics := make([]string, 0, len(entity.Achievements))
+	for _, achievement := range entity.Achievements {
+		metrics = append(metrics, achievement.Metric)
+	}
+	values, err := uc.getMetrics(userID, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make([]entity.AchievementProgress, 0, len(entity.Achievements))
+	for _, achievement := range entity.Achievements {
+		item := entity.AchievementProgress{
+			Achievement: achievement,
+			Progress:    values[achievement.Metric],
+		}
+		if badge, exists := badgeMap[achievement.Key]; exists {
+			item.Unlocked = true
+			item.UnlockedAt = &badge.UnlockedAt
+		}
+		if item.Progress > achievement.Threshold || item.Unlocked {
+			item.Progress = achievement.Threshold
+		}
+		progress = append(progress, item)
+	}
+	return &progress, nil
+}
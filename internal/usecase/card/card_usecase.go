@@ -1,6 +1,7 @@
 package card
 
 import (
+	"errors"
 	"vietcard-backend/internal/delivery/http/request"
 	"vietcard-backend/internal/domain/entity"
 	"vietcard-backend/internal/domain/interface/repository"
@@ -11,19 +12,32 @@ import (
 )
 
 type cardUsecase struct {
-	cardRepository repository.CardRepository
-	deckRepository repository.DeckRepository
+	cardRepository   repository.CardRepository
+	deckRepository   repository.DeckRepository
+	memberRepository repository.MemberRepository
 }
 
-func NewCardUsecase(cr repository.CardRepository, dr repository.DeckRepository) usecase.CardUsecase {
+func NewCardUsecase(cr repository.CardRepository, dr repository.DeckRepository, mr repository.MemberRepository) usecase.CardUsecase {
 	return &cardUsecase{
-		cardRepository: cr,
-		deckRepository: dr,
+		cardRepository:   cr,
+		deckRepository:   dr,
+		memberRepository: mr,
 	}
 }
 
 func (uc *cardUsecase) CreateCard(card *entity.Card) (*entity.Card, error) {
-	return uc.cardRepository.CreateCard(card)
+	if card.Region != nil {
+		card.Region.Normalize()
+	}
+	card, err := uc.cardRepository.CreateCard(card)
+	if err != nil {
+		return nil, err
+	}
+	deckID := card.DeckID.Hex()
+	if err = uc.deckRepository.IncrementTotalCards(&deckID, 1); err != nil {
+		return nil, err
+	}
+	return card, nil
 }
 
 func (uc *cardUsecase) GetCardByID(id *string) (*entity.Card, error) {
@@ -47,8 +61,55 @@ func (uc *cardUsecase) GetCardsByDeck(deckID *string) (*[]entity.Card, error) {
 	return cards, nil
 }
 
+// UpdateCard updates the card. A card moved to another deck goes to that
+// deck's owner, and the progress of the old deck's members on it is dropped.
 func (uc *cardUsecase) UpdateCard(cardID *string, req *request.UpdateCardRequest) (*entity.Card, error) {
-	return uc.cardRepository.UpdateCard(cardID, req)
+	if req.Region != nil {
+		req.Region.Normalize()
+	}
+	var oldDeckID *primitive.ObjectID
+	if req.DeckID != nil {
+		card, err := uc.cardRepository.GetCardByID(cardID)
+		if err != nil {
+			return nil, err
+		}
+		if card == nil {
+			return nil, errors.New("card not found")
+		}
+		if card.DeckID != *req.DeckID {
+			targetDeckID := req.DeckID.Hex()
+			deck, err := uc.deckRepository.GetDeckByID(&targetDeckID)
+			if err != nil {
+				return nil, err
+			}
+			if deck == nil {
+				return nil, errors.New("deck not found")
+			}
+			req.UserID = &deck.UserID
+			oldDeckID = &card.DeckID
+		}
+	}
+	card, err := uc.cardRepository.UpdateCard(cardID, req)
+	if err != nil {
+		return nil, err
+	}
+	deckID := card.DeckID.Hex()
+	if oldDeckID != nil {
+		oldID := oldDeckID.Hex()
+		if err = uc.deckRepository.IncrementTotalCards(&oldID, -1); err != nil {
+			return nil, err
+		}
+		if err = uc.deckRepository.IncrementTotalCards(&deckID, 1); err != nil {
+			return nil, err
+		}
+		if err = uc.memberRepository.DeleteCardProgress(card.ID); err != nil {
+			return nil, err
+		}
+	}
+	if err = uc.deckRepository.TouchDeck(&deckID); err != nil {
+		return nil, err
+	}
+	return card, nil
 }
 
 func (uc *cardUsecase) UpdateCardReview(card *entity.Card) error {
@@ -60,19 +121,38 @@ func (uc *cardUsecase) CopyCardToDeck(cardID *string, deckID *string) (*entity.C
 	if err != nil {
 		return nil, err
 	}
-	card.ID = primitive.NilObjectID
-	card.DeckID, err = primitive.ObjectIDFromHex(*deckID)
-	card.SetDefault()
+	deck, err := uc.deckRepository.GetDeckByID(deckID)
 	if err != nil {
 		return nil, err
 	}
+	card.ID = primitive.NilObjectID
+	card.UserID = deck.UserID
+	card.DeckID = deck.ID
+	card.SetDefault()
 	card, err = uc.cardRepository.CreateCard(card)
 	if err != nil {
 		return nil, err
 	}
+	if err = uc.deckRepository.IncrementTotalCards(deckID, 1); err != nil {
+		return nil, err
+	}
 	return card, nil
 }
 
 func (uc *cardUsecase) DeleteCard(cardID *string) error {
-	return uc.cardRepository.DeleteCard(cardID)
+	card, err := uc.cardRepository.GetCardByID(cardID)
+	if err != nil {
+		return err
+	}
+	if card == nil {
+		return nil
+	}
+	if err = uc.cardRepository.DeleteCard(cardID); err != nil {
+		return err
+	}
+	if err = uc.memberRepository.DeleteCardProgress(card.ID); err != nil {
+		return err
+	}
+	deckID := card.DeckID.Hex()
+	return uc.deckRepository.IncrementTotalCards(&deckID, -1)
 }
@@ -0,0 +1,206 @@
+package comment
+
+import (
+	"errors"
+	"strings"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+)
+
+type commentUsecase struct {
+	commentRepository repository.CommentRepository
+	deckRepository    repository.DeckRepository
+	cardRepository    repository.CardRepository
+	userRepository    repository.UserRepository
+	memberRepository  repository.MemberRepository
+}
+
+func NewCommentUsecase(cmr repository.CommentRepository, dr repository.DeckRepository, cr repository.CardRepository, ur repository.UserRepository, mr repository.MemberRepository) usecase.CommentUsecase {
+	return &commentUsecase{
+		commentRepository: cmr,
+		deckRepository:    dr,
+		cardRepository:    cr,
+		userRepository:    ur,
+		memberRepository:  mr,
+	}
+}
+
+// getVisibleDeck returns the deck if it exists and the user may read it, i.e.
+// it is public, owned by the user or shared with them. userID is empty for
+// anonymous readers.
+func (uc *commentUsecase) getVisibleDeck(userID string, deckID *string) (*entity.Deck, error) {
+	deck, err := uc.deckRepository.GetDeckByID(deckID)
+	if err != nil {
+		return nil, err
+	}
+	if deck == nil {
+		return nil, errors.New("deck not found")
+	}
+	if deck.IsPublic || deck.UserID.Hex() == userID {
+		return deck, nil
+	}
+	if userID != "" {
+		member, err := uc.memberRepository.GetMember(deckID, &userID)
+		if err != nil {
+			return nil, err
+		}
+		if member != nil {
+			return deck, nil
+		}
+	}
+	return nil, errors.New("deck not found")
+}
+
+func (uc *commentUsecase) CreateComment(userID *string, req *request.CreateCommentRequest) (*entity.Comment, error) {
+	content := strings.TrimSpace(req.Content)
+	if content == "" {
+		return nil, errors.New("comment can't be empty")
+	}
+	deckID := req.DeckID.Hex()
+	deck, err := uc.getVisibleDeck(*userID, &deckID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := uc.userRepository.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	comment := &entity.Comment{
+		DeckID:   deck.ID,
+		CardID:   req.CardID,
+		ParentID: req.ParentID,
+		UserID:   user.ID,
+		UserName: user.Name,
+		Content:  content,
+	}
+	if req.CardID != nil {
+		cardID := req.CardID.Hex()
+		card, err := uc.cardRepository.GetCardByID(&cardID)
+		if err != nil {
+			return nil, err
+		}
+		if card == nil || card.DeckID != deck.ID {
+			return nil, errors.New("card not found in deck")
+		}
+	}
+	if req.ParentID != nil {
+		parentID := req.ParentID.Hex()
+		parent, err := uc.commentRepository.GetCommentByID(&parentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil || parent.DeckID != deck.ID {
+			return nil, errors.New("parent comment not found in deck")
+		}
+		// Replies always stay on the same card as the comment they answer.
+		comment.CardID = parent.CardID
+	}
+
+	comment, err = uc.commentRepository.CreateComment(comment)
+	if err != nil {
+		return nil, err
+	}
+	if req.ParentID != nil {
+		parentID := req.ParentID.Hex()
+		if err = uc.commentRepository.IncrementReplyCount(&parentID, 1); err != nil {
+			return nil, err
+		}
+	}
+	if err = uc.deckRepository.IncrementCommentCount(&deckID, 1); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (uc *commentUsecase) UpdateComment(userID *string, req *request.UpdateCommentRequest) (*entity.Comment, error) {
+	content := strings.TrimSpace(req.Content)
+	if content == "" {
+		return nil, errors.New("comment can't be empty")
+	}
+	commentID := req.CommentID.Hex()
+	comment, err := uc.commentRepository.GetCommentByID(&commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil || comment.IsDeleted {
+		return nil, errors.New("comment not found")
+	}
+	if comment.UserID.Hex() != *userID {
+		return nil, errors.New("only the author can edit this comment")
+	}
+	return uc.commentRepository.UpdateCommentContent(&commentID, &content)
+}
+
+func (uc *commentUsecase) DeleteComment(userID *string, commentID *string) (*entity.Comment, error) {
+	comment, err := uc.commentRepository.GetCommentByID(commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil || comment.IsDeleted {
+		return nil, errors.New("comment not found")
+	}
+	if comment.UserID.Hex() != *userID {
+		deckID := comment.DeckID.Hex()
+		deck, err := uc.deckRepository.GetDeckByID(&deckID)
+		if err != nil {
+			return nil, err
+		}
+		if deck == nil || deck.UserID.Hex() != *userID {
+			user, err := uc.userRepository.GetByID(userID)
+			if err != nil {
+				return nil, err
+			}
+			if user == nil || !user.IsAdmin {
+				return nil, errors.New("you don't have permission to delete this comment")
+			}
+		}
+	}
+
+	deleted, err := uc.commentRepository.DeleteComment(commentID)
+	if err != nil {
+		return nil, err
+	}
+	if deleted == nil {
+		return nil, errors.New("comment not found")
+	}
+	// The parent's reply count and the deck's comment count go down together,
+	// so the reply count is put back if the deck can't be updated.
+	if deleted.ParentID != nil {
+		parentID := deleted.ParentID.Hex()
+		if err = uc.commentRepository.IncrementReplyCount(&parentID, -1); err != nil {
+			return nil, err
+		}
+	}
+	deckID := deleted.DeckID.Hex()
+	if err = uc.deckRepository.IncrementCommentCount(&deckID, -1); err != nil {
+		if deleted.ParentID != nil {
+			parentID := deleted.ParentID.Hex()
+			_ = uc.commentRepository.IncrementReplyCount(&parentID, 1)
+		}
+		return nil, err
+	}
+	return deleted, nil
+}
+
+func (uc *commentUsecase) GetComments(viewerUserID string, req *request.GetCommentsRequest) (*[]entity.Comment, string, error) {
+	if _, err := uc.getVisibleDeck(viewerUserID, &req.DeckID); err != nil {
+		return nil, "", err
+	}
+	comments, nextCursor, err := uc.commentRepository.GetComments(req)
+	if err != nil {
+		return nil, "", err
+	}
+	// Hidden comments keep their place in the thread, like deleted ones.
+	for i := range *comments {
+		if (*comments)[i].IsHidden {
+			(*comments)[i].Content = ""
+		}
+	}
+	return comments, nextCursor, nil
+}
@@ -25,6 +25,10 @@ func NewDeckUsecase(dr repository.DeckRepository, cr repository.CardRepository,
 }
 
 func (uc *deckUsecase) CreateDeck(deck *entity.Deck) (*entity.Deck, error) {
+	deck.Tags = helpers.NormalizeTags(deck.Tags)
+	if deck.Region != nil {
+		deck.Region.Normalize()
+	}
 	deck, err := uc.deckRepository.CreateDeck(deck)
 	if err != nil {
 		return nil, err
@@ -37,9 +41,20 @@ func (uc *deckUsecase) GetDeckByID(id *string) (*entity.Deck, error) {
 }
 
 func (uc *deckUsecase) UpdateDeck(deckID *string, req *request.UpdateDeckRequest) (*entity.Deck, error) {
+	if req.Tags != nil {
+		tags := helpers.NormalizeTags(*req.Tags)
+		req.Tags = &tags
+	}
+	if req.Region != nil {
+		req.Region.Normalize()
+	}
 	return uc.deckRepository.UpdateDeck(deckID, req)
 }
 
+func (uc *deckUsecase) GetPublicDeckCatalog(req *request.DeckCatalogRequest) (*[]entity.DeckSummary, string, error) {
+	return uc.deckRepository.GetPublicDecks(req)
+}
+
 func (uc *deckUsecase) GetReviewCardsAllDecksOfUser(userID *string) (*[]entity.DeckWithReviewCards, error) {
 	rawDeckWithCards, err := uc.deckRepository.GetCardsAllDecksOfUser(userID)
 	if err != nil {
@@ -71,10 +86,13 @@ func (uc *deckUsecase) CopyDeck(userID *string, deckID *string) (*entity.DeckWit
 	if err != nil {
 		return nil, nil, err
 	}
-	cards, err := uc.cardRepository.GetCardsByDeck(deckID)
+	allCards, err := uc.cardRepository.GetCardsByDeck(deckID)
 	if err != nil {
 		return nil, nil, err
 	}
+	// Cards hidden by moderation are not copied.
+	cards := entity.VisibleCards(allCards)
+	sourceUserID := deck.UserID.Hex()
 	deck.ID = primitive.NilObjectID
 	deck.UserID = user.ID
 	deck.IsPublic = false
@@ -90,11 +108,21 @@ func (uc *deckUsecase) CopyDeck(userID *string, deckID *string) (*entity.DeckWit
 		(*cards)[i].DeckID = deck.ID
 		(*cards)[i].ID = primitive.NilObjectID
 	}
-	err = uc.cardRepository.CreateManyCards(cards)
-	if err != nil {
-		return nil, nil, err
+	if len(*cards) > 0 {
+		err = uc.cardRepository.CreateManyCards(cards)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 	dID := deck.ID.Hex()
+	if err = uc.deckRepository.IncrementTotalCards(&dID, len(*cards)); err != nil {
+		return nil, nil, err
+	}
+	if deck.UserID.Hex() != sourceUserID {
+		if err = uc.deckRepository.IncrementCopies(deckID); err != nil {
+			return nil, nil, err
+		}
+	}
 	rawDeckWithCards, err := uc.deckRepository.GetDeckWithCards(&dID)
 	if err != nil {
 		return nil, nil, err
@@ -114,8 +142,11 @@ func (uc *deckUsecase) CopyDeck(userID *string, deckID *string) (*entity.DeckWit
 	return rawDeckWithCards, &deckWithReviewCards, nil
 }
 
+// GetDecksWithCards only loads the user's own decks. Public decks of other
+// users are browsed through the paginated catalog and shared decks through
+// the member endpoints.
 func (uc *deckUsecase) GetDecksWithCards(userID *string) (*[]entity.DeckWithCards, *[]entity.DeckWithCards, *[]entity.DeckWithReviewCards, error) {
-	rawDeckWithCards, err := uc.deckRepository.GetCardsAllDecks(userID)
+	rawDeckWithCards, err := uc.deckRepository.GetCardsAllDecksOfUser(userID)
 	if err != nil {
 		return nil, nil, nil, err
 	}
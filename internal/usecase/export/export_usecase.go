@@ -0,0 +1,598 @@
+package export
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+	"vietcard-backend/pkg/netutil"
+	"vietcard-backend/pkg/randutil"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DEFAULT_EXPIRY_HOUR = 24
+	MAX_MEDIA_BYTES     = 10 << 20
+	MAX_RUNNING_EXPORTS = 2
+	MAX_MEDIA_REDIRECTS = 3
+	STALE_JOB_AFTER     = time.Hour
+)
+
+type exportUsecase struct {
+	exportRepository repository.ExportRepository
+	userRepository   repository.UserRepository
+	deckRepository   repository.DeckRepository
+	cardRepository   repository.CardRepository
+	reviewRepository repository.ReviewRepository
+	streakRepository repository.StreakRepository
+	xpRepository     repository.XPRepository
+	exportDir        string
+	expiry           time.Duration
+	httpClient       *http.Client
+	mediaHosts       map[string]bool
+	slots            chan struct{}
+}
+
+// NewExportUsecase takes the comma separated hosts media may be downloaded
+// from. If there are none, any public host is allowed.
+func NewExportUsecase(er repository.ExportRepository, ur repository.UserRepository, dr repository.DeckRepository, cr repository.CardRepository, rr repository.ReviewRepository, sr repository.StreakRepository, xr repository.XPRepository, exportDir string, expiryHour int, mediaHosts string) usecase.ExportUsecase {
+	if exportDir == "" {
+		exportDir = filepath.Join(os.TempDir(), "vietcard-exports")
+	}
+	if expiryHour <= 0 {
+		expiryHour = DEFAULT_EXPIRY_HOUR
+	}
+	uc := &exportUsecase{
+		exportRepository: er,
+		userRepository:   ur,
+		deckRepository:   dr,
+		cardRepository:   cr,
+		reviewRepository: rr,
+		streakRepository: sr,
+		xpRepository:     xr,
+		exportDir:        exportDir,
+		expiry:           time.Duration(expiryHour) * time.Hour,
+		httpClient:       netutil.NewPublicHTTPClient(15 * time.Second),
+		mediaHosts:       make(map[string]bool),
+		slots:            make(chan struct{}, MAX_RUNNING_EXPORTS),
+	}
+	for _, host := range strings.Split(mediaHosts, ",") {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			uc.mediaHosts[host] = true
+		}
+	}
+	uc.httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) > MAX_MEDIA_REDIRECTS {
+			return errors.New("too many redirects")
+		}
+		return uc.checkMediaURL(req.URL)
+	}
+	return uc
+}
+
+// exportedUser hides the password hash of the embedded user.
+type exportedUser struct {
+	*entity.User
+	HashedPassword string `json:"hashed_password,omitempty"`
+}
+
+type reviewState struct {
+	CardID     primitive.ObjectID `json:"card_id"`
+	DeckID     primitive.ObjectID `json:"deck_id"`
+	LastReview time.Time          `json:"last_review"`
+	NextReview time.Time          `json:"next_review"`
+	NumReviews int                `json:"num_reviews"`
+	Sm2N       int                `json:"sm2_n"`
+	Sm2EF      float64            `json:"sm2_ef"`
+	Sm2I       int                `json:"sm2_i"`
+}
+
+type mediaEntry struct {
+	URL   string `json:"url"`
+	File  string `json:"file,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type ndjsonLine struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+func (uc *exportUsecase) CreateExportJob(userID *string) (*entity.ExportJob, error) {
+	uc.purgeExpiredJobs()
+
+	latest, err := uc.exportRepository.GetLatestExportJobOfUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && latest.IsActive() {
+		if time.Since(latest.CreatedAt) < STALE_JOB_AFTER {
+			return latest, nil
+		}
+		fromStatus := latest.Status
+		latest.Status = entity.EXPORT_STATUS_FAILED
+		latest.Error = "export job timed out"
+		if _, err = uc.exportRepository.UpdateExportJob(latest, fromStatus); err != nil {
+			return nil, err
+		}
+	}
+
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	job, err := uc.exportRepository.CreateExportJob(&entity.ExportJob{UserID: uID})
+	if err != nil {
+		return nil, err
+	}
+	go uc.runExportJob(*job)
+	return job, nil
+}
+
+func (uc *exportUsecase) GetExportJob(userID *string, jobID *string) (*entity.ExportJob, error) {
+	job, err := uc.exportRepository.GetExportJobByID(jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil || job.UserID.Hex() != *userID {
+		return nil, nil
+	}
+	if job.IsExpired() {
+		job.Status = entity.EXPORT_STATUS_EXPIRED
+	}
+	return job, nil
+}
+
+func (uc *exportUsecase) GetExportJobByToken(token *string) (*entity.ExportJob, error) {
+	job, err := uc.exportRepository.GetExportJobByToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil || job.Status != entity.EXPORT_STATUS_READY {
+		return nil, nil
+	}
+	if job.IsExpired() {
+		uc.expireJob(job)
+		return nil, errors.New("export download link has expired")
+	}
+	return job, nil
+}
+
+func (uc *exportUsecase) StreamExport(userID *string, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	write := func(lineType string, data interface{}) error {
+		if err := enc.Encode(ndjsonLine{Type: lineType, Data: data}); err != nil {
+			return err
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		return nil
+	}
+
+	user, err := uc.userRepository.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+	if err = write("profile", exportedUser{User: user}); err != nil {
+		return err
+	}
+
+	deckIDs := []primitive.ObjectID{}
+	err = uc.deckRepository.StreamDecksOfUser(userID, func(deck *entity.Deck) error {
+		deckIDs = append(deckIDs, deck.ID)
+		return write("deck", deck)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = uc.cardRepository.StreamCardsByDecks(deckIDs, func(card *entity.Card) error {
+		if err := write("card", card); err != nil {
+			return err
+		}
+		if card.NumReviews == 0 {
+			return nil
+		}
+		return write("review", newReviewState(card))
+	})
+	if err != nil {
+		return err
+	}
+
+	err = uc.reviewRepository.StreamReviewLogsOfUser(userID, func(log *entity.ReviewLog) error {
+		return write("review_log", log)
+	})
+	if err != nil {
+		return err
+	}
+
+	streakDays, err := uc.streakRepository.GetStreakDays(userID, time.Time{})
+	if err != nil {
+		return err
+	}
+	for i := range *streakDays {
+		if err = write("streak_day", &(*streakDays)[i]); err != nil {
+			return err
+		}
+	}
+
+	err = uc.xpRepository.StreamXPLedgerOfUser(userID, func(entry *entity.XPEntry) error {
+		return write("xp_entry", entry)
+	})
+	if err != nil {
+		return err
+	}
+
+	return write("end", nil)
+}
+
+func (uc *exportUsecase) runExportJob(job entity.ExportJob) {
+	uc.slots <- struct{}{}
+	defer func() { <-uc.slots }()
+
+	// The job may have been timed out while it waited for a slot.
+	job.Status = entity.EXPORT_STATUS_RUNNING
+	started, err := uc.exportRepository.UpdateExportJob(&job, entity.EXPORT_STATUS_PENDING)
+	if err != nil {
+		log.Println("export: ", err)
+		return
+	}
+	if !started {
+		return
+	}
+
+	filePath, size, err := uc.buildArchive(&job)
+	if err != nil {
+		uc.failJob(&job, err)
+		return
+	}
+
+	token, err := randutil.RandomToken(32)
+	if err != nil {
+		os.Remove(filePath)
+		uc.failJob(&job, err)
+		return
+	}
+	job.Status = entity.EXPORT_STATUS_READY
+	job.Token = token
+	job.FilePath = filePath
+	job.Size = size
+	job.CompletedAt = time.Now()
+	job.ExpiresAt = job.CompletedAt.Add(uc.expiry)
+	ready, err := uc.exportRepository.UpdateExportJob(&job, entity.EXPORT_STATUS_RUNNING)
+	if err != nil {
+		log.Println("export: ", err)
+	}
+	if err != nil || !ready {
+		os.Remove(filePath)
+	}
+}
+
+func (uc *exportUsecase) failJob(job *entity.ExportJob, cause error) {
+	job.Status = entity.EXPORT_STATUS_FAILED
+	job.Error = cause.Error()
+	if _, err := uc.exportRepository.UpdateExportJob(job, entity.EXPORT_STATUS_RUNNING); err != nil {
+		log.Println("export: ", err)
+	}
+}
+
+func (uc *exportUsecase) buildArchive(job *entity.ExportJob) (string, int64, error) {
+	if err := os.MkdirAll(uc.exportDir, 0o700); err != nil {
+		return "", 0, err
+	}
+	filePath := filepath.Join(uc.exportDir, job.ID.Hex()+".zip")
+	tmpPath := filePath + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(tmpPath)
+
+	zw := zip.NewWriter(f)
+	if err = uc.writeArchive(zw, job); err != nil {
+		zw.Close()
+		f.Close()
+		return "", 0, err
+	}
+	if err = zw.Close(); err != nil {
+		f.Close()
+		return "", 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return "", 0, err
+	}
+	if err = f.Close(); err != nil {
+		return "", 0, err
+	}
+	if err = os.Rename(tmpPath, filePath); err != nil {
+		return "", 0, err
+	}
+	return filePath, info.Size(), nil
+}
+
+func (uc *exportUsecase) writeArchive(zw *zip.Writer, job *entity.ExportJob) error {
+	userID := job.UserID.Hex()
+	user, err := uc.userRepository.GetByID(&userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+	if err = writeJSONFile(zw, "profile.json", exportedUser{User: user}); err != nil {
+		return err
+	}
+
+	mediaURLs := []string{}
+	addMedia := func(mediaURL string) {
+		if mediaURL != "" {
+			mediaURLs = append(mediaURLs, mediaURL)
+		}
+	}
+
+	deckIDs := []primitive.ObjectID{}
+	decks, err := newJSONArrayFile(zw, "decks.json")
+	if err != nil {
+		return err
+	}
+	err = uc.deckRepository.StreamDecksOfUser(&userID, func(deck *entity.Deck) error {
+		deckIDs = append(deckIDs, deck.ID)
+		addMedia(deck.DescriptionImageURL)
+		return decks.Add(deck)
+	})
+	if err != nil {
+		return err
+	}
+	if err = decks.Close(); err != nil {
+		return err
+	}
+
+	reviews := []reviewState{}
+	cards, err := newJSONArrayFile(zw, "cards.json")
+	if err != nil {
+		return err
+	}
+	err = uc.cardRepository.StreamCardsByDecks(deckIDs, func(card *entity.Card) error {
+		addMedia(card.QuestionImgURL)
+		if card.NumReviews > 0 {
+			reviews = append(reviews, *newReviewState(card))
+		}
+		return cards.Add(card)
+	})
+	if err != nil {
+		return err
+	}
+	if err = cards.Close(); err != nil {
+		return err
+	}
+	if err = writeJSONFile(zw, "review_history.json", reviews); err != nil {
+		return err
+	}
+
+	reviewLogs, err := newJSONArrayFile(zw, "review_logs.json")
+	if err != nil {
+		return err
+	}
+	err = uc.reviewRepository.StreamReviewLogsOfUser(&userID, func(log *entity.ReviewLog) error {
+		return reviewLogs.Add(log)
+	})
+	if err != nil {
+		return err
+	}
+	if err = reviewLogs.Close(); err != nil {
+		return err
+	}
+
+	streakDays, err := uc.streakRepository.GetStreakDays(&userID, time.Time{})
+	if err != nil {
+		return err
+	}
+	if err = writeJSONFile(zw, "streak_days.json", streakDays); err != nil {
+		return err
+	}
+
+	xpLedger, err := newJSONArrayFile(zw, "xp_ledger.json")
+	if err != nil {
+		return err
+	}
+	err = uc.xpRepository.StreamXPLedgerOfUser(&userID, func(entry *entity.XPEntry) error {
+		return xpLedger.Add(entry)
+	})
+	if err != nil {
+		return err
+	}
+	if err = xpLedger.Close(); err != nil {
+		return err
+	}
+
+	return writeJSONFile(zw, "media.json", uc.writeMedia(zw, mediaURLs))
+}
+
+// writeMedia downloads every distinct media URL into media/ and reports
+// per-URL failures in the manifest instead of failing the whole export.
+func (uc *exportUsecase) writeMedia(zw *zip.Writer, urls []string) []mediaEntry {
+	seen := make(map[string]bool)
+	entries := []mediaEntry{}
+	for _, mediaURL := range urls {
+		if seen[mediaURL] {
+			continue
+		}
+		seen[mediaURL] = true
+		entry := mediaEntry{URL: mediaURL}
+		file, err := uc.downloadMedia(zw, mediaURL)
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.File = file
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// checkMediaURL only lets http(s) URLs of the allowed media hosts through.
+// The HTTP client itself refuses non-public addresses.
+func (uc *exportUsecase) checkMediaURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("unsupported media URL")
+	}
+	if len(uc.mediaHosts) > 0 && !uc.mediaHosts[strings.ToLower(u.Hostname())] {
+		return errors.New("media host is not allowed")
+	}
+	return nil
+}
+
+// downloadMedia reads the whole file before adding it to the archive, so a
+// failed download leaves nothing behind in the zip.
+func (uc *exportUsecase) downloadMedia(zw *zip.Writer, mediaURL string) (string, error) {
+	u, err := url.Parse(mediaURL)
+	if err != nil {
+		return "", errors.New("unsupported media URL")
+	}
+	if err = uc.checkMediaURL(u); err != nil {
+		return "", err
+	}
+	resp, err := uc.httpClient.Get(u.String())
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("media download failed with status %d", resp.StatusCode)
+	}
+	if resp.ContentLength > MAX_MEDIA_BYTES {
+		return "", errors.New("media file is too large")
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MAX_MEDIA_BYTES+1))
+	if err != nil {
+		return "", err
+	}
+	if len(data) > MAX_MEDIA_BYTES {
+		return "", errors.New("media file is too large")
+	}
+
+	sum := sha1.Sum([]byte(mediaURL))
+	ext := path.Ext(path.Base(u.Path))
+	if len(ext) > 5 {
+		ext = ""
+	}
+	name := "media/" + hex.EncodeToString(sum[:]) + ext
+	w, err := zw.Create(name)
+	if err != nil {
+		return "", err
+	}
+	if _, err = w.Write(data); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+func (uc *exportUsecase) purgeExpiredJobs() {
+	jobs, err := uc.exportRepository.GetExpiredExportJobs()
+	if err != nil {
+		log.Println("export: ", err)
+		return
+	}
+	for i := range *jobs {
+		uc.expireJob(&(*jobs)[i])
+	}
+}
+
+func (uc *exportUsecase) expireJob(job *entity.ExportJob) {
+	if job.FilePath != "" {
+		if err := os.Remove(job.FilePath); err != nil && !os.IsNotExist(err) {
+			log.Println("export: ", err)
+			return
+		}
+	}
+	job.Status = entity.EXPORT_STATUS_EXPIRED
+	job.FilePath = ""
+	job.Token = ""
+	if _, err := uc.exportRepository.UpdateExportJob(job, entity.EXPORT_STATUS_READY); err != nil {
+		log.Println("export: ", err)
+	}
+}
+
+func newReviewState(card *entity.Card) *reviewState {
+	return &reviewState{
+		CardID:     card.ID,
+		DeckID:     card.DeckID,
+		LastReview: card.LastReview,
+		NextReview: card.NextReview,
+		NumReviews: card.NumReviews,
+		Sm2N:       card.Sm2N,
+		Sm2EF:      card.Sm2EF,
+		Sm2I:       card.Sm2I,
+	}
+}
+
+func writeJSONFile(zw *zip.Writer, name string, v interface{}) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// jsonArrayFile writes a JSON array element by element so large accounts
+// never have to be held in memory at once.
+type jsonArrayFile struct {
+	w     io.Writer
+	count int
+}
+
+func newJSONArrayFile(zw *zip.Writer, name string) (*jsonArrayFile, error) {
+	w, err := zw.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.WriteString(w, "["); err != nil {
+		return nil, err
+	}
+	return &jsonArrayFile{w: w}, nil
+}
+
+func (f *jsonArrayFile) Add(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if f.count > 0 {
+		if _, err = io.WriteString(f.w, ",\n"); err != nil {
+			return err
+		}
+	} else if _, err = io.WriteString(f.w, "\n"); err != nil {
+		return err
+	}
+	f.count++
+	_, err = f.w.Write(b)
+	return err
+}
+
+func (f *jsonArrayFile) Close() error {
+	_, err := io.WriteString(f.w, "\n]\n")
+	return err
+}
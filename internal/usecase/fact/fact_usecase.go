@@ -0,0 +1,197 @@
+package fact
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+	"vietcard-backend/pkg/timeutil"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type factUsecase struct {
+	factRepository repository.FactRepository
+	deckRepository repository.DeckRepository
+}
+
+func NewFactUsecase(fr repository.FactRepository, dr repository.DeckRepository) usecase.FactUsecase {
+	return &factUsecase{
+		factRepository: fr,
+		deckRepository: dr,
+	}
+}
+
+// CreateFact returns the existing fact and false if a fact with the same
+// content already exists in the locale.
+func (uc *factUsecase) CreateFact(req *request.CreateFactRequest) (*entity.Fact, bool, error) {
+	fact := &entity.Fact{
+		Content:   req.Content,
+		Category:  req.Category,
+		Source:    req.Source,
+		SourceURL: req.SourceURL,
+		DeckID:    req.DeckID,
+		Locale:    req.Locale,
+	}
+	fact.Normalize()
+	if err := uc.validateFact(fact); err != nil {
+		return nil, false, err
+	}
+	created, err := uc.factRepository.CreateFact(fact)
+	if err != nil {
+		return nil, false, err
+	}
+	if !created {
+		return uc.duplicateOf(fact)
+	}
+	return fact, true, nil
+}
+
+// UpdateFact returns nil if the fact does not exist, and the existing fact
+// and false if the new content duplicates another fact.
+func (uc *factUsecase) UpdateFact(req *request.UpdateFactRequest) (*entity.Fact, bool, error) {
+	factID := req.FactID.Hex()
+	fact, err := uc.factRepository.GetFactByID(&factID)
+	if err != nil || fact == nil {
+		return nil, true, err
+	}
+	if req.Content != nil {
+		fact.Content = *req.Content
+	}
+	if req.Category != nil {
+		fact.Category = *req.Category
+	}
+	if req.Source != nil {
+		fact.Source = *req.Source
+	}
+	if req.SourceURL != nil {
+		fact.SourceURL = *req.SourceURL
+	}
+	if req.DeckID != nil {
+		fact.DeckID = req.DeckID
+	}
+	if req.RemoveDeck {
+		fact.DeckID = nil
+	}
+	if req.Locale != nil {
+		fact.Locale = *req.Locale
+	}
+	fact.Normalize()
+	if err = uc.validateFact(fact); err != nil {
+		return nil, true, err
+	}
+	updated, err := uc.factRepository.UpdateFact(fact)
+	if err != nil {
+		return nil, true, err
+	}
+	if !updated {
+		return uc.duplicateOf(fact)
+	}
+	return fact, true, nil
+}
+
+func (uc *factUsecase) DeleteFact(factID *string) (bool, error) {
+	return uc.factRepository.DeleteFact(factID)
+}
+
+func (uc *factUsecase) GetFacts(req *request.GetFactsRequest) (*[]entity.Fact, string, error) {
+	req.Locale = strings.ToLower(strings.TrimSpace(req.Locale))
+	req.Category = strings.ToLower(strings.TrimSpace(req.Category))
+	return uc.factRepository.GetFacts(req)
+}
+
+// GetFact returns a fact of the locale, random by default, or nil if there is
+// none. The daily mode returns the same fact all day and the unseen mode
+// avoids the facts the user saw recently. Facts are marked as seen when the
+// user is logged in.
+func (uc *factUsecase) GetFact(userID string, req *request.GetFactRequest) (*entity.Fact, error) {
+	locale := strings.ToLower(strings.TrimSpace(req.Locale))
+	if locale == "" {
+		locale = entity.DEFAULT_FACT_LOCALE
+	}
+	category := strings.ToLower(strings.TrimSpace(req.Category))
+
+	var (
+		fact *entity.Fact
+		err  error
+	)
+	switch req.Mode {
+	case entity.FACT_MODE_DAILY:
+		fact, err = uc.getDailyFact(locale, category)
+	case entity.FACT_MODE_UNSEEN:
+		if userID == "" {
+			return nil, errors.New("log in to get unseen facts")
+		}
+		fact, err = uc.getUnseenFact(userID, locale, category)
+	default:
+		fact, err = uc.factRepository.GetRandomFact(locale, category, nil)
+	}
+	if err != nil || fact == nil {
+		return nil, err
+	}
+
+	if userID != "" {
+		uID, err := primitive.ObjectIDFromHex(userID)
+		if err != nil {
+			return nil, err
+		}
+		if err = uc.factRepository.MarkFactSeen(uID, fact.ID); err != nil {
+			return nil, err
+		}
+	}
+	return fact, nil
+}
+
+func (uc *factUsecase) getDailyFact(locale string, category string) (*entity.Fact, error) {
+	count, err := uc.factRepository.CountFacts(locale, category)
+	if err != nil || count == 0 {
+		return nil, err
+	}
+	day := timeutil.TruncateToDay(time.Now())
+	return uc.factRepository.GetNthFact(locale, category, entity.DailyFactIndex(locale+"|"+category, day, count))
+}
+
+// getUnseenFact starts over with every fact once the user saw them all.
+func (uc *factUsecase) getUnseenFact(userID string, locale string, category string) (*entity.Fact, error) {
+	since := time.Now().AddDate(0, 0, -entity.FACT_RECENT_DAYS)
+	seen, err := uc.factRepository.GetSeenFactIDs(&userID, since)
+	if err != nil {
+		return nil, err
+	}
+	fact, err := uc.factRepository.GetRandomFact(locale, category, seen)
+	if err != nil || fact != nil || len(seen) == 0 {
+		return fact, err
+	}
+	return uc.factRepository.GetRandomFact(locale, category, nil)
+}
+
+func (uc *factUsecase) validateFact(fact *entity.Fact) error {
+	if fact.Content == "" {
+		return errors.New("content must not be empty")
+	}
+	if fact.DeckID != nil {
+		deckID := fact.DeckID.Hex()
+		deck, err := uc.deckRepository.GetDeckByID(&deckID)
+		if err != nil {
+			return err
+		}
+		if deck == nil {
+			return errors.New("deck not found")
+		}
+	}
+	return nil
+}
+
+func (uc *factUsecase) duplicateOf(fact *entity.Fact) (*entity.Fact, bool, error) {
+	existing, err := uc.factRepository.GetFactByHash(fact.ContentHash)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing == nil {
+		return nil, false, errors.New("a fact with the same content was just changed, try again")
+	}
+	return existing, false, nil
+}
@@ -0,0 +1,221 @@
+package friend
+
+import (
+	"errors"
+	"sort"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+	"vietcard-backend/pkg/timeutil"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type friendUsecase struct {
+	friendRepository repository.FriendRepository
+	userRepository   repository.UserRepository
+	reviewRepository repository.ReviewRepository
+	leagueRepository repository.LeagueRepository
+}
+
+func NewFriendUsecase(fr repository.FriendRepository, ur repository.UserRepository, rr repository.ReviewRepository, lr repository.LeagueRepository) usecase.FriendUsecase {
+	return &friendUsecase{
+		friendRepository: fr,
+		userRepository:   ur,
+		reviewRepository: rr,
+		leagueRepository: lr,
+	}
+}
+
+// SendFriendRequest sends a request to otherID, or accepts theirs if they
+// already sent one.
+func (uc *friendUsecase) SendFriendRequest(userID *string, otherID *string) (*entity.Friendship, error) {
+	if *userID == *otherID {
+		return nil, errors.New("you can't add yourself as a friend")
+	}
+	other, err := uc.userRepository.GetByID(otherID)
+	if err != nil {
+		return nil, err
+	}
+	if other == nil {
+		return nil, errors.New("user not found")
+	}
+
+	existing, err := uc.friendRepository.GetFriendship(userID, otherID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if existing.Status == entity.FRIENDSHIP_STATUS_PENDING && existing.RequesterID == other.ID {
+			return uc.AcceptFriendRequest(userID, otherID)
+		}
+		return existing, nil
+	}
+	if other.Privacy.BlockFriendRequests {
+		return nil, errors.New("this user doesn't accept friend requests")
+	}
+
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	friendship := &entity.Friendship{
+		RequesterID: uID,
+		AddresseeID: other.ID,
+		Status:      entity.FRIENDSHIP_STATUS_PENDING,
+	}
+	created, err := uc.friendRepository.CreateFriendship(friendship)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		// The other user sent a request at the same time.
+		return uc.friendRepository.GetFriendship(userID, otherID)
+	}
+	return friendship, nil
+}
+
+func (uc *friendUsecase) AcceptFriendRequest(userID *string, requesterID *string) (*entity.Friendship, error) {
+	friendship, err := uc.friendRepository.AcceptFriendship(requesterID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if friendship == nil {
+		return nil, errors.New("friend request not found")
+	}
+	return friendship, nil
+}
+
+func (uc *friendUsecase) DeclineFriendRequest(userID *string, requesterID *string) error {
+	friendship, err := uc.friendRepository.GetFriendship(userID, requesterID)
+	if err != nil {
+		return err
+	}
+	if friendship == nil || friendship.Status != entity.FRIENDSHIP_STATUS_PENDING || friendship.RequesterID.Hex() != *requesterID {
+		return errors.New("friend request not found")
+	}
+	return uc.friendRepository.DeleteFriendship(friendship.ID)
+}
+
+// RemoveFriend removes a friend, or cancels a request the user sent.
+func (uc *friendUsecase) RemoveFriend(userID *string, otherID *string) error {
+	friendship, err := uc.friendRepository.GetFriendship(userID, otherID)
+	if err != nil {
+		return err
+	}
+	if friendship == nil {
+		return errors.New("friend not found")
+	}
+	if friendship.Status == entity.FRIENDSHIP_STATUS_PENDING && friendship.RequesterID.Hex() != *userID {
+		return errors.New("decline the friend request instead")
+	}
+	return uc.friendRepository.DeleteFriendship(friendship.ID)
+}
+
+func (uc *friendUsecase) getUsersByID(ids []primitive.ObjectID) (map[primitive.ObjectID]*entity.User, error) {
+	users, err := uc.userRepository.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	usersByID := make(map[primitive.ObjectID]*entity.User, len(*users))
+	for i := range *users {
+		usersByID[(*users)[i].ID] = &(*users)[i]
+	}
+	return usersByID, nil
+}
+
+// GetFriends returns accepted friends and pending requests in both directions.
+func (uc *friendUsecase) GetFriends(userID *string) (*[]entity.Friend, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	friendships, err := uc.friendRepository.GetFriendshipsOfUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	otherIDs := make([]primitive.ObjectID, 0, len(*friendships))
+	for i := range *friendships {
+		otherIDs = append(otherIDs, (*friendships)[i].OtherUser(uID))
+	}
+	usersByID, err := uc.getUsersByID(otherIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	friends := []entity.Friend{}
+	for _, friendship := range *friendships {
+		other, ok := usersByID[friendship.OtherUser(uID)]
+		if !ok {
+			continue
+		}
+		direction := entity.FRIEND_DIRECTION_OUTGOING
+		if friendship.AddresseeID == uID {
+			direction = entity.FRIEND_DIRECTION_INCOMING
+		}
+		friends = append(friends, entity.Friend{
+			UserID:    other.ID,
+			Name:      other.Name,
+			AvatarURL: other.AvatarURL,
+			Level:     other.Level,
+			Status:    friendship.Status,
+			Direction: direction,
+			Since:     friendship.UpdatedAt,
+		})
+	}
+	return &friends, nil
+}
+
+// GetFriendsActivity returns today's study activity of friends who don't
+// hide it, best streak first.
+func (uc *friendUsecase) GetFriendsActivity(userID *string) (*[]entity.FriendActivity, error) {
+	friendIDs, err := uc.friendRepository.GetFriendIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+	activities := []entity.FriendActivity{}
+	if len(friendIDs) == 0 {
+		return &activities, nil
+	}
+	usersByID, err := uc.getUsersByID(friendIDs)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	weeklyXPs, err := uc.leagueRepository.GetWeeklyXPOfUsers(friendIDs, entity.LeagueWeek(now))
+	if err != nil {
+		return nil, err
+	}
+	weeklyXPByUser := make(map[primitive.ObjectID]int, len(*weeklyXPs))
+	for _, weeklyXP := range *weeklyXPs {
+		weeklyXPByUser[weeklyXP.UserID] = weeklyXP.XP
+	}
+	decksToday, err := uc.reviewRepository.CountDecksReviewedOn(friendIDs, timeutil.TruncateToDay(now))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, friendID := range friendIDs {
+		friend, ok := usersByID[friendID]
+		if !ok || friend.Privacy.HideActivity {
+			continue
+		}
+		activities = append(activities, entity.FriendActivity{
+			UserID:            friend.ID,
+			Name:              friend.Name,
+			AvatarURL:         friend.AvatarURL,
+			Level:             friend.Level,
+			Streak:            friend.CurrentStreak(),
+			WeeklyXP:          weeklyXPByUser[friend.ID],
+			DecksStudiedToday: decksToday[friend.ID],
+		})
+	}
+	sort.SliceStable(activities, func(i, j int) bool {
+		if activities[i].Streak != activities[j].Streak {
+			return activities[i].Streak > activities[j].Streak
+		}
+		return activities[i].WeeklyXP > activities[j].WeeklyXP
+	})
+	return &activities, nil
+}
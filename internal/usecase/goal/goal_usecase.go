@@ -0,0 +1,92 @@
+package goal
+
+import (
+	"errors"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+	"vietcard-backend/pkg/timeutil"
+)
+
+type goalUsecase struct {
+	goalRepository repository.GoalRepository
+	userRepository repository.UserRepository
+}
+
+func NewGoalUsecase(gr repository.GoalRepository, ur repository.UserRepository) usecase.GoalUsecase {
+	return &goalUsecase{
+		goalRepository: gr,
+		userRepository: ur,
+	}
+}
+
+// RecordProgress adds a review session to today's progress and marks the goal
+// as completed the first time it is met.
+func (uc *goalUsecase) RecordProgress(userID *string, xp int, reviews int) (*entity.DailyProgress, error) {
+	user, err := uc.userRepository.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	now := time.Now()
+	progress, err := uc.goalRepository.AddDailyProgress(user.ID, timeutil.TruncateToDay(now), xp, reviews, user.DailyGoal.WithDefaults())
+	if err != nil {
+		return nil, err
+	}
+	if !progress.Completed && progress.IsGoalMet() {
+		completed, err := uc.goalRepository.CompleteDailyProgress(progress.ID, now)
+		if err != nil {
+			return nil, err
+		}
+		progress.Completed = true
+		if completed {
+			progress.CompletedAt = &now
+		}
+	}
+	return progress, nil
+}
+
+// GetDailyGoal returns the user's goal, today's progress toward it and how
+// often the goal was met in the last given number of days. Today is left out
+// of the hit rate until the goal is hit.
+func (uc *goalUsecase) GetDailyGoal(userID *string, days int) (*entity.DailyGoalStats, error) {
+	user, err := uc.userRepository.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	today := timeutil.TruncateToDay(time.Now())
+	history, err := uc.goalRepository.GetDailyProgressSince(userID, today.AddDate(0, 0, 1-days))
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &entity.DailyGoalStats{
+		Goal:    user.DailyGoal.WithDefaults(),
+		History: *history,
+		Days:    days,
+	}
+	stats.Today = entity.DailyProgress{UserID: user.ID, Day: today, Goal: stats.Goal}
+	for _, progress := range *history {
+		if progress.Completed {
+			stats.DaysHit++
+		}
+		if progress.Day.Equal(today) {
+			stats.Today = progress
+		}
+	}
+	// Today only counts once the goal is hit, since it isn't over yet.
+	pastDays := days
+	if !stats.Today.Completed {
+		pastDays--
+	}
+	if pastDays > 0 {
+		stats.HitRate = float64(stats.DaysHit) / float64(pastDays)
+	}
+	return stats, nil
+}
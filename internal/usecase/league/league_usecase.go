@@ -0,0 +1,221 @@
+package league
+
+import (
+	"time"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+	"vietcard-backend/pkg/pagination"
+	"vietcard-backend/pkg/timeutil"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type leagueUsecase struct {
+	leagueRepository repository.LeagueRepository
+	userRepository   repository.UserRepository
+	friendRepository repository.FriendRepository
+}
+
+func NewLeagueUsecase(lr repository.LeagueRepository, ur repository.UserRepository, fr repository.FriendRepository) usecase.LeagueUsecase {
+	return &leagueUsecase{
+		leagueRepository: lr,
+		userRepository:   ur,
+		friendRepository: fr,
+	}
+}
+
+// RecordXP adds XP to the user's ledger of the current week, placing the user
+// in a league of their tier on their first XP of the week.
+func (uc *leagueUsecase) RecordXP(userID *string, xp int) error {
+	if xp <= 0 {
+		return nil
+	}
+	now := time.Now()
+	week := entity.LeagueWeek(now)
+	weeklyXP, err := uc.leagueRepository.GetWeeklyXP(userID, week)
+	if err != nil {
+		return err
+	}
+	if weeklyXP != nil {
+		return uc.leagueRepository.IncrementWeeklyXP(userID, week, xp)
+	}
+
+	// The user's tier depends on last week's result, so settle that league
+	// first if the weekly job hasn't got to it yet.
+	lastWeekXP, err := uc.leagueRepository.GetWeeklyXP(userID, entity.LeagueWeek(now.AddDate(0, 0, -7)))
+	if err != nil {
+		return err
+	}
+	if lastWeekXP != nil && lastWeekXP.Result == "" {
+		leagueID := lastWeekXP.LeagueID.Hex()
+		league, err := uc.leagueRepository.GetLeagueByID(&leagueID)
+		if err != nil {
+			return err
+		}
+		if league != nil && league.ClosedAt == nil {
+			if err = uc.closeLeague(league); err != nil {
+				return err
+			}
+		}
+	}
+
+	user, err := uc.userRepository.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	league, err := uc.leagueRepository.JoinLeague(week, user.LeagueTier)
+	if err != nil {
+		return err
+	}
+	created, err := uc.leagueRepository.CreateWeeklyXP(&entity.WeeklyXP{
+		UserID:   user.ID,
+		Week:     week,
+		XP:       xp,
+		LeagueID: league.ID,
+		Tier:     league.Tier,
+	})
+	if err != nil {
+		return err
+	}
+	if !created {
+		// A concurrent request created the ledger first.
+		return uc.leagueRepository.IncrementWeeklyXP(userID, week, xp)
+	}
+	return nil
+}
+
+func (uc *leagueUsecase) GetLeaderboard(userID *string, req *request.LeaderboardRequest) (*entity.Leaderboard, error) {
+	user, err := uc.userRepository.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	week := entity.LeagueWeek(now)
+	weeklyXP, err := uc.leagueRepository.GetWeeklyXP(userID, week)
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboard := &entity.Leaderboard{
+		Scope:   req.Scope,
+		Week:    week,
+		EndsAt:  timeutil.StartOfWeek(now).AddDate(0, 0, 7),
+		Tier:    user.LeagueTier,
+		Entries: []entity.LeaderboardEntry{},
+	}
+	if leaderboard.Scope == "" {
+		leaderboard.Scope = entity.LEADERBOARD_SCOPE_LEAGUE
+	}
+
+	var (
+		leagueID *primitive.ObjectID
+		userIDs  []primitive.ObjectID
+	)
+	limit := pagination.NormalizeLimit(req.Limit)
+	switch leaderboard.Scope {
+	case entity.LEADERBOARD_SCOPE_LEAGUE:
+		if weeklyXP == nil {
+			leaderboard.TierName = entity.LeagueTierName(leaderboard.Tier)
+			return leaderboard, nil
+		}
+		leagueID = &weeklyXP.LeagueID
+		leaderboard.Tier = weeklyXP.Tier
+		leaderboard.PromoteCount = entity.LEAGUE_PROMOTE_COUNT
+		leaderboard.DemoteCount = entity.LEAGUE_DEMOTE_COUNT
+		limit = entity.LEAGUE_GROUP_SIZE
+	case entity.LEADERBOARD_SCOPE_FRIENDS:
+		friendIDs, err := uc.friendRepository.GetFriendIDs(userID)
+		if err != nil {
+			return nil, err
+		}
+		// Friends who hide their activity are left out, like in the friends
+		// activity feed.
+		if len(friendIDs) > 0 {
+			friends, err := uc.userRepository.GetByIDs(friendIDs)
+			if err != nil {
+				return nil, err
+			}
+			for _, friend := range *friends {
+				if !friend.Privacy.HideActivity {
+					userIDs = append(userIDs, friend.ID)
+				}
+			}
+		}
+		userIDs = append(userIDs, user.ID)
+	}
+	leaderboard.TierName = entity.LeagueTierName(leaderboard.Tier)
+
+	entries, err := uc.leagueRepository.GetWeeklyRanking(week, leagueID, userIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+	// Users with the same XP share a rank.
+	for i := range *entries {
+		(*entries)[i].Rank = i + 1
+		if i > 0 && (*entries)[i].XP == (*entries)[i-1].XP {
+			(*entries)[i].Rank = (*entries)[i-1].Rank
+		}
+	}
+	leaderboard.Entries = *entries
+
+	if weeklyXP != nil {
+		above, err := uc.leagueRepository.CountWeeklyXPAbove(week, leagueID, userIDs, weeklyXP.XP)
+		if err != nil {
+			return nil, err
+		}
+		leaderboard.Me = &entity.LeaderboardEntry{
+			Rank:      above + 1,
+			UserID:    user.ID,
+			Name:      user.Name,
+			AvatarURL: user.AvatarURL,
+			Level:     user.Level,
+			XP:        weeklyXP.XP,
+		}
+	}
+	return leaderboard, nil
+}
+
+// CloseFinishedWeeks settles every league of a week before now's week.
+func (uc *leagueUsecase) CloseFinishedWeeks(now time.Time) error {
+	leagues, err := uc.leagueRepository.GetOpenLeaguesBefore(entity.LeagueWeek(now))
+	if err != nil {
+		return err
+	}
+	for i := range *leagues {
+		if err = uc.closeLeague(&(*leagues)[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// closeLeague promotes the top and demotes the bottom of a league. Tiers are
+// set from the league's tier rather than incremented, so closing a league
+// again gives the same result.
+func (uc *leagueUsecase) closeLeague(league *entity.League) error {
+	members, err := uc.leagueRepository.GetLeagueMembers(league.ID)
+	if err != nil {
+		return err
+	}
+	demoteFrom := len(*members) - entity.LEAGUE_DEMOTE_COUNT
+	if demoteFrom < entity.LEAGUE_PROMOTE_COUNT {
+		demoteFrom = entity.LEAGUE_PROMOTE_COUNT
+	}
+	for i, member := range *members {
+		tier, result := league.Tier, entity.LEAGUE_RESULT_STAYED
+		if i < entity.LEAGUE_PROMOTE_COUNT && member.XP > 0 && tier < len(entity.LEAGUE_TIERS)-1 {
+			tier, result = tier+1, entity.LEAGUE_RESULT_PROMOTED
+		} else if i >= demoteFrom && tier > 0 {
+			tier, result = tier-1, entity.LEAGUE_RESULT_DEMOTED
+		}
+		if err = uc.userRepository.SetLeagueTier(member.UserID, tier); err != nil {
+			return err
+		}
+		if err = uc.leagueRepository.SetWeeklyResult(member.ID, i+1, result); err != nil {
+			return err
+		}
+	}
+	return uc.leagueRepository.CloseLeague(league.ID)
+}
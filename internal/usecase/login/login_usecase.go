@@ -20,10 +20,6 @@ func (lu *loginUsecase) GetUserByEmail(email *string) (*entity.User, error) {
 	return lu.userRepository.GetByEmail(email)
 }
 
-func (lu *loginUsecase) CreateAccessToken(user *entity.User, secret *string, expiry int) (accessToken string, err error) {
-	return tokenutil.CreateAccessToken(user, secret, expiry)
-}
-
-func (lu *loginUsecase) CreateRefreshToken(user *entity.User, secret *string, expiry int) (refreshToken string, err error) {
-	return tokenutil.CreateRefreshToken(user, secret, expiry)
+func (lu *loginUsecase) CreateAccessToken(user *entity.User, session *entity.Session, secret *string, expiry int) (accessToken string, err error) {
+	return tokenutil.CreateAccessToken(user, session, secret, expiry)
 }
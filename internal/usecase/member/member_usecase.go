@@ -0,0 +1,210 @@
+package member
+
+import (
+	"errors"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+	"vietcard-backend/pkg/helpers"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memberUsecase struct {
+	memberRepository repository.MemberRepository
+	deckRepository   repository.DeckRepository
+	cardRepository   repository.CardRepository
+	userRepository   repository.UserRepository
+}
+
+func NewMemberUsecase(mr repository.MemberRepository, dr repository.DeckRepository, cr repository.CardRepository, ur repository.UserRepository) usecase.MemberUsecase {
+	return &memberUsecase{
+		memberRepository: mr,
+		deckRepository:   dr,
+		cardRepository:   cr,
+		userRepository:   ur,
+	}
+}
+
+// GetDeckRole returns the role of the user on the deck, which is empty if the
+// user has no access. The deck is nil if it doesn't exist. A deck hidden by
+// moderators is only accessible to its owner.
+func (uc *memberUsecase) GetDeckRole(userID *string, deckID *string) (string, *entity.Deck, error) {
+	deck, err := uc.deckRepository.GetDeckByID(deckID)
+	if err != nil || deck == nil {
+		return "", nil, err
+	}
+	if *userID == "" {
+		return "", deck, nil
+	}
+	if deck.UserID.Hex() == *userID {
+		return entity.DECK_ROLE_OWNER, deck, nil
+	}
+	if deck.IsHidden {
+		return "", deck, nil
+	}
+	member, err := uc.memberRepository.GetMember(deckID, userID)
+	if err != nil {
+		return "", nil, err
+	}
+	if member == nil {
+		return "", deck, nil
+	}
+	return member.Role, deck, nil
+}
+
+func (uc *memberUsecase) InviteMember(userID *string, req *request.InviteMemberRequest) (*entity.DeckMember, error) {
+	deckID := req.DeckID.Hex()
+	role, deck, err := uc.GetDeckRole(userID, &deckID)
+	if err != nil {
+		return nil, err
+	}
+	if deck == nil {
+		return nil, errors.New("deck not found")
+	}
+	if role != entity.DECK_ROLE_OWNER {
+		return nil, errors.New("only the owner can invite members")
+	}
+
+	var invitee *entity.User
+	if req.UserID != nil {
+		inviteeID := req.UserID.Hex()
+		invitee, err = uc.userRepository.GetByID(&inviteeID)
+	} else if req.Email != "" {
+		invitee, err = uc.userRepository.GetByEmail(&req.Email)
+	} else {
+		return nil, errors.New("user_id or email is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if invitee == nil {
+		return nil, errors.New("user not found")
+	}
+	if invitee.ID == deck.UserID {
+		return nil, errors.New("the owner is already a member")
+	}
+
+	inviterID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.memberRepository.UpsertMember(&entity.DeckMember{
+		DeckID:    deck.ID,
+		UserID:    invitee.ID,
+		Role:      req.Role,
+		InvitedBy: inviterID,
+	})
+}
+
+// RemoveMember lets the owner remove anyone and a member leave the deck.
+func (uc *memberUsecase) RemoveMember(userID *string, req *request.RemoveMemberRequest) error {
+	deckID := req.DeckID.Hex()
+	memberID := req.UserID.Hex()
+	role, deck, err := uc.GetDeckRole(userID, &deckID)
+	if err != nil {
+		return err
+	}
+	if deck == nil {
+		return errors.New("deck not found")
+	}
+	if role != entity.DECK_ROLE_OWNER && memberID != *userID {
+		return errors.New("only the owner can remove other members")
+	}
+	removed, err := uc.memberRepository.RemoveMember(&deckID, &memberID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return errors.New("user is not a member of this deck")
+	}
+	return nil
+}
+
+func (uc *memberUsecase) GetMembers(userID *string, deckID *string) (*[]entity.DeckMemberInfo, error) {
+	role, deck, err := uc.GetDeckRole(userID, deckID)
+	if err != nil {
+		return nil, err
+	}
+	if deck == nil {
+		return nil, errors.New("deck not found")
+	}
+	if !entity.DeckRoleAtLeast(role, entity.DECK_ROLE_VIEWER) {
+		return nil, errors.New("not a member of this deck")
+	}
+	ownerID := deck.UserID.Hex()
+	owner, err := uc.userRepository.GetByID(&ownerID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := uc.memberRepository.GetMembersOfDeck(deckID)
+	if err != nil {
+		return nil, err
+	}
+	result := []entity.DeckMemberInfo{}
+	if owner != nil {
+		result = append(result, entity.DeckMemberInfo{
+			UserID:    owner.ID,
+			Name:      owner.Name,
+			Email:     owner.Email,
+			AvatarURL: owner.AvatarURL,
+			Role:      entity.DECK_ROLE_OWNER,
+		})
+	}
+	result = append(result, *members...)
+	return &result, nil
+}
+
+func (uc *memberUsecase) DeleteDeckMembership(deckID *string) error {
+	return uc.memberRepository.DeleteDeckMembership(deckID)
+}
+
+// getLearnerCards replaces the review state of the deck and its cards with the
+// learner's own, starting them from scratch if they haven't studied it yet.
+func (uc *memberUsecase) getLearnerCards(userID *string, deck *entity.Deck) (*[]entity.Card, error) {
+	deckID := deck.ID.Hex()
+	deckProgress, err := uc.memberRepository.GetDeckProgress(userID, &deckID)
+	if err != nil {
+		return nil, err
+	}
+	deckProgress.ApplyTo(deck).UpdateReview()
+
+	cards, err := uc.cardRepository.GetCardsByDeck(&deckID)
+	if err != nil {
+		return nil, err
+	}
+	cards = entity.VisibleCards(cards)
+	cardProgress, err := uc.memberRepository.GetCardProgress(userID, &deckID)
+	if err != nil {
+		return nil, err
+	}
+	entity.ApplyCardProgress(cards, cardProgress)
+	return cards, nil
+}
+
+func (uc *memberUsecase) GetSharedDecksWithReviewCards(userID *string) (*[]entity.DeckWithReviewCards, error) {
+	deckIDs, err := uc.memberRepository.GetDeckIDsOfMember(userID)
+	if err != nil {
+		return nil, err
+	}
+	decksWithReviewCards := []entity.DeckWithReviewCards{}
+	for _, id := range deckIDs {
+		deckID := id.Hex()
+		deck, err := uc.deckRepository.GetDeckByID(&deckID)
+		if err != nil {
+			return nil, err
+		}
+		if deck == nil || deck.IsHidden {
+			continue
+		}
+		cards, err := uc.getLearnerCards(userID, deck)
+		if err != nil {
+			return nil, err
+		}
+		deckWithReviewCards := entity.DeckWithReviewCards{Deck: *deck}
+		deckWithReviewCards.Cards, deckWithReviewCards.NumBlueCards, deckWithReviewCards.NumRedCards, deckWithReviewCards.NumGreenCards = helpers.FilterReviewCards(cards, deck.MaxNewCards-deck.CurNewCards, deck.MaxReviewCards-deck.CurReviewCards)
+		decksWithReviewCards = append(decksWithReviewCards, deckWithReviewCards)
+	}
+	return &decksWithReviewCards, nil
+}
@@ -0,0 +1,226 @@
+package moderation
+
+import (
+	"errors"
+	"fmt"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type moderationUsecase struct {
+	reportRepository  repository.ReportRepository
+	deckRepository    repository.DeckRepository
+	cardRepository    repository.CardRepository
+	commentRepository repository.CommentRepository
+	userRepository    repository.UserRepository
+	sessionRepository repository.SessionRepository
+	hideThreshold     int
+}
+
+func NewModerationUsecase(rr repository.ReportRepository, dr repository.DeckRepository, cr repository.CardRepository, cmr repository.CommentRepository, ur repository.UserRepository, sr repository.SessionRepository, hideThreshold int) usecase.ModerationUsecase {
+	if hideThreshold <= 0 {
+		hideThreshold = entity.DEFAULT_REPORT_HIDE_THRESHOLD
+	}
+	return &moderationUsecase{
+		reportRepository:  rr,
+		deckRepository:    dr,
+		cardRepository:    cr,
+		commentRepository: cmr,
+		userRepository:    ur,
+		sessionRepository: sr,
+		hideThreshold:     hideThreshold,
+	}
+}
+
+// getTarget returns the owner of the reported content and whether it is
+// already hidden.
+func (uc *moderationUsecase) getTarget(targetType string, targetID primitive.ObjectID) (primitive.ObjectID, bool, error) {
+	id := targetID.Hex()
+	switch targetType {
+	case entity.REPORT_TARGET_DECK:
+		deck, err := uc.deckRepository.GetDeckByID(&id)
+		if err != nil || deck == nil {
+			return primitive.NilObjectID, false, err
+		}
+		return deck.UserID, deck.IsHidden, nil
+	case entity.REPORT_TARGET_CARD:
+		card, err := uc.cardRepository.GetCardByID(&id)
+		if err != nil || card == nil {
+			return primitive.NilObjectID, false, err
+		}
+		return card.UserID, card.IsHidden, nil
+	case entity.REPORT_TARGET_COMMENT:
+		comment, err := uc.commentRepository.GetCommentByID(&id)
+		if err != nil || comment == nil || comment.IsDeleted {
+			return primitive.NilObjectID, false, err
+		}
+		return comment.UserID, comment.IsHidden, nil
+	}
+	return primitive.NilObjectID, false, errors.New("invalid target type")
+}
+
+func (uc *moderationUsecase) setHidden(targetType string, targetID primitive.ObjectID, hidden bool) error {
+	switch targetType {
+	case entity.REPORT_TARGET_DECK:
+		return uc.deckRepository.SetDeckHidden(targetID, hidden)
+	case entity.REPORT_TARGET_CARD:
+		return uc.cardRepository.SetCardHidden(targetID, hidden)
+	case entity.REPORT_TARGET_COMMENT:
+		return uc.commentRepository.SetCommentHidden(targetID, hidden)
+	}
+	return errors.New("invalid target type")
+}
+
+// setBanned bans the user, signs them out everywhere and hides their public
+// decks and comments, or lifts the ban and brings back what it hid.
+func (uc *moderationUsecase) setBanned(userID primitive.ObjectID, banned bool) error {
+	if err := uc.userRepository.SetBanned(userID, banned); err != nil {
+		return err
+	}
+	if banned {
+		if _, err := uc.sessionRepository.RevokeUserSessions(userID, nil, entity.SESSION_REVOKE_BAN); err != nil {
+			return err
+		}
+	}
+	if err := uc.deckRepository.SetUserDecksHiddenByBan(userID, banned); err != nil {
+		return err
+	}
+	return uc.commentRepository.SetUserCommentsHiddenByBan(userID, banned)
+}
+
+// ReportContent records the report and hides the target once reports from
+// enough distinct users are open.
+func (uc *moderationUsecase) ReportContent(userID *string, req *request.ReportContentRequest) (*entity.Report, error) {
+	reporterID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	ownerID, isHidden, err := uc.getTarget(req.TargetType, *req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if ownerID.IsZero() {
+		return nil, errors.New(req.TargetType + " not found")
+	}
+	if ownerID == reporterID {
+		return nil, errors.New("you can't report your own content")
+	}
+
+	report := &entity.Report{
+		ReporterID:    reporterID,
+		TargetType:    req.TargetType,
+		TargetID:      *req.TargetID,
+		TargetOwnerID: ownerID,
+		Reason:        req.Reason,
+		Details:       req.Details,
+	}
+	created, err := uc.reportRepository.CreateReport(report)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, errors.New("you already reported this " + req.TargetType)
+	}
+	if isHidden {
+		return report, nil
+	}
+
+	count, err := uc.reportRepository.CountOpenReports(req.TargetType, *req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= uc.hideThreshold {
+		if err = uc.setHidden(req.TargetType, *req.TargetID, true); err != nil {
+			return nil, err
+		}
+		_, err = uc.reportRepository.CreateModerationAction(&entity.ModerationAction{
+			TargetType:    req.TargetType,
+			TargetID:      *req.TargetID,
+			TargetOwnerID: ownerID,
+			Action:        entity.MODERATION_ACTION_HIDE,
+			Note:          fmt.Sprintf("Hidden automatically after %d reports", count),
+			ReportCount:   count,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+func (uc *moderationUsecase) GetModerationQueue(req *request.GetModerationQueueRequest) (*[]entity.ModerationQueueItem, error) {
+	return uc.reportRepository.GetModerationQueue(req)
+}
+
+// ModerateContent applies the action to the target, closes its open reports
+// and records the decision.
+func (uc *moderationUsecase) ModerateContent(moderatorID *string, req *request.ModerateContentRequest) (*entity.ModerationAction, error) {
+	modID, err := primitive.ObjectIDFromHex(*moderatorID)
+	if err != nil {
+		return nil, err
+	}
+	ownerID, _, err := uc.getTarget(req.TargetType, *req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if ownerID.IsZero() {
+		return nil, errors.New(req.TargetType + " not found")
+	}
+	count, err := uc.reportRepository.CountOpenReports(req.TargetType, *req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := entity.REPORT_STATUS_RESOLVED
+	switch req.Action {
+	case entity.MODERATION_ACTION_HIDE:
+		err = uc.setHidden(req.TargetType, *req.TargetID, true)
+	case entity.MODERATION_ACTION_RESTORE:
+		err = uc.setHidden(req.TargetType, *req.TargetID, false)
+		status = entity.REPORT_STATUS_DISMISSED
+	case entity.MODERATION_ACTION_UNPUBLISH:
+		if req.TargetType != entity.REPORT_TARGET_DECK {
+			return nil, errors.New("only decks can be unpublished")
+		}
+		deckID := req.TargetID.Hex()
+		isPublic := false
+		_, err = uc.deckRepository.UpdateDeck(&deckID, &request.UpdateDeckRequest{IsPublic: &isPublic})
+	case entity.MODERATION_ACTION_WARN:
+		err = uc.userRepository.IncrementWarningCount(ownerID)
+	case entity.MODERATION_ACTION_BAN:
+		if err = uc.setHidden(req.TargetType, *req.TargetID, true); err == nil {
+			err = uc.setBanned(ownerID, true)
+		}
+	case entity.MODERATION_ACTION_UNBAN:
+		err = uc.setBanned(ownerID, false)
+		status = entity.REPORT_STATUS_DISMISSED
+	case entity.MODERATION_ACTION_DISMISS:
+		status = entity.REPORT_STATUS_DISMISSED
+	default:
+		return nil, errors.New("invalid moderation action")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = uc.reportRepository.CloseReports(req.TargetType, *req.TargetID, status); err != nil {
+		return nil, err
+	}
+	return uc.reportRepository.CreateModerationAction(&entity.ModerationAction{
+		ModeratorID:   &modID,
+		TargetType:    req.TargetType,
+		TargetID:      *req.TargetID,
+		TargetOwnerID: ownerID,
+		Action:        req.Action,
+		Note:          req.Note,
+		ReportCount:   count,
+	})
+}
+
+func (uc *moderationUsecase) GetModerationLog(req *request.GetModerationLogRequest) (*[]entity.ModerationAction, string, error) {
+	return uc.reportRepository.GetModerationActions(req)
+}
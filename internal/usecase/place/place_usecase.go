@@ -0,0 +1,50 @@
+package place
+
+import (
+	"sort"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+)
+
+type placeUsecase struct {
+	deckRepository repository.DeckRepository
+	cardRepository repository.CardRepository
+}
+
+func NewPlaceUsecase(dr repository.DeckRepository, cr repository.CardRepository) usecase.PlaceUsecase {
+	return &placeUsecase{
+		deckRepository: dr,
+		cardRepository: cr,
+	}
+}
+
+// FindPlaces returns decks, cards or, when kind is empty, both. Near queries
+// are merged by distance and the others list decks before cards.
+func (uc *placeUsecase) FindPlaces(query *entity.PlaceQuery, kind string) (*[]entity.MapPlace, error) {
+	places := []entity.MapPlace{}
+	if kind == "" || kind == entity.PLACE_KIND_DECK {
+		decks, err := uc.deckRepository.FindDeckPlaces(query)
+		if err != nil {
+			return nil, err
+		}
+		places = append(places, *decks...)
+	}
+	if kind == "" || kind == entity.PLACE_KIND_CARD {
+		cards, err := uc.cardRepository.FindCardPlaces(query)
+		if err != nil {
+			return nil, err
+		}
+		places = append(places, *cards...)
+	}
+
+	if query.Near != nil {
+		sort.SliceStable(places, func(i, j int) bool {
+			return places[i].DistanceMeters < places[j].DistanceMeters
+		})
+	}
+	if len(places) > query.Limit {
+		places = places[:query.Limit]
+	}
+	return &places, nil
+}
@@ -0,0 +1,131 @@
+package profile
+
+import (
+	"errors"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type profileUsecase struct {
+	followRepository repository.FollowRepository
+	userRepository   repository.UserRepository
+	deckRepository   repository.DeckRepository
+}
+
+func NewProfileUsecase(fr repository.FollowRepository, ur repository.UserRepository, dr repository.DeckRepository) usecase.ProfileUsecase {
+	return &profileUsecase{
+		followRepository: fr,
+		userRepository:   ur,
+		deckRepository:   dr,
+	}
+}
+
+// GetAuthorProfile returns nil if the author does not exist. viewerUserID is
+// empty for anonymous viewers.
+func (uc *profileUsecase) GetAuthorProfile(authorID *string, viewerUserID string) (*entity.AuthorProfile, error) {
+	author, err := uc.userRepository.GetByID(authorID)
+	if err != nil || author == nil {
+		return nil, err
+	}
+	publishedDecks, totalCopies, err := uc.deckRepository.GetAuthorDeckStats(authorID)
+	if err != nil {
+		return nil, err
+	}
+	decks, _, err := uc.deckRepository.GetPublicDecks(&request.DeckCatalogRequest{
+		Sort:     "newest",
+		AuthorID: *authorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	isFollowing := false
+	if viewerUserID != "" && viewerUserID != *authorID {
+		isFollowing, err = uc.followRepository.IsFollowing(&viewerUserID, authorID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &entity.AuthorProfile{
+		ID:             author.ID,
+		Name:           author.Name,
+		AvatarURL:      author.AvatarURL,
+		Bio:            author.Bio,
+		Level:          author.Level,
+		FollowerCount:  author.FollowerCount,
+		FollowingCount: author.FollowingCount,
+		PublishedDecks: publishedDecks,
+		TotalCopies:    totalCopies,
+		IsFollowing:    isFollowing,
+		Decks:          *decks,
+	}, nil
+}
+
+func (uc *profileUsecase) FollowUser(userID *string, authorID *string) (*entity.User, error) {
+	if *userID == *authorID {
+		return nil, errors.New("you can't follow yourself")
+	}
+	author, err := uc.userRepository.GetByID(authorID)
+	if err != nil {
+		return nil, err
+	}
+	if author == nil {
+		return nil, errors.New("user not found")
+	}
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := uc.followRepository.CreateFollow(&entity.Follow{
+		FollowerID: uID,
+		FolloweeID: author.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if created {
+		if err = uc.userRepository.IncrementFollowCounts(userID, authorID, 1); err != nil {
+			return nil, err
+		}
+		author.FollowerCount++
+	}
+	return author, nil
+}
+
+func (uc *profileUsecase) UnfollowUser(userID *string, authorID *string) (*entity.User, error) {
+	author, err := uc.userRepository.GetByID(authorID)
+	if err != nil {
+		return nil, err
+	}
+	if author == nil {
+		return nil, errors.New("user not found")
+	}
+
+	deleted, err := uc.followRepository.DeleteFollow(userID, authorID)
+	if err != nil {
+		return nil, err
+	}
+	if deleted {
+		if err = uc.userRepository.IncrementFollowCounts(userID, authorID, -1); err != nil {
+			return nil, err
+		}
+		author.FollowerCount--
+	}
+	return author, nil
+}
+
+func (uc *profileUsecase) GetFeed(userID *string, req *request.GetFeedRequest) (*[]entity.DeckSummary, string, error) {
+	authorIDs, err := uc.followRepository.GetFolloweeIDs(userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(authorIDs) == 0 {
+		return &[]entity.DeckSummary{}, "", nil
+	}
+	return uc.deckRepository.GetFeedDecks(authorIDs, req)
+}
@@ -0,0 +1,147 @@
+package quest
+
+import (
+	"errors"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type questUsecase struct {
+	questRepository repository.QuestRepository
+}
+
+func NewQuestUsecase(qr repository.QuestRepository) usecase.QuestUsecase {
+	return &questUsecase{
+		questRepository: qr,
+	}
+}
+
+// currentQuests generates the user's quests of the current day and week if
+// needed and returns them.
+func (uc *questUsecase) currentQuests(userID primitive.ObjectID) (*[]entity.UserQuest, error) {
+	now := time.Now()
+	periods := []struct {
+		period string
+		count  int
+	}{
+		{entity.QUEST_PERIOD_DAILY, entity.DAILY_QUEST_COUNT},
+		{entity.QUEST_PERIOD_WEEKLY, entity.WEEKLY_QUEST_COUNT},
+	}
+	periodKeys := make([]string, 0, len(periods))
+	quests := []entity.UserQuest{}
+	for _, p := range periods {
+		periodKey, expiresAt := entity.QuestPeriodKey(p.period, now)
+		periodKeys = append(periodKeys, periodKey)
+		for _, template := range entity.PickQuestTemplates(userID, p.period, periodKey, p.count) {
+			quests = append(quests, entity.UserQuest{
+				UserID:      userID,
+				Period:      p.period,
+				PeriodKey:   periodKey,
+				TemplateKey: template.Key,
+				Title:       template.Title,
+				Metric:      template.Metric,
+				Topic:       template.Topic,
+				Target:      template.Target,
+				Progress:    0,
+				RewardXP:    template.RewardXP,
+				RewardCoins: template.RewardCoins,
+				ExpiresAt:   expiresAt,
+			})
+		}
+	}
+	if err := uc.questRepository.EnsureQuests(quests); err != nil {
+		return nil, err
+	}
+	return uc.questRepository.GetQuests(userID, periodKeys)
+}
+
+func (uc *questUsecase) GetQuests(userID *string) (*[]entity.UserQuest, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.currentQuests(uID)
+}
+
+// RecordEvent moves the user's current quests and returns the ones completed
+// by the event.
+func (uc *questUsecase) RecordEvent(userID *string, event *entity.QuestEvent) ([]entity.UserQuest, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	quests, err := uc.currentQuests(uID)
+	if err != nil {
+		return nil, err
+	}
+	completed := []entity.UserQuest{}
+	for i := range *quests {
+		quest := &(*quests)[i]
+		amount := quest.Amount(event)
+		if quest.CompletedAt != nil || amount <= 0 {
+			continue
+		}
+		quest, err = uc.questRepository.AddQuestProgress(quest.ID, amount)
+		if err != nil {
+			return nil, err
+		}
+		if quest.Progress < quest.Target {
+			continue
+		}
+		now := time.Now()
+		isNew, err := uc.questRepository.CompleteQuest(quest.ID, now)
+		if err != nil {
+			return nil, err
+		}
+		if isNew {
+			quest.CompletedAt = &now
+			completed = append(completed, *quest)
+		}
+	}
+	return completed, nil
+}
+
+// GetClaimableQuest returns the quest if it is completed and not claimed yet.
+func (uc *questUsecase) GetClaimableQuest(userID *string, questID *string) (*entity.UserQuest, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	qID, err := primitive.ObjectIDFromHex(*questID)
+	if err != nil {
+		return nil, err
+	}
+	quest, err := uc.questRepository.GetQuestByID(uID, qID)
+	if err != nil {
+		return nil, err
+	}
+	if quest == nil || quest.CompletedAt == nil || quest.ClaimedAt != nil {
+		return nil, errors.New("quest is not completed or was already claimed")
+	}
+	return quest, nil
+}
+
+// ClaimQuest marks a completed quest as claimed and returns it. The caller
+// pays the reward first, so a claim that failed halfway can be retried.
+func (uc *questUsecase) ClaimQuest(userID *string, questID *string) (*entity.UserQuest, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	qID, err := primitive.ObjectIDFromHex(*questID)
+	if err != nil {
+		return nil, err
+	}
+	quest, err := uc.questRepository.ClaimQuest(uID, qID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if quest == nil {
+		return nil, errors.New("quest is not completed or was already claimed")
+	}
+	return quest, nil
+}
@@ -0,0 +1,64 @@
+package rating
+
+import (
+	"errors"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ratingUsecase struct {
+	ratingRepository repository.RatingRepository
+	deckRepository   repository.DeckRepository
+}
+
+func NewRatingUsecase(rr repository.RatingRepository, dr repository.DeckRepository) usecase.RatingUsecase {
+	return &ratingUsecase{
+		ratingRepository: rr,
+		deckRepository:   dr,
+	}
+}
+
+func (uc *ratingUsecase) RateDeck(userID *string, deckID *string, rating int) (*entity.Deck, error) {
+	if rating < entity.MIN_DECK_RATING || rating > entity.MAX_DECK_RATING {
+		return nil, errors.New("rating must be between 1 and 5")
+	}
+	deck, err := uc.deckRepository.GetDeckByID(deckID)
+	if err != nil {
+		return nil, err
+	}
+	if deck == nil || !deck.IsPublic {
+		return nil, errors.New("only public decks can be rated")
+	}
+	if deck.UserID.Hex() == *userID {
+		return nil, errors.New("you can't rate your own deck")
+	}
+
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := uc.ratingRepository.UpsertDeckRating(&entity.DeckRating{
+		DeckID: deck.ID,
+		UserID: uID,
+		Rating: rating,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sumDelta, countDelta := rating, 1
+	if previous != nil {
+		sumDelta, countDelta = rating-previous.Rating, 0
+	}
+	if sumDelta == 0 && countDelta == 0 {
+		return deck, nil
+	}
+	return uc.deckRepository.ApplyDeckRating(deckID, sumDelta, countDelta)
+}
+
+func (uc *ratingUsecase) GetMyDeckRating(userID *string, deckID *string) (*entity.DeckRating, error) {
+	return uc.ratingRepository.GetDeckRating(deckID, userID)
+}
@@ -1,30 +1,107 @@
 package refreshtkn
 
 import (
+	"time"
 	"vietcard-backend/internal/domain/entity"
 	"vietcard-backend/internal/domain/interface/repository"
 	"vietcard-backend/internal/domain/interface/usecase"
+	"vietcard-backend/pkg/randutil"
 	"vietcard-backend/pkg/tokenutil"
 )
 
 type refreshTokenUsecase struct {
-	userRepository repository.UserRepository
+	sessionRepository repository.SessionRepository
 }
 
-func NewRefreshTokenUsecase(userRepository repository.UserRepository) usecase.RefreshTokenUsecase {
+func NewRefreshTokenUsecase(sessionRepository repository.SessionRepository) usecase.RefreshTokenUsecase {
 	return &refreshTokenUsecase{
-		userRepository: userRepository,
+		sessionRepository: sessionRepository,
 	}
 }
 
-func (rtu *refreshTokenUsecase) CreateAccessToken(user *entity.User, secret *string, expiry int) (accessToken string, err error) {
-	return tokenutil.CreateAccessToken(user, secret, expiry)
+func (rtu *refreshTokenUsecase) CreateAccessToken(user *entity.User, session *entity.Session, secret *string, expiry int) (accessToken string, err error) {
+	return tokenutil.CreateAccessToken(user, session, secret, expiry)
 }
 
-func (rtu *refreshTokenUsecase) CreateRefreshToken(user *entity.User, secret *string, expiry int) (refreshToken string, err error) {
-	return tokenutil.CreateRefreshToken(user, secret, expiry)
+// CreateSession starts a new session of the user on the device and returns its
+// first refresh token.
+func (rtu *refreshTokenUsecase) CreateSession(user *entity.User, device *entity.SessionDevice, expiry int) (*entity.Session, string, error) {
+	now := time.Now()
+	session, err := rtu.sessionRepository.CreateSession(&entity.Session{
+		CreatedAt:     now,
+		UserID:        user.ID,
+		SessionDevice: *device.Truncate(),
+		LastSeenAt:    now,
+		ExpiresAt:     now.Add(time.Duration(expiry) * time.Hour),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	refreshToken, err := rtu.issueRefreshToken(session, now)
+	if err != nil {
+		return nil, "", err
+	}
+	return session, refreshToken, nil
+}
+
+func (rtu *refreshTokenUsecase) issueRefreshToken(session *entity.Session, now time.Time) (string, error) {
+	token, err := randutil.RandomToken(entity.REFRESH_TOKEN_BYTES)
+	if err != nil {
+		return "", err
+	}
+	_, err = rtu.sessionRepository.CreateRefreshToken(&entity.RefreshToken{
+		CreatedAt: now,
+		SessionID: session.ID,
+		UserID:    session.UserID,
+		TokenHash: entity.HashRefreshToken(token),
+		ExpiresAt: session.ExpiresAt,
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
 }
 
-func (rtu *refreshTokenUsecase) ExtractIDFromToken(requestToken *string, secret *string) (string, error) {
-	return tokenutil.ExtractIDFromToken(requestToken, secret)
+// RotateRefreshToken exchanges the refresh token for a new one of the same
+// session. A token that was already rotated means it leaked, so the whole
+// session is revoked. It returns a nil session if the token can't be used.
+func (rtu *refreshTokenUsecase) RotateRefreshToken(refreshToken *string, device *entity.SessionDevice, expiry int) (*entity.Session, string, error) {
+	now := time.Now()
+	token, err := rtu.sessionRepository.GetRefreshToken(entity.HashRefreshToken(*refreshToken))
+	if err != nil {
+		return nil, "", err
+	}
+	if token == nil || !now.Before(token.ExpiresAt) {
+		return nil, "", nil
+	}
+	if token.RotatedAt != nil {
+		return nil, "", rtu.sessionRepository.RevokeSession(token.SessionID, entity.SESSION_REVOKE_REUSE)
+	}
+	session, err := rtu.sessionRepository.GetSession(token.SessionID)
+	if err != nil {
+		return nil, "", err
+	}
+	if session == nil || !session.IsActive(now) {
+		return nil, "", nil
+	}
+	rotated, err := rtu.sessionRepository.RotateRefreshToken(token.ID, now)
+	if err != nil {
+		return nil, "", err
+	}
+	if !rotated {
+		return nil, "", rtu.sessionRepository.RevokeSession(token.SessionID, entity.SESSION_REVOKE_REUSE)
+	}
+
+	session.SessionDevice = *device.Truncate()
+	session.LastSeenAt = now
+	session.ExpiresAt = now.Add(time.Duration(expiry) * time.Hour)
+	err = rtu.sessionRepository.TouchSession(session.ID, &session.SessionDevice, session.LastSeenAt, session.ExpiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+	newToken, err := rtu.issueRefreshToken(session, now)
+	if err != nil {
+		return nil, "", err
+	}
+	return session, newToken, nil
 }
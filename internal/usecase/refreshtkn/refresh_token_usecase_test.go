@@ -0,0 +1,165 @@
+package refreshtkn
+
+import (
+	"testing"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fakeSessionRepository struct {
+	repository.SessionRepository
+	sessions map[primitive.ObjectID]*entity.Session
+	tokens   map[string]*entity.RefreshToken
+}
+
+func newFakeSessionRepository() *fakeSessionRepository {
+	return &fakeSessionRepository{
+		sessions: map[primitive.ObjectID]*entity.Session{},
+		tokens:   map[string]*entity.RefreshToken{},
+	}
+}
+
+func (sr *fakeSessionRepository) CreateSession(session *entity.Session) (*entity.Session, error) {
+	session.ID = primitive.NewObjectID()
+	stored := *session
+	sr.sessions[session.ID] = &stored
+	return session, nil
+}
+
+func (sr *fakeSessionRepository) GetSession(sessionID primitive.ObjectID) (*entity.Session, error) {
+	session, exists := sr.sessions[sessionID]
+	if !exists {
+		return nil, nil
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (sr *fakeSessionRepository) TouchSession(sessionID primitive.ObjectID, device *entity.SessionDevice, now time.Time, expiresAt time.Time) error {
+	session := sr.sessions[sessionID]
+	session.SessionDevice = *device
+	session.LastSeenAt = now
+	session.ExpiresAt = expiresAt
+	return nil
+}
+
+func (sr *fakeSessionRepository) RevokeSession(sessionID primitive.ObjectID, reason string) error {
+	now := time.Now()
+	sr.sessions[sessionID].RevokedAt = &now
+	sr.sessions[sessionID].RevokeReason = reason
+	return nil
+}
+
+func (sr *fakeSessionRepository) CreateRefreshToken(token *entity.RefreshToken) (*entity.RefreshToken, error) {
+	token.ID = primitive.NewObjectID()
+	stored := *token
+	sr.tokens[token.TokenHash] = &stored
+	return token, nil
+}
+
+func (sr *fakeSessionRepository) GetRefreshToken(tokenHash string) (*entity.RefreshToken, error) {
+	token, exists := sr.tokens[tokenHash]
+	if !exists {
+		return nil, nil
+	}
+	copied := *token
+	return &copied, nil
+}
+
+func (sr *fakeSessionRepository) RotateRefreshToken(tokenID primitive.ObjectID, now time.Time) (bool, error) {
+	for _, token := range sr.tokens {
+		if token.ID == tokenID && token.RotatedAt == nil {
+			token.RotatedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func signIn(t *testing.T) (*fakeSessionRepository, *entity.Session, string) {
+	t.Helper()
+	repo := newFakeSessionRepository()
+	user := &entity.User{ID: primitive.NewObjectID()}
+	session, token, err := NewRefreshTokenUsecase(repo).CreateSession(user, &entity.SessionDevice{UserAgent: "app"}, 24)
+	if err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+	return repo, session, token
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	repo, session, token := signIn(t)
+	uc := NewRefreshTokenUsecase(repo)
+
+	for i := 0; i < 3; i++ {
+		rotated, newToken, err := uc.RotateRefreshToken(&token, &entity.SessionDevice{UserAgent: "app"}, 24)
+		if err != nil {
+			t.Fatalf("rotation %d: error = %v", i, err)
+		}
+		if rotated == nil || rotated.ID != session.ID {
+			t.Fatalf("rotation %d: session = %v, want %s", i, rotated, session.ID.Hex())
+		}
+		if newToken == "" || newToken == token {
+			t.Fatalf("rotation %d: got token %q, want a new one", i, newToken)
+		}
+		token = newToken
+	}
+	if len(repo.tokens) != 4 {
+		t.Errorf("stored %d refresh tokens, want 4", len(repo.tokens))
+	}
+	for hash, stored := range repo.tokens {
+		if stored.TokenHash == token {
+			t.Errorf("token %s was stored in plain text", hash)
+		}
+	}
+}
+
+func TestRotateRefreshTokenRevokesReusedFamily(t *testing.T) {
+	repo, session, first := signIn(t)
+	uc := NewRefreshTokenUsecase(repo)
+	device := &entity.SessionDevice{UserAgent: "app"}
+
+	_, second, err := uc.RotateRefreshToken(&first, device, 24)
+	if err != nil || second == "" {
+		t.Fatalf("RotateRefreshToken() = %q, %v", second, err)
+	}
+	// Someone replays the first token after the client rotated it.
+	reused, _, err := uc.RotateRefreshToken(&first, device, 24)
+	if err != nil || reused != nil {
+		t.Fatalf("reused token gave session %v, error %v, want neither", reused, err)
+	}
+	if stored := repo.sessions[session.ID]; stored.RevokedAt == nil || stored.RevokeReason != entity.SESSION_REVOKE_REUSE {
+		t.Fatalf("session revoked at %v for %q, want revoked for reuse", stored.RevokedAt, stored.RevokeReason)
+	}
+	// The whole family is gone, the client's newest token included.
+	if refreshed, _, err := uc.RotateRefreshToken(&second, device, 24); err != nil || refreshed != nil {
+		t.Errorf("newest token after reuse gave session %v, error %v, want neither", refreshed, err)
+	}
+}
+
+func TestRotateRefreshTokenRefusesUnusableTokens(t *testing.T) {
+	device := &entity.SessionDevice{}
+
+	repo, _, _ := signIn(t)
+	unknown := "not-a-token"
+	if session, _, err := NewRefreshTokenUsecase(repo).RotateRefreshToken(&unknown, device, 24); session != nil || err != nil {
+		t.Errorf("unknown token gave session %v, error %v", session, err)
+	}
+
+	repo, session, token := signIn(t)
+	repo.RevokeSession(session.ID, entity.SESSION_REVOKE_LOGOUT)
+	if session, _, err := NewRefreshTokenUsecase(repo).RotateRefreshToken(&token, device, 24); session != nil || err != nil {
+		t.Errorf("token of a logged out session gave session %v, error %v", session, err)
+	}
+
+	repo, _, token = signIn(t)
+	for _, stored := range repo.tokens {
+		stored.ExpiresAt = time.Now().Add(-time.Minute)
+	}
+	if session, _, err := NewRefreshTokenUsecase(repo).RotateRefreshToken(&token, device, 24); session != nil || err != nil {
+		t.Errorf("expired token gave session %v, error %v", session, err)
+	}
+}
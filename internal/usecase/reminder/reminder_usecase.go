@@ -0,0 +1,124 @@
+package reminder
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/notifier"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+)
+
+type reminderUsecase struct {
+	userRepository   repository.UserRepository
+	cardRepository   repository.CardRepository
+	memberRepository repository.MemberRepository
+	reviewRepository repository.ReviewRepository
+	notifiers        map[string]notifier.Notifier
+}
+
+// NewReminderUsecase sends reminders through the notifier of each channel.
+// Channels without a notifier are skipped.
+func NewReminderUsecase(ur repository.UserRepository, cr repository.CardRepository, mr repository.MemberRepository, rr repository.ReviewRepository, notifiers []notifier.Notifier) usecase.ReminderUsecase {
+	uc := &reminderUsecase{
+		userRepository:   ur,
+		cardRepository:   cr,
+		memberRepository: mr,
+		reviewRepository: rr,
+		notifiers:        make(map[string]notifier.Notifier),
+	}
+	for _, n := range notifiers {
+		uc.notifiers[n.Channel()] = n
+	}
+	return uc
+}
+
+// SendDueReminders reminds the users whose reminder time of the day has
+// passed, outside of their quiet hours, if they have cards due and have not
+// studied yet today. A failure to remind a user does not stop the others.
+func (uc *reminderUsecase) SendDueReminders(now time.Time) error {
+	users, err := uc.userRepository.GetReminderUsers(now.Add(-entity.REMINDER_MIN_GAP_HOUR * time.Hour))
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for i := range *users {
+		user := &(*users)[i]
+		settings := user.Reminder.WithDefaults()
+		dueAt, ok := settings.DueAt(now)
+		if !ok || now.Before(dueAt) || !settings.LastSentAt.Before(dueAt) || settings.InQuietHours(now) {
+			continue
+		}
+		claimed, err := uc.userRepository.ClaimReminder(user.ID, dueAt, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("user %s: %w", user.ID.Hex(), err))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		if err = uc.remind(user, settings, now); err != nil {
+			errs = append(errs, fmt.Errorf("user %s: %w", user.ID.Hex(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (uc *reminderUsecase) remind(user *entity.User, settings entity.ReminderSettings, now time.Time) error {
+	loc, _ := time.LoadLocation(settings.TimeZone)
+	local := now.In(loc)
+	dayStart := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	userID := user.ID.Hex()
+	last, err := uc.reviewRepository.GetLastReviewLog(&userID)
+	if err != nil {
+		return err
+	}
+	if last != nil && !last.CreatedAt.Before(dayStart) {
+		return nil
+	}
+	dueCards, err := uc.cardRepository.CountDueCards(user.ID, dayEnd)
+	if err != nil {
+		return err
+	}
+	dueProgress, err := uc.memberRepository.CountDueCardProgress(user.ID, dayEnd)
+	if err != nil {
+		return err
+	}
+	if dueCards+dueProgress == 0 {
+		return nil
+	}
+
+	body := fmt.Sprintf("You have %d cards to review today.", dueCards+dueProgress)
+	if streak := user.CurrentStreak(); streak > 1 {
+		body += fmt.Sprintf(" Keep your %d-day streak going!", streak)
+	}
+	var errs []error
+	for _, channel := range settings.Channels {
+		n, ok := uc.notifiers[channel]
+		if !ok {
+			continue
+		}
+		to := user.Email
+		if channel == entity.NOTIFY_CHANNEL_PUSH {
+			to = settings.PushToken
+		}
+		if to == "" {
+			continue
+		}
+		err = n.Notify(&entity.Notification{
+			UserID:    user.ID,
+			Channel:   channel,
+			To:        to,
+			Title:     "Time to study",
+			Body:      body,
+			CreatedAt: now,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", channel, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,164 @@
+package reminder
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/notifier"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/notifier/memnotifier"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The fakes embed the repository interfaces and only implement what
+// SendDueReminders uses.
+
+type fakeUserRepository struct {
+	repository.UserRepository
+	users     []entity.User
+	claimErrs map[primitive.ObjectID]error
+	claimed   map[primitive.ObjectID]bool
+}
+
+func (ur *fakeUserRepository) GetReminderUsers(sentBefore time.Time) (*[]entity.User, error) {
+	return &ur.users, nil
+}
+
+func (ur *fakeUserRepository) ClaimReminder(userID primitive.ObjectID, dueAt time.Time, now time.Time) (bool, error) {
+	if err := ur.claimErrs[userID]; err != nil {
+		return false, err
+	}
+	if ur.claimed[userID] {
+		return false, nil
+	}
+	ur.claimed[userID] = true
+	return true, nil
+}
+
+type fakeCardRepository struct {
+	repository.CardRepository
+	due map[primitive.ObjectID]int
+}
+
+func (cr *fakeCardRepository) CountDueCards(userID primitive.ObjectID, before time.Time) (int, error) {
+	return cr.due[userID], nil
+}
+
+type fakeMemberRepository struct {
+	repository.MemberRepository
+}
+
+func (mr *fakeMemberRepository) CountDueCardProgress(userID primitive.ObjectID, before time.Time) (int, error) {
+	return 0, nil
+}
+
+type fakeReviewRepository struct {
+	repository.ReviewRepository
+	last map[string]*entity.ReviewLog
+}
+
+func (rr *fakeReviewRepository) GetLastReviewLog(userID *string) (*entity.ReviewLog, error) {
+	return rr.last[*userID], nil
+}
+
+func reminderUser(email string, channels ...string) entity.User {
+	return entity.User{
+		ID:    primitive.NewObjectID(),
+		Email: email,
+		Reminder: entity.ReminderSettings{
+			Enabled:   true,
+			Time:      "20:00",
+			TimeZone:  "UTC",
+			Channels:  channels,
+			PushToken: "token-" + email,
+		},
+	}
+}
+
+func TestSendDueReminders(t *testing.T) {
+	now := time.Date(2024, 5, 6, 20, 30, 0, 0, time.UTC)
+	studied := reminderUser("studied@example.com")
+	nothingDue := reminderUser("nothing-due@example.com")
+	notDueYet := reminderUser("not-due-yet@example.com")
+	notDueYet.Reminder.Time = "21:00"
+	quiet := reminderUser("quiet@example.com")
+	quiet.Reminder.QuietStart, quiet.Reminder.QuietEnd = "20:00", "07:00"
+	broken := reminderUser("broken@example.com")
+	push := reminderUser("push@example.com", entity.NOTIFY_CHANNEL_EMAIL, entity.NOTIFY_CHANNEL_PUSH)
+	due := reminderUser("due@example.com")
+
+	tests := []struct {
+		name      string
+		user      entity.User
+		claimErr  error
+		wantEmail int
+		wantPush  int
+	}{
+		// The user whose claim fails comes first, so the others are only
+		// reminded if the loop goes on after the error.
+		{"claim fails", broken, errors.New("claim failed"), 0, 0},
+		{"due", due, nil, 1, 0},
+		{"due on both channels", push, nil, 1, 1},
+		{"already studied today", studied, nil, 0, 0},
+		{"no cards due", nothingDue, nil, 0, 0},
+		{"reminder time not reached", notDueYet, nil, 0, 0},
+		{"in quiet hours", quiet, nil, 0, 0},
+	}
+
+	userRP := &fakeUserRepository{claimErrs: map[primitive.ObjectID]error{}, claimed: map[primitive.ObjectID]bool{}}
+	cardRP := &fakeCardRepository{due: map[primitive.ObjectID]int{}}
+	reviewRP := &fakeReviewRepository{last: map[string]*entity.ReviewLog{
+		studied.ID.Hex(): {CreatedAt: now.Add(-time.Hour)},
+	}}
+	for _, tt := range tests {
+		userRP.users = append(userRP.users, tt.user)
+		userRP.claimErrs[tt.user.ID] = tt.claimErr
+		if tt.user.ID != nothingDue.ID {
+			cardRP.due[tt.user.ID] = 3
+		}
+	}
+
+	email := memnotifier.NewMemNotifier(entity.NOTIFY_CHANNEL_EMAIL)
+	pushNotifier := memnotifier.NewMemNotifier(entity.NOTIFY_CHANNEL_PUSH)
+	uc := NewReminderUsecase(userRP, cardRP, &fakeMemberRepository{}, reviewRP, []notifier.Notifier{email, pushNotifier})
+
+	if err := uc.SendDueReminders(now); err == nil {
+		t.Error("SendDueReminders() error = nil, want the claim error")
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotEmail, gotPush := 0, 0
+			for _, notification := range email.Sent() {
+				if notification.UserID == tt.user.ID {
+					gotEmail++
+				}
+			}
+			for _, notification := range pushNotifier.Sent() {
+				if notification.UserID == tt.user.ID {
+					gotPush++
+					if notification.To != tt.user.Reminder.PushToken {
+						t.Errorf("push sent to %q, want %q", notification.To, tt.user.Reminder.PushToken)
+					}
+				}
+			}
+			if gotEmail != tt.wantEmail || gotPush != tt.wantPush {
+				t.Errorf("email, push sent = %d, %d, want %d, %d", gotEmail, gotPush, tt.wantEmail, tt.wantPush)
+			}
+		})
+	}
+
+	// A second run in the same window sends nothing more.
+	email.Reset()
+	pushNotifier.Reset()
+	userRP.claimErrs[broken.ID] = nil
+	if err := uc.SendDueReminders(now.Add(time.Minute)); err != nil {
+		t.Fatalf("SendDueReminders() error = %v", err)
+	}
+	for _, notification := range append(email.Sent(), pushNotifier.Sent()...) {
+		if notification.UserID != broken.ID {
+			t.Errorf("reminded %s twice", notification.To)
+		}
+	}
+}
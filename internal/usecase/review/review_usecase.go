@@ -0,0 +1,281 @@
+package review
+
+import (
+	"errors"
+	"time"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+	"vietcard-backend/pkg/helpers"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type reviewUsecase struct {
+	reviewRepository repository.ReviewRepository
+	cardRepository   repository.CardRepository
+	deckRepository   repository.DeckRepository
+	memberRepository repository.MemberRepository
+	userRepository   repository.UserRepository
+	rules            entity.XPRules
+}
+
+func NewReviewUsecase(rr repository.ReviewRepository, cr repository.CardRepository, dr repository.DeckRepository, mr repository.MemberRepository, ur repository.UserRepository, rules entity.XPRules) usecase.ReviewUsecase {
+	return &reviewUsecase{
+		reviewRepository: rr,
+		cardRepository:   cr,
+		deckRepository:   dr,
+		memberRepository: mr,
+		userRepository:   ur,
+		rules:            rules,
+	}
+}
+
+// loadCards returns the cards of the deck with the reviewer's review state.
+// Members of a shared deck review it with their own progress, the owner's
+// stays on the deck and its cards. Members don't get hidden decks or cards.
+func (uc *reviewUsecase) loadCards(userID *string, deck *entity.Deck, isOwner bool) (*[]entity.Card, error) {
+	deckID := deck.ID.Hex()
+	if !isOwner && deck.IsHidden {
+		return nil, errors.New("deck not found")
+	}
+	if !isOwner {
+		deckProgress, err := uc.memberRepository.GetDeckProgress(userID, &deckID)
+		if err != nil {
+			return nil, err
+		}
+		deckProgress.ApplyTo(deck)
+	}
+	deck.UpdateReview()
+
+	cards, err := uc.cardRepository.GetCardsByDeck(&deckID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner {
+		cards = entity.VisibleCards(cards)
+		cardProgress, err := uc.memberRepository.GetCardProgress(userID, &deckID)
+		if err != nil {
+			return nil, err
+		}
+		entity.ApplyCardProgress(cards, cardProgress)
+	}
+	return cards, nil
+}
+
+func (uc *reviewUsecase) saveCard(userID primitive.ObjectID, card *entity.Card, isOwner bool) error {
+	if isOwner {
+		return uc.cardRepository.UpdateCardReview(card)
+	}
+	return uc.memberRepository.UpsertCardProgress(entity.NewCardProgress(userID, card))
+}
+
+func (uc *reviewUsecase) saveDeck(userID primitive.ObjectID, deck *entity.Deck, isOwner bool) error {
+	if isOwner {
+		deckID := deck.ID.Hex()
+		_, err := uc.deckRepository.UpdateDeck(&deckID, &request.UpdateDeckRequest{
+			LastReview:        &deck.LastReview,
+			CurNewCards:       &deck.CurNewCards,
+			MaxNewCards:       &deck.MaxNewCards,
+			TotalLearnedCards: &deck.TotalLearnedCards,
+		})
+		return err
+	}
+	return uc.memberRepository.UpsertDeckProgress(entity.NewDeckProgress(userID, deck))
+}
+
+// flag checks the session for implausible submissions and returns the XP it
+// may be awarded. Sessions reviewed faster than humanly possible get no XP.
+// since is when the previous session was logged, or when the user signed up
+// if there is none.
+func (uc *reviewUsecase) flag(log *entity.ReviewLog, since time.Time) int {
+	awardedXP := log.Breakdown.TotalXP
+
+	// The session can't have lasted longer than the time since the previous
+	// one, whatever the client claims.
+	elapsed := time.Duration(log.DurationMs) * time.Millisecond
+	if gap := log.CreatedAt.Sub(since); elapsed <= 0 || gap < elapsed {
+		elapsed = gap
+	}
+	if log.NumCards > 0 && (elapsed <= 0 || float64(log.NumCards)/elapsed.Seconds() > uc.rules.MaxReviewsPerSecond) {
+		log.Flags = append(log.Flags, entity.REVIEW_FLAG_TOO_FAST)
+		awardedXP = 0
+	}
+	if log.Breakdown.TotalXP > uc.rules.MaxSessionXP {
+		log.Flags = append(log.Flags, entity.REVIEW_FLAG_OVER_SESSION_XP)
+		if awardedXP > uc.rules.MaxSessionXP {
+			awardedXP = uc.rules.MaxSessionXP
+		}
+	}
+	if log.ClaimedXP > log.Breakdown.TotalXP {
+		log.Flags = append(log.Flags, entity.REVIEW_FLAG_CLAIMED_XP_HIGHER)
+	}
+	log.IsFlagged = len(log.Flags) > 0
+	return awardedXP
+}
+
+// SubmitReview reschedules the graded cards, computes the XP of the session on
+// the server and logs it. The caller awards Log.AwardedXP to the user.
+func (uc *reviewUsecase) SubmitReview(userID *string, deck *entity.Deck, isOwner bool, req *request.UpdateReviewCardsRequest) (*entity.ReviewResult, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := uc.userRepository.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	cards, err := uc.loadCards(userID, deck, isOwner)
+	if err != nil {
+		return nil, err
+	}
+	cardsMap := make(map[primitive.ObjectID]*entity.Card)
+	for i := range *cards {
+		cardsMap[(*cards)[i].ID] = &(*cards)[i]
+	}
+	// Only the cards the deck would serve today can be reviewed, so cards that
+	// aren't due can't be resubmitted for XP.
+	dueCards, _, _, _ := helpers.FilterReviewCards(cards, deck.MaxNewCards-deck.CurNewCards, deck.MaxReviewCards-deck.CurReviewCards)
+	isDue := make(map[primitive.ObjectID]bool, len(*dueCards))
+	for _, card := range *dueCards {
+		isDue[card.ID] = true
+	}
+
+	log := &entity.ReviewLog{
+		CreatedAt:  time.Now(),
+		UserID:     uID,
+		DeckID:     deck.ID,
+		NumCards:   len(req.CardIDs),
+		DurationMs: req.DurationMs,
+		ClaimedXP:  req.TotalXP,
+		Flags:      []string{},
+	}
+	answers := make([]entity.ReviewAnswer, 0, len(req.CardIDs))
+	needUpdate := make(map[primitive.ObjectID]bool)
+	for i, id := range req.CardIDs {
+		correct := req.IsCorrect[i]
+		card, exists := cardsMap[id]
+		if !exists {
+			return nil, errors.New("Some card doesn't exist in given deck!")
+		}
+		if needUpdate[id] {
+			return nil, errors.New("Some card is reviewed more than once!")
+		}
+		if !isDue[id] {
+			return nil, errors.New("Some card isn't due for review!")
+		}
+		answers = append(answers, entity.ReviewAnswer{
+			CardType:   card.ReviewType(),
+			Correct:    correct,
+			FirstLearn: correct && card.NumReviews == 0,
+		})
+		card.UpdateScheduleSM2(correct)
+		needUpdate[id] = true
+		if correct {
+			log.NumCorrect++
+			if card.NumReviews == 1 {
+				log.NumNew++
+				deck.CurNewCards++
+				deck.TotalLearnedCards++
+			} else {
+				deck.CurReviewCards++
+			}
+		}
+	}
+
+	for id := range needUpdate {
+		if err = uc.saveCard(uID, cardsMap[id], isOwner); err != nil {
+			return nil, err
+		}
+	}
+	if err = uc.saveDeck(uID, deck, isOwner); err != nil {
+		return nil, err
+	}
+
+	last, err := uc.reviewRepository.GetLastReviewLog(userID)
+	if err != nil {
+		return nil, err
+	}
+	since := user.CreatedAt
+	if last != nil {
+		since = last.CreatedAt
+	}
+	log.Breakdown = uc.rules.ComputeXP(answers, user.CurrentStreak())
+	log.Breakdown.ApplyBoost(user.ActiveXPBoostPercent())
+	log.AwardedXP = uc.flag(log, since)
+	if _, err = uc.reviewRepository.CreateReviewLog(log); err != nil {
+		return nil, err
+	}
+
+	result := &entity.ReviewResult{Log: log}
+	result.Cards, result.NumBlueCards, result.NumRedCards, result.NumGreenCards = helpers.FilterReviewCards(cards, deck.MaxNewCards-deck.CurNewCards, deck.MaxReviewCards-deck.CurReviewCards)
+	return result, nil
+}
+
+func (uc *reviewUsecase) GetFlaggedReviews(req *request.GetFlaggedReviewsRequest) (*[]entity.ReviewLog, string, error) {
+	return uc.reviewRepository.GetFlaggedReviewLogs(req)
+}
+
+// GetActivity returns the user's activity per local day of [from, to] and
+// their study streaks, both days being truncated to the day in loc.
+func (uc *reviewUsecase) GetActivity(userID *string, from time.Time, to time.Time, loc *time.Location) (*entity.ActivityCalendar, error) {
+	activeDays, err := uc.reviewRepository.GetActivityDays(userID, loc.String())
+	if err != nil {
+		return nil, err
+	}
+	activity := make(map[string]entity.ActivityDay, len(*activeDays))
+	for _, day := range *activeDays {
+		activity[day.Day] = day
+	}
+
+	calendar := &entity.ActivityCalendar{
+		From:            from.Format(entity.ACTIVITY_DAY_LAYOUT),
+		To:              to.Format(entity.ACTIVITY_DAY_LAYOUT),
+		TimeZone:        loc.String(),
+		Days:            []entity.ActivityDay{},
+		TotalActiveDays: len(*activeDays),
+	}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		key := day.Format(entity.ACTIVITY_DAY_LAYOUT)
+		if _, ok := activity[key]; !ok {
+			activity[key] = entity.ActivityDay{Day: key}
+		}
+		calendar.Days = append(calendar.Days, activity[key])
+	}
+
+	today := time.Now().In(loc).Format(entity.ACTIVITY_DAY_LAYOUT)
+	calendar.CurrentStreak, calendar.LongestStreak = activityStreaks(*activeDays, today)
+	return calendar, nil
+}
+
+// activityStreaks returns the run of consecutive active days still going,
+// that is ending today or yesterday, and the longest run ever. The days are
+// sorted, oldest first.
+func activityStreaks(days []entity.ActivityDay, today string) (int, int) {
+	longest, run := 0, 0
+	var last time.Time
+	for i, day := range days {
+		t, err := time.Parse(entity.ACTIVITY_DAY_LAYOUT, day.Day)
+		if err != nil {
+			continue
+		}
+		if i > 0 && t.Equal(last.AddDate(0, 0, 1)) {
+			run++
+		} else {
+			run = 1
+		}
+		last = t
+		if run > longest {
+			longest = run
+		}
+	}
+	now, err := time.Parse(entity.ACTIVITY_DAY_LAYOUT, today)
+	if err != nil || run == 0 || last.Before(now.AddDate(0, 0, -1)) {
+		return 0, longest
+	}
+	return run, longest
+}
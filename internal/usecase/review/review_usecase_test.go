@@ -0,0 +1,199 @@
+package review
+
+import (
+	"testing"
+	"time"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/pkg/timeutil"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fakeUserRepository struct {
+	repository.UserRepository
+	user *entity.User
+}
+
+func (ur *fakeUserRepository) GetByID(id *string) (*entity.User, error) {
+	return ur.user, nil
+}
+
+type fakeCardRepository struct {
+	repository.CardRepository
+	cards []entity.Card
+	saved []primitive.ObjectID
+}
+
+func (cr *fakeCardRepository) GetCardsByDeck(deckID *string) (*[]entity.Card, error) {
+	cards := append([]entity.Card{}, cr.cards...)
+	return &cards, nil
+}
+
+func (cr *fakeCardRepository) UpdateCardReview(card *entity.Card) error {
+	cr.saved = append(cr.saved, card.ID)
+	return nil
+}
+
+type fakeDeckRepository struct {
+	repository.DeckRepository
+}
+
+func (dr *fakeDeckRepository) UpdateDeck(deckID *string, req *request.UpdateDeckRequest) (*entity.Deck, error) {
+	return nil, nil
+}
+
+type fakeReviewRepository struct {
+	repository.ReviewRepository
+	logs []entity.ReviewLog
+}
+
+func (rr *fakeReviewRepository) GetLastReviewLog(userID *string) (*entity.ReviewLog, error) {
+	if len(rr.logs) == 0 {
+		return nil, nil
+	}
+	return &rr.logs[len(rr.logs)-1], nil
+}
+
+func (rr *fakeReviewRepository) CreateReviewLog(log *entity.ReviewLog) (*entity.ReviewLog, error) {
+	rr.logs = append(rr.logs, *log)
+	return log, nil
+}
+
+// reviewFixture is an owner with one deck whose cards are set by each test.
+type reviewFixture struct {
+	user    *entity.User
+	deck    *entity.Deck
+	cards   *fakeCardRepository
+	reviews *fakeReviewRepository
+}
+
+func newReviewFixture(cards ...entity.Card) *reviewFixture {
+	user := &entity.User{ID: primitive.NewObjectID(), CreatedAt: time.Now().Add(-time.Hour)}
+	deck := (&entity.Deck{ID: primitive.NewObjectID(), UserID: user.ID}).SetDefault()
+	for i := range cards {
+		cards[i].ID = primitive.NewObjectID()
+		cards[i].DeckID = deck.ID
+	}
+	return &reviewFixture{
+		user:    user,
+		deck:    deck,
+		cards:   &fakeCardRepository{cards: cards},
+		reviews: &fakeReviewRepository{},
+	}
+}
+
+func (f *reviewFixture) submit(cardIDs ...primitive.ObjectID) (*entity.ReviewResult, error) {
+	uc := NewReviewUsecase(f.reviews, f.cards, &fakeDeckRepository{}, nil, &fakeUserRepository{user: f.user}, entity.DefaultXPRules)
+	userID := f.user.ID.Hex()
+	isCorrect := make([]bool, len(cardIDs))
+	for i := range isCorrect {
+		isCorrect[i] = true
+	}
+	return uc.SubmitReview(&userID, f.deck, true, &request.UpdateReviewCardsRequest{
+		DeckID:     f.deck.ID,
+		DurationMs: 60000,
+		CardIDs:    cardIDs,
+		IsCorrect:  isCorrect,
+	})
+}
+
+func dueCard(nextReview time.Time) entity.Card {
+	card := (&entity.Card{}).SetDefault()
+	card.NumReviews = 3
+	card.Sm2N = 2
+	card.Sm2I = 4
+	card.NextReview = nextReview
+	return *card
+}
+
+func TestSubmitReviewAwardsDueCards(t *testing.T) {
+	today := timeutil.TruncateToDay(time.Now())
+	f := newReviewFixture(dueCard(today), dueCard(today.AddDate(0, 0, -3)), *(&entity.Card{}).SetDefault())
+
+	result, err := f.submit(f.cards.cards[0].ID, f.cards.cards[1].ID, f.cards.cards[2].ID)
+	if err != nil {
+		t.Fatalf("SubmitReview() error = %v", err)
+	}
+	if result.Log.AwardedXP == 0 || result.Log.AwardedXP != result.Log.Breakdown.TotalXP {
+		t.Errorf("AwardedXP = %d, want the session's %d", result.Log.AwardedXP, result.Log.Breakdown.TotalXP)
+	}
+	if len(f.cards.saved) != 3 {
+		t.Errorf("saved %d cards, want 3", len(f.cards.saved))
+	}
+}
+
+func TestSubmitReviewRejectsCardsTheDeckDoesNotServe(t *testing.T) {
+	today := timeutil.TruncateToDay(time.Now())
+
+	t.Run("not due yet", func(t *testing.T) {
+		f := newReviewFixture(dueCard(today), dueCard(today.AddDate(0, 0, 2)))
+		if _, err := f.submit(f.cards.cards[0].ID, f.cards.cards[1].ID); err == nil {
+			t.Error("SubmitReview() error = nil, want the card that isn't due to be rejected")
+		}
+		if len(f.cards.saved) != 0 || len(f.reviews.logs) != 0 {
+			t.Errorf("rejected session saved %d cards and %d logs", len(f.cards.saved), len(f.reviews.logs))
+		}
+	})
+
+	t.Run("hidden by moderators", func(t *testing.T) {
+		hidden := dueCard(today)
+		hidden.IsHidden = true
+		f := newReviewFixture(hidden)
+		if _, err := f.submit(f.cards.cards[0].ID); err == nil {
+			t.Error("SubmitReview() error = nil, want the hidden card to be rejected")
+		}
+	})
+
+	t.Run("over the daily new card limit", func(t *testing.T) {
+		f := newReviewFixture(*(&entity.Card{}).SetDefault(), *(&entity.Card{}).SetDefault())
+		f.deck.MaxNewCards = 1
+		if _, err := f.submit(f.cards.cards[0].ID, f.cards.cards[1].ID); err == nil {
+			t.Error("SubmitReview() error = nil, want the second new card to be rejected")
+		}
+	})
+
+	t.Run("resubmitted after review", func(t *testing.T) {
+		f := newReviewFixture(dueCard(today))
+		if _, err := f.submit(f.cards.cards[0].ID); err != nil {
+			t.Fatalf("first SubmitReview() error = %v", err)
+		}
+		// The card was pushed to a later day, as the repository would store it.
+		f.cards.cards[0].NextReview = today.AddDate(0, 0, 4)
+		if _, err := f.submit(f.cards.cards[0].ID); err == nil {
+			t.Error("SubmitReview() error = nil, want the reviewed card to be rejected")
+		}
+	})
+}
+
+func activeDays(days ...string) []entity.ActivityDay {
+	result := make([]entity.ActivityDay, len(days))
+	for i, day := range days {
+		result[i] = entity.ActivityDay{Day: day}
+	}
+	return result
+}
+
+func TestActivityStreaks(t *testing.T) {
+	tests := []struct {
+		name        string
+		days        []entity.ActivityDay
+		today       string
+		wantCurrent int
+		wantLongest int
+	}{
+		{"no activity", nil, "2024-05-10", 0, 0},
+		{"studied today", activeDays("2024-05-08", "2024-05-09", "2024-05-10"), "2024-05-10", 3, 3},
+		{"today not studied yet", activeDays("2024-05-08", "2024-05-09"), "2024-05-10", 2, 2},
+		{"run broken", activeDays("2024-05-01", "2024-05-02", "2024-05-03", "2024-05-08"), "2024-05-10", 0, 3},
+		{"longest run earlier", activeDays("2024-05-01", "2024-05-02", "2024-05-03", "2024-05-09", "2024-05-10"), "2024-05-10", 2, 3},
+		{"across months", activeDays("2024-04-29", "2024-04-30", "2024-05-01"), "2024-05-01", 3, 3},
+	}
+	for _, tt := range tests {
+		current, longest := activityStreaks(tt.days, tt.today)
+		if current != tt.wantCurrent || longest != tt.wantLongest {
+			t.Errorf("%s: activityStreaks() = %d, %d, want %d, %d", tt.name, current, longest, tt.wantCurrent, tt.wantLongest)
+		}
+	}
+}
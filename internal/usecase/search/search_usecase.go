@@ -0,0 +1,91 @@
+package search
+
+import (
+	"errors"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+	"vietcard-backend/pkg/textutil"
+)
+
+const (
+	DEFAULT_LIMIT = 20
+)
+
+type searchUsecase struct {
+	deckRepository repository.DeckRepository
+	cardRepository repository.CardRepository
+}
+
+func NewSearchUsecase(dr repository.DeckRepository, cr repository.CardRepository) usecase.SearchUsecase {
+	return &searchUsecase{
+		deckRepository: dr,
+		cardRepository: cr,
+	}
+}
+
+func (uc *searchUsecase) Search(userID *string, req *request.SearchRequest) (*[]entity.DeckSearchHit, *[]entity.CardSearchHit, error) {
+	terms := textutil.Terms(req.Query)
+	if len(terms) == 0 {
+		return nil, nil, errors.New("search query has no searchable words")
+	}
+	scope := req.Scope
+	if scope == "" {
+		scope = entity.SEARCH_SCOPE_PUBLIC
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = DEFAULT_LIMIT
+	}
+	skip := 0
+	if req.Page > 1 {
+		skip = (req.Page - 1) * limit
+	}
+
+	decks := []entity.DeckSearchHit{}
+	cards := []entity.CardSearchHit{}
+	if req.Type != "cards" {
+		deckHits, err := uc.deckRepository.SearchDecks(&req.Query, userID, scope, skip, limit)
+		if err != nil {
+			return nil, nil, err
+		}
+		decks = *deckHits
+		for i := range decks {
+			decks[i].Highlights = highlights(terms, map[string]string{
+				"name":        decks[i].Name,
+				"description": decks[i].Description,
+			}, "name", "description")
+		}
+	}
+	if req.Type != "decks" {
+		cardHits, err := uc.cardRepository.SearchCards(&req.Query, userID, scope, skip, limit)
+		if err != nil {
+			return nil, nil, err
+		}
+		cards = *cardHits
+		for i := range cards {
+			cards[i].Highlights = highlights(terms, map[string]string{
+				"question": cards[i].Question,
+				"answer":   cards[i].Answer,
+			}, "question", "answer")
+		}
+	}
+	return &decks, &cards, nil
+}
+
+func highlights(terms []string, fields map[string]string, order ...string) []entity.SearchHighlight {
+	result := []entity.SearchHighlight{}
+	for _, field := range order {
+		snippet, ranges, ok := textutil.Highlight(fields[field], terms)
+		if !ok {
+			continue
+		}
+		result = append(result, entity.SearchHighlight{
+			Field:   field,
+			Snippet: snippet,
+			Ranges:  ranges,
+		})
+	}
+	return result
+}
@@ -0,0 +1,71 @@
+package session
+
+import (
+	"errors"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type sessionUsecase struct {
+	sessionRepository repository.SessionRepository
+}
+
+func NewSessionUsecase(sr repository.SessionRepository) usecase.SessionUsecase {
+	return &sessionUsecase{
+		sessionRepository: sr,
+	}
+}
+
+// GetSessions returns the user's active sessions and marks the one the
+// request was made from.
+func (uc *sessionUsecase) GetSessions(userID *string, currentSessionID *string) (*[]entity.Session, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := uc.sessionRepository.GetUserSessions(uID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for i := range *sessions {
+		(*sessions)[i].IsCurrent = (*sessions)[i].ID.Hex() == *currentSessionID
+	}
+	return sessions, nil
+}
+
+func (uc *sessionUsecase) RevokeSession(userID *string, sessionID *string, reason string) error {
+	sID, err := primitive.ObjectIDFromHex(*sessionID)
+	if err != nil {
+		return err
+	}
+	session, err := uc.sessionRepository.GetSession(sID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID.Hex() != *userID || !session.IsActive(time.Now()) {
+		return errors.New("session not found")
+	}
+	return uc.sessionRepository.RevokeSession(sID, reason)
+}
+
+// RevokeSessions revokes all of the user's sessions, or all but one if
+// exceptSessionID is set, and returns how many were revoked.
+func (uc *sessionUsecase) RevokeSessions(userID *string, exceptSessionID *string, reason string) (int, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return 0, err
+	}
+	var except *primitive.ObjectID
+	if exceptSessionID != nil {
+		sID, err := primitive.ObjectIDFromHex(*exceptSessionID)
+		if err != nil {
+			return 0, err
+		}
+		except = &sID
+	}
+	return uc.sessionRepository.RevokeUserSessions(uID, except, reason)
+}
@@ -0,0 +1,125 @@
+package share
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+	"vietcard-backend/pkg/randutil"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type shareUsecase struct {
+	shareRepository repository.ShareRepository
+	deckRepository  repository.DeckRepository
+}
+
+func NewShareUsecase(sr repository.ShareRepository, dr repository.DeckRepository) usecase.ShareUsecase {
+	return &shareUsecase{
+		shareRepository: sr,
+		deckRepository:  dr,
+	}
+}
+
+func (uc *shareUsecase) getOwnedDeck(userID *string, deckID *string) (*entity.Deck, error) {
+	deck, err := uc.deckRepository.GetDeckByID(deckID)
+	if err != nil {
+		return nil, err
+	}
+	if deck == nil {
+		return nil, errors.New("deck not found")
+	}
+	if deck.UserID.Hex() != *userID {
+		return nil, errors.New("only the owner can manage share links of this deck")
+	}
+	return deck, nil
+}
+
+func (uc *shareUsecase) CreateShareLink(userID *string, link *entity.ShareLink) (*entity.ShareLink, error) {
+	deckID := link.DeckID.Hex()
+	deck, err := uc.getOwnedDeck(userID, &deckID)
+	if err != nil {
+		return nil, err
+	}
+	link.Token, err = randutil.RandomToken(entity.SHARE_TOKEN_BYTES)
+	if err != nil {
+		return nil, err
+	}
+	link.UserID = deck.UserID
+	link.HasPassword = link.HashedPassword != ""
+	return uc.shareRepository.CreateShareLink(link)
+}
+
+func (uc *shareUsecase) GetShareLinks(userID *string, deckID *string) (*[]entity.ShareLink, error) {
+	if _, err := uc.getOwnedDeck(userID, deckID); err != nil {
+		return nil, err
+	}
+	return uc.shareRepository.GetShareLinksOfDeck(deckID)
+}
+
+func (uc *shareUsecase) RevokeShareLink(userID *string, shareLinkID *string) error {
+	link, err := uc.shareRepository.GetShareLinkByID(shareLinkID)
+	if err != nil {
+		return err
+	}
+	if link == nil {
+		return errors.New("share link not found")
+	}
+	deckID := link.DeckID.Hex()
+	if _, err = uc.getOwnedDeck(userID, &deckID); err != nil {
+		return err
+	}
+	return uc.shareRepository.RevokeShareLink(shareLinkID)
+}
+
+func (uc *shareUsecase) GetShareLinkByToken(token *string) (*entity.ShareLink, error) {
+	return uc.shareRepository.GetShareLinkByToken(token)
+}
+
+// GetSharedDeck returns the deck of the link without its hidden cards, or nil
+// if the deck is gone or hidden by moderation.
+func (uc *shareUsecase) GetSharedDeck(link *entity.ShareLink) (*entity.DeckWithCards, error) {
+	deckID := link.DeckID.Hex()
+	deck, err := uc.deckRepository.GetDeckWithCards(&deckID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if deck.IsHidden {
+		return nil, nil
+	}
+	deck.Cards = entity.VisibleCards(deck.Cards)
+	return deck, nil
+}
+
+// shareAttemptKeys are the keys failed attempts on the link of the token from
+// the IP are counted under. Tokens are stored hashed.
+func shareAttemptKeys(token string, ip string) []string {
+	sum := sha256.Sum256([]byte(token))
+	return []string{"token:" + hex.EncodeToString(sum[:]), "ip:" + ip}
+}
+
+func shareAttemptWindow(now time.Time) time.Time {
+	return now.Truncate(entity.SHARE_ATTEMPT_WINDOW_MINUTE * time.Minute)
+}
+
+// IsShareLinkLocked tells if the link of the token or the IP had too many
+// failed attempts in the current window.
+func (uc *shareUsecase) IsShareLinkLocked(token string, ip string) (bool, error) {
+	keys := shareAttemptKeys(token, ip)
+	attempts, err := uc.shareRepository.GetFailedAttempts(keys, shareAttemptWindow(time.Now()))
+	if err != nil {
+		return false, err
+	}
+	return attempts[keys[0]] >= entity.MAX_SHARE_ATTEMPTS_PER_TOKEN || attempts[keys[1]] >= entity.MAX_SHARE_ATTEMPTS_PER_IP, nil
+}
+
+func (uc *shareUsecase) RecordFailedShareAttempt(token string, ip string) error {
+	return uc.shareRepository.AddFailedAttempt(shareAttemptKeys(token, ip), shareAttemptWindow(time.Now()))
+}
@@ -0,0 +1,91 @@
+package share
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+)
+
+// fakeShareRepository counts failed attempts per window like the attempts
+// collection does.
+type fakeShareRepository struct {
+	repository.ShareRepository
+	attempts map[time.Time]map[string]int
+}
+
+func (sr *fakeShareRepository) GetFailedAttempts(keys []string, windowStart time.Time) (map[string]int, error) {
+	result := map[string]int{}
+	for _, key := range keys {
+		if count, exists := sr.attempts[windowStart][key]; exists {
+			result[key] = count
+		}
+	}
+	return result, nil
+}
+
+func (sr *fakeShareRepository) AddFailedAttempt(keys []string, windowStart time.Time) error {
+	if sr.attempts[windowStart] == nil {
+		sr.attempts[windowStart] = map[string]int{}
+	}
+	for _, key := range keys {
+		sr.attempts[windowStart][key]++
+	}
+	return nil
+}
+
+func newLockoutUsecase() (*shareUsecase, *fakeShareRepository) {
+	repo := &fakeShareRepository{attempts: map[time.Time]map[string]int{}}
+	return &shareUsecase{shareRepository: repo}, repo
+}
+
+func mustBeLocked(t *testing.T, uc *shareUsecase, token string, ip string, want bool) {
+	t.Helper()
+	locked, err := uc.IsShareLinkLocked(token, ip)
+	if err != nil {
+		t.Fatalf("IsShareLinkLocked(%q, %q) error = %v", token, ip, err)
+	}
+	if locked != want {
+		t.Fatalf("IsShareLinkLocked(%q, %q) = %v, want %v", token, ip, locked, want)
+	}
+}
+
+func TestShareLinkLocksAfterTooManyFailuresOnToken(t *testing.T) {
+	uc, repo := newLockoutUsecase()
+
+	// Guesses for the same token from changing IPs still add up.
+	for i := 0; i < entity.MAX_SHARE_ATTEMPTS_PER_TOKEN; i++ {
+		mustBeLocked(t, uc, "guessed", fmt.Sprintf("10.0.0.%d", i), false)
+		uc.RecordFailedShareAttempt("guessed", fmt.Sprintf("10.0.0.%d", i))
+	}
+	mustBeLocked(t, uc, "guessed", "10.0.0.99", true)
+	mustBeLocked(t, uc, "another", "10.0.0.99", false)
+
+	for _, counts := range repo.attempts {
+		if _, exists := counts["token:guessed"]; exists {
+			t.Error("token was counted in plain text")
+		}
+	}
+}
+
+func TestShareLinkLocksAfterTooManyFailuresFromIP(t *testing.T) {
+	uc, _ := newLockoutUsecase()
+
+	for i := 0; i < entity.MAX_SHARE_ATTEMPTS_PER_IP; i++ {
+		uc.RecordFailedShareAttempt(fmt.Sprintf("token-%d", i), "10.0.0.1")
+	}
+	mustBeLocked(t, uc, "fresh-token", "10.0.0.1", true)
+	mustBeLocked(t, uc, "fresh-token", "10.0.0.2", false)
+}
+
+func TestShareLinkLockoutEndsWithItsWindow(t *testing.T) {
+	uc, repo := newLockoutUsecase()
+	previous := shareAttemptWindow(time.Now()).Add(-entity.SHARE_ATTEMPT_WINDOW_MINUTE * time.Minute)
+	repo.attempts[previous] = map[string]int{}
+	for _, key := range shareAttemptKeys("guessed", "10.0.0.1") {
+		repo.attempts[previous][key] = entity.MAX_SHARE_ATTEMPTS_PER_IP
+	}
+
+	mustBeLocked(t, uc, "guessed", "10.0.0.1", false)
+}
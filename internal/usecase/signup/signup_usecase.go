@@ -5,30 +5,45 @@ import (
 	"vietcard-backend/internal/domain/interface/repository"
 	"vietcard-backend/internal/domain/interface/usecase"
 	"vietcard-backend/pkg/tokenutil"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type signupUsecase struct {
 	userRepository repository.UserRepository
+	levelCurve     entity.LevelCurve
 }
 
-func NewSignupUsecase(userRepository repository.UserRepository) usecase.SignupUsecase {
+func NewSignupUsecase(userRepository repository.UserRepository, levelCurve entity.LevelCurve) usecase.SignupUsecase {
 	return &signupUsecase{
 		userRepository: userRepository,
+		levelCurve:     levelCurve,
 	}
 }
 
+// Create stores the user at the start of the first level of the configured
+// level curve.
 func (su *signupUsecase) Create(user *entity.User) (string, error) {
-	return su.userRepository.Create(user)
+	uID, err := su.userRepository.Create(user)
+	if err != nil {
+		return "", err
+	}
+	user.ID, err = primitive.ObjectIDFromHex(uID)
+	if err != nil {
+		return "", err
+	}
+	user.UpdateLevel(su.levelCurve)
+	err = su.userRepository.SetLevel(user)
+	if err != nil {
+		return "", err
+	}
+	return uID, nil
 }
 
 func (su *signupUsecase) GetUserByEmail(email *string) (*entity.User, error) {
 	return su.userRepository.GetByEmail(email)
 }
 
-func (su *signupUsecase) CreateAccessToken(user *entity.User, secret *string, expiry int) (accessToken string, err error) {
-	return tokenutil.CreateAccessToken(user, secret, expiry)
-}
-
-func (su *signupUsecase) CreateRefreshToken(user *entity.User, secret *string, expiry int) (refreshToken string, err error) {
-	return tokenutil.CreateRefreshToken(user, secret, expiry)
+func (su *signupUsecase) CreateAccessToken(user *entity.User, session *entity.Session, secret *string, expiry int) (accessToken string, err error) {
+	return tokenutil.CreateAccessToken(user, session, secret, expiry)
 }
@@ -0,0 +1,75 @@
+package streak
+
+import (
+	"errors"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+	"vietcard-backend/pkg/timeutil"
+)
+
+type streakUsecase struct {
+	streakRepository repository.StreakRepository
+	userRepository   repository.UserRepository
+}
+
+func NewStreakUsecase(sr repository.StreakRepository, ur repository.UserRepository) usecase.StreakUsecase {
+	return &streakUsecase{
+		streakRepository: sr,
+		userRepository:   ur,
+	}
+}
+
+// GetStreak returns the user's streak, freezes and the history of the last
+// given number of days.
+func (uc *streakUsecase) GetStreak(userID *string, days int) (*entity.StreakInfo, error) {
+	user, err := uc.userRepository.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	from := timeutil.TruncateToDay(time.Now()).AddDate(0, 0, 1-days)
+	history, err := uc.streakRepository.GetStreakDays(userID, from)
+	if err != nil {
+		return nil, err
+	}
+	info := &entity.StreakInfo{
+		Streak:           user.CurrentStreak(),
+		StreakFreezes:    user.StreakFreezes,
+		MaxStreakFreezes: entity.MAX_STREAK_FREEZES,
+		CanRepair:        user.CanRepairStreak(),
+		History:          *history,
+	}
+	if info.CanRepair {
+		info.StreakRepair = user.StreakRepair
+	}
+	return info, nil
+}
+
+func (uc *streakUsecase) RepairStreak(userID *string) (*entity.User, error) {
+	user, err := uc.userRepository.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	repair, lastStreak := user.StreakRepair, user.LastStreak
+	if err = user.RepairStreak(); err != nil {
+		return nil, err
+	}
+	updated, err := uc.userRepository.UpdateUserStreak(user, lastStreak, 0)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, errors.New("streak was updated meanwhile, try again")
+	}
+	if err = uc.streakRepository.RepairStreakDays(user.ID, repair.MissedFrom, repair.MissedTo); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
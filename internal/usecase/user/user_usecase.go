@@ -1,19 +1,27 @@
 package user
 
 import (
+	"errors"
 	"vietcard-backend/internal/delivery/http/request"
 	"vietcard-backend/internal/domain/entity"
 	"vietcard-backend/internal/domain/interface/repository"
 	"vietcard-backend/internal/domain/interface/usecase"
+	"vietcard-backend/pkg/timeutil"
 )
 
 type userUsecase struct {
-	userRepository repository.UserRepository
+	userRepository   repository.UserRepository
+	streakRepository repository.StreakRepository
+	xpRepository     repository.XPRepository
+	levelCurve       entity.LevelCurve
 }
 
-func NewUserUsecase(userRepository repository.UserRepository) usecase.UserUsecase {
+func NewUserUsecase(userRepository repository.UserRepository, streakRepository repository.StreakRepository, xpRepository repository.XPRepository, levelCurve entity.LevelCurve) usecase.UserUsecase {
 	return &userUsecase{
-		userRepository: userRepository,
+		userRepository:   userRepository,
+		streakRepository: streakRepository,
+		xpRepository:     xpRepository,
+		levelCurve:       levelCurve,
 	}
 }
 func (uu *userUsecase) GetUserByEmail(email *string) (*entity.User, error) {
@@ -28,25 +36,104 @@ func (uu *userUsecase) UpdateUser(userID *string, req *request.UpdateUserRequest
 	return uu.userRepository.UpdateUser(userID, req)
 }
 
-func (uu *userUsecase) AddXPToUser(userID *string, XP int) (*entity.User, error) {
+// AddXPToUser records the entry in the XP ledger and adds its amount to the
+// user's lifetime XP. An entry whose reference was already recorded is not
+// counted again, and one whose XP couldn't be added is taken back out of the
+// ledger so it can be retried. The day only counts in the streak if countStreak is set, for
+// users whose streak requires meeting the daily goal.
+func (uu *userUsecase) AddXPToUser(userID *string, entry *entity.XPEntry, countStreak bool) (*entity.User, error) {
 	user, err := uu.userRepository.GetByID(userID)
 	if err != nil {
 		return nil, err
 	}
-	user.XP += XP
-	user.UpdateLevel()
-	user.UpdateStreak()
+	if entry.Amount > 0 {
+		entry.UserID = user.ID
+		created, err := uu.xpRepository.CreateXPEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			user, err = uu.userRepository.IncrementLifetimeXP(user.ID, entry.Amount)
+			if err != nil {
+				if deleteErr := uu.xpRepository.DeleteXPEntry(entry.ID); deleteErr != nil {
+					return nil, deleteErr
+				}
+				return nil, err
+			}
+		}
+	}
+	user.UpdateLevel(uu.levelCurve)
 	err = uu.userRepository.UpdateUserXP(user)
 	if err != nil {
 		return nil, err
 	}
+	if countStreak {
+		var days []entity.StreakDay
+		user, days, err = uu.updateStreak(userID, user)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, entity.StreakDay{UserID: user.ID, Day: timeutil.TruncateToDay(user.LastStreak), Status: entity.STREAK_DAY_STUDIED})
+		err = uu.streakRepository.RecordStreakDays(days)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return user, nil
 }
 
-func (uu *userUsecase) CreateFact(fact *entity.Fact) error {
-	return uu.userRepository.CreateFact(fact)
+// updateStreak counts today in the user's streak. Streak freezes are only
+// changed by the number spent or earned, so ones bought meanwhile are kept.
+func (uu *userUsecase) updateStreak(userID *string, user *entity.User) (*entity.User, []entity.StreakDay, error) {
+	for attempt := 1; ; attempt++ {
+		lastStreak, freezes := user.LastStreak, user.StreakFreezes
+		days := user.UpdateStreak()
+		if user.LastStreak.Equal(lastStreak) {
+			return user, days, nil
+		}
+		updated, err := uu.userRepository.UpdateUserStreak(user, lastStreak, user.StreakFreezes-freezes)
+		if err != nil {
+			return nil, nil, err
+		}
+		if updated {
+			return user, days, nil
+		}
+		if attempt == entity.STREAK_UPDATE_ATTEMPTS {
+			return nil, nil, errors.New("streak was updated concurrently")
+		}
+		user, err = uu.userRepository.GetByID(userID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if user == nil {
+			return nil, nil, errors.New("user not found")
+		}
+	}
+}
+
+func (uu *userUsecase) GetXPHistory(userID *string, limit int, cursor string) (*[]entity.XPEntry, string, error) {
+	return uu.xpRepository.GetXPLedger(userID, limit, cursor)
 }
 
-func (uu *userUsecase) GetFact() (*entity.Fact, error) {
-	return uu.userRepository.GetFact()
+// RecomputeLevels sets every user's lifetime XP to the sum of their ledger
+// entries and their level to the one it reaches on the configured curve. It
+// returns the number of users with XP.
+func (uu *userUsecase) RecomputeLevels() (int, error) {
+	totals, err := uu.xpRepository.GetXPTotals()
+	if err != nil {
+		return 0, err
+	}
+	err = uu.userRepository.ResetLevels(uu.levelCurve.XPToLevelUp(1))
+	if err != nil {
+		return 0, err
+	}
+	for _, total := range *totals {
+		user := entity.User{ID: total.UserID, LifetimeXP: total.Total}
+		user.UpdateLevel(uu.levelCurve)
+		err = uu.userRepository.SetLevel(&user)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(*totals), nil
 }
@@ -0,0 +1,114 @@
+package view
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+	"vietcard-backend/pkg/timeutil"
+)
+
+type viewUsecase struct {
+	viewRepository repository.ViewRepository
+	deckRepository repository.DeckRepository
+	window         time.Duration
+}
+
+func NewViewUsecase(vr repository.ViewRepository, dr repository.DeckRepository, windowHour int) usecase.ViewUsecase {
+	if windowHour <= 0 {
+		windowHour = entity.DEFAULT_VIEW_WINDOW_HOUR
+	}
+	if windowHour > entity.MAX_VIEW_WINDOW_HOUR {
+		windowHour = entity.MAX_VIEW_WINDOW_HOUR
+	}
+	return &viewUsecase{
+		viewRepository: vr,
+		deckRepository: dr,
+		window:         time.Duration(windowHour) * time.Hour,
+	}
+}
+
+func hashViewer(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// RecordDeckView counts a view of a public deck at most once per viewer per
+// window. Logged in viewers are keyed by user ID, anonymous ones by a hash of
+// their IP and user agent. Owners viewing their own deck are never counted.
+func (uc *viewUsecase) RecordDeckView(deckID *string, viewerUserID string, ip string, userAgent string, fingerprint string) (bool, *entity.Deck, error) {
+	deck, err := uc.deckRepository.GetDeckByID(deckID)
+	if err != nil {
+		return false, nil, err
+	}
+	if deck == nil || !deck.IsPublic {
+		return false, nil, nil
+	}
+	if viewerUserID != "" && deck.UserID.Hex() == viewerUserID {
+		return false, deck, nil
+	}
+
+	viewerKey := "user:" + viewerUserID
+	if viewerUserID == "" {
+		viewerKey = "anon:" + hashViewer(ip+"|"+userAgent)
+	}
+	fingerprintHash := ""
+	if fingerprint != "" {
+		fingerprintHash = hashViewer(fingerprint)
+	}
+	now := time.Now()
+	counted, err := uc.viewRepository.CreateViewEvent(&entity.DeckViewEvent{
+		DeckID:          deck.ID,
+		ViewerKey:       viewerKey,
+		FingerprintHash: fingerprintHash,
+		WindowStart:     now.Truncate(uc.window),
+	})
+	if err != nil || !counted {
+		return false, deck, err
+	}
+
+	if err = uc.deckRepository.IncrementViews(deckID); err != nil {
+		return false, deck, err
+	}
+	if err = uc.viewRepository.IncrementDailyViews(deckID, now.Local().Format(entity.VIEW_DAY_LAYOUT)); err != nil {
+		return false, deck, err
+	}
+	deck.Views++
+	return true, deck, nil
+}
+
+// GetDeckViewTrend returns one bucket per day for the last days days,
+// including days without views.
+func (uc *viewUsecase) GetDeckViewTrend(deckID *string, days int) (*[]entity.DeckViewDaily, error) {
+	deck, err := uc.deckRepository.GetDeckByID(deckID)
+	if err != nil {
+		return nil, err
+	}
+	if deck == nil {
+		return nil, errors.New("deck not found")
+	}
+
+	from := timeutil.TruncateToDay(time.Now()).AddDate(0, 0, -(days - 1))
+	buckets, err := uc.viewRepository.GetDailyViews(deckID, from)
+	if err != nil {
+		return nil, err
+	}
+	views := make(map[string]int)
+	for _, bucket := range *buckets {
+		views[bucket.Day] = bucket.Views
+	}
+
+	trend := []entity.DeckViewDaily{}
+	for i := 0; i < days; i++ {
+		day := from.AddDate(0, 0, i).Format(entity.VIEW_DAY_LAYOUT)
+		trend = append(trend, entity.DeckViewDaily{
+			DeckID: deck.ID,
+			Day:    day,
+			Views:  views[day],
+		})
+	}
+	return &trend, nil
+}
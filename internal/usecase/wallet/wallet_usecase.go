@@ -0,0 +1,354 @@
+package wallet
+
+import (
+	"errors"
+	"time"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+	"vietcard-backend/internal/domain/interface/usecase"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type walletUsecase struct {
+	walletRepository repository.WalletRepository
+	shopRepository   repository.ShopRepository
+	userRepository   repository.UserRepository
+}
+
+func NewWalletUsecase(wr repository.WalletRepository, sr repository.ShopRepository, ur repository.UserRepository) usecase.WalletUsecase {
+	return &walletUsecase{
+		walletRepository: wr,
+		shopRepository:   sr,
+		userRepository:   ur,
+	}
+}
+
+// applyEntry records the ledger entry and moves the balance by it at once. An
+// entry whose idempotency key was already used isn't applied again, the
+// recorded one is returned instead.
+func (uc *walletUsecase) applyEntry(entry *entity.LedgerEntry) (*entity.LedgerEntry, error) {
+	recorded, covered, err := uc.walletRepository.ApplyLedgerEntry(entry)
+	if err != nil {
+		return nil, err
+	}
+	if !covered {
+		return nil, errors.New("not enough coins")
+	}
+	if !recorded {
+		return uc.walletRepository.GetLedgerEntry(entry.UserID, entry.IdempotencyKey)
+	}
+	return entry, nil
+}
+
+// purchaseEntry is the spend or refund of a purchase, recorded once per
+// purchase.
+func purchaseEntry(purchase *entity.Purchase, entryType string, source string) *entity.LedgerEntry {
+	return &entity.LedgerEntry{
+		UserID:         purchase.UserID,
+		Type:           entryType,
+		Amount:         purchase.Price,
+		Source:         source,
+		RefID:          purchase.ID,
+		IdempotencyKey: source + ":" + purchase.ID.Hex(),
+	}
+}
+
+func (uc *walletUsecase) GetWallet(userID *string) (*entity.Wallet, *[]entity.UserItem, error) {
+	wallet, err := uc.walletRepository.GetWallet(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if wallet == nil {
+		uID, err := primitive.ObjectIDFromHex(*userID)
+		if err != nil {
+			return nil, nil, err
+		}
+		wallet = &entity.Wallet{UserID: uID, Balance: 0}
+	}
+	items, err := uc.shopRepository.GetUserItems(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return wallet, items, nil
+}
+
+func (uc *walletUsecase) GetLedger(userID *string, limit int, cursor string) (*[]entity.LedgerEntry, string, error) {
+	return uc.walletRepository.GetLedger(userID, limit, cursor)
+}
+
+// Credit gives coins to the user once per idempotency key.
+func (uc *walletUsecase) Credit(userID *string, amount int, source string, refID primitive.ObjectID, idempotencyKey string) (*entity.LedgerEntry, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	if amount <= 0 {
+		return nil, errors.New("amount must be positive")
+	}
+	return uc.applyEntry(&entity.LedgerEntry{
+		UserID:         uID,
+		Type:           entity.WALLET_ENTRY_EARN,
+		Amount:         amount,
+		Source:         source,
+		RefID:          refID,
+		IdempotencyKey: idempotencyKey,
+	})
+}
+
+func (uc *walletUsecase) GrantCoins(adminID *string, req *request.GrantCoinsRequest) (*entity.LedgerEntry, error) {
+	aID, err := primitive.ObjectIDFromHex(*adminID)
+	if err != nil {
+		return nil, err
+	}
+	userID := req.UserID.Hex()
+	user, err := uc.userRepository.GetByID(&userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	entry := &entity.LedgerEntry{
+		UserID:         user.ID,
+		Type:           entity.WALLET_ENTRY_EARN,
+		Amount:         req.Amount,
+		Source:         entity.WALLET_SOURCE_ADMIN_GRANT,
+		Note:           req.Note,
+		IdempotencyKey: entity.WALLET_SOURCE_ADMIN_GRANT + ":" + req.IdempotencyKey,
+		ActorID:        &aID,
+	}
+	if req.Amount < 0 {
+		entry.Type = entity.WALLET_ENTRY_SPEND
+		entry.Amount = -req.Amount
+	}
+	return uc.applyEntry(entry)
+}
+
+func (uc *walletUsecase) GetShopItems(activeOnly bool) (*[]entity.ShopItem, error) {
+	return uc.shopRepository.GetShopItems(activeOnly)
+}
+
+func validateShopItem(item *entity.ShopItem) error {
+	switch item.Type {
+	case entity.SHOP_ITEM_STREAK_FREEZE:
+		if item.Quantity < 1 || item.Quantity > entity.MAX_STREAK_FREEZES {
+			return errors.New("quantity of a streak freeze must be between 1 and the maximum number of streak freezes")
+		}
+	case entity.SHOP_ITEM_XP_BOOST:
+		if item.BoostPercent < 1 || item.BoostMinutes < 1 {
+			return errors.New("an XP boost needs a boost percent and a duration")
+		}
+	}
+	return nil
+}
+
+func (uc *walletUsecase) CreateShopItem(req *request.CreateShopItemRequest) (*entity.ShopItem, error) {
+	item := &entity.ShopItem{
+		Type:         req.Type,
+		Name:         req.Name,
+		Description:  req.Description,
+		ImageURL:     req.ImageURL,
+		Price:        req.Price,
+		Quantity:     req.Quantity,
+		BoostPercent: req.BoostPercent,
+		BoostMinutes: req.BoostMinutes,
+		IsActive:     true,
+	}
+	if req.IsActive != nil {
+		item.IsActive = *req.IsActive
+	}
+	if item.Type == entity.SHOP_ITEM_STREAK_FREEZE && item.Quantity == 0 {
+		item.Quantity = 1
+	}
+	if err := validateShopItem(item); err != nil {
+		return nil, err
+	}
+	return uc.shopRepository.CreateShopItem(item)
+}
+
+func (uc *walletUsecase) UpdateShopItem(req *request.UpdateShopItemRequest) (*entity.ShopItem, error) {
+	itemID := req.ItemID.Hex()
+	item, err := uc.shopRepository.GetShopItem(&itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, errors.New("shop item not found")
+	}
+	if req.Quantity != nil {
+		item.Quantity = *req.Quantity
+	}
+	if req.BoostPercent != nil {
+		item.BoostPercent = *req.BoostPercent
+	}
+	if req.BoostMinutes != nil {
+		item.BoostMinutes = *req.BoostMinutes
+	}
+	if err = validateShopItem(item); err != nil {
+		return nil, err
+	}
+	return uc.shopRepository.UpdateShopItem(&itemID, req)
+}
+
+// checkItem tells whether the user can get the item at all, so they aren't
+// charged for something that can't be given.
+func (uc *walletUsecase) checkItem(user *entity.User, item *entity.ShopItem) error {
+	switch item.Type {
+	case entity.SHOP_ITEM_STREAK_FREEZE:
+		if user.StreakFreezes+item.Quantity > entity.MAX_STREAK_FREEZES {
+			return errors.New("can't hold more streak freezes")
+		}
+	case entity.SHOP_ITEM_COSMETIC:
+		owned, err := uc.shopRepository.GetUserItem(user.ID, item.ID)
+		if err != nil {
+			return err
+		}
+		if owned != nil {
+			return errors.New("item is already owned")
+		}
+	}
+	return nil
+}
+
+// giveItem applies the item to the user. It returns false if the item could
+// no longer be given, e.g. a concurrent purchase filled the streak freezes.
+func (uc *walletUsecase) giveItem(user *entity.User, item *entity.ShopItem) (bool, error) {
+	switch item.Type {
+	case entity.SHOP_ITEM_STREAK_FREEZE:
+		return uc.userRepository.AddStreakFreezes(user.ID, item.Quantity, entity.MAX_STREAK_FREEZES)
+	case entity.SHOP_ITEM_XP_BOOST:
+		// Buying a boost while one is active extends it, at the higher of
+		// the two percents.
+		boost := &entity.XPBoost{Percent: item.BoostPercent, ExpiresAt: time.Now()}
+		if active := user.ActiveXPBoostPercent(); active > 0 {
+			boost.ExpiresAt = user.XPBoost.ExpiresAt
+			if active > boost.Percent {
+				boost.Percent = active
+			}
+		}
+		boost.ExpiresAt = boost.ExpiresAt.Add(time.Duration(item.BoostMinutes) * time.Minute)
+		return true, uc.userRepository.SetXPBoost(user.ID, boost)
+	case entity.SHOP_ITEM_COSMETIC:
+		return uc.shopRepository.AddUserItem(&entity.UserItem{UserID: user.ID, ItemID: item.ID})
+	}
+	return false, errors.New("unknown shop item type")
+}
+
+func (uc *walletUsecase) failPurchase(purchase *entity.Purchase, reason string) (*entity.Purchase, error) {
+	purchase.Status = entity.PURCHASE_STATUS_FAILED
+	purchase.FailReason = reason
+	if _, err := uc.shopRepository.SetPurchaseStatus(purchase.ID, purchase.Status, reason); err != nil {
+		return nil, err
+	}
+	return purchase, errors.New(reason)
+}
+
+// refundPurchase gives the coins of a purchase back before failing it, so a
+// purchase that failed never keeps the coins.
+func (uc *walletUsecase) refundPurchase(purchase *entity.Purchase, reason string) (*entity.Purchase, error) {
+	if _, err := uc.applyEntry(purchaseEntry(purchase, entity.WALLET_ENTRY_EARN, entity.WALLET_SOURCE_REFUND)); err != nil {
+		return nil, err
+	}
+	return uc.failPurchase(purchase, reason)
+}
+
+// recoverPurchase settles a purchase that was interrupted before it completed.
+// Whether its item was given can't be told, so it fails and the coins it took,
+// if any, are refunded.
+func (uc *walletUsecase) recoverPurchase(purchase *entity.Purchase) (*entity.Purchase, error) {
+	spend, err := uc.walletRepository.GetLedgerEntry(purchase.UserID, purchaseEntry(purchase, entity.WALLET_ENTRY_SPEND, entity.WALLET_SOURCE_PURCHASE).IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if spend == nil {
+		return uc.failPurchase(purchase, "purchase was interrupted")
+	}
+	return uc.refundPurchase(purchase, "purchase was interrupted")
+}
+
+// PurchaseItem buys a shop item with the user's coins. Purchases are
+// idempotent: retrying with the same key returns the first purchase, or its
+// error if it failed, without charging again. Retrying a purchase that was
+// interrupted settles it.
+func (uc *walletUsecase) PurchaseItem(userID *string, req *request.PurchaseItemRequest) (*entity.Purchase, error) {
+	uID, err := primitive.ObjectIDFromHex(*userID)
+	if err != nil {
+		return nil, err
+	}
+	purchase, err := uc.shopRepository.GetPurchase(uID, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if purchase == nil {
+		purchase, err = uc.newPurchase(uID, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if purchase.Status == entity.PURCHASE_STATUS_PENDING && time.Since(purchase.CreatedAt) > entity.PENDING_PURCHASE_TIMEOUT_MINUTE*time.Minute {
+		return uc.recoverPurchase(purchase)
+	}
+	if purchase.Status == entity.PURCHASE_STATUS_FAILED {
+		return purchase, errors.New(purchase.FailReason)
+	}
+	return purchase, nil
+}
+
+func (uc *walletUsecase) newPurchase(userID primitive.ObjectID, req *request.PurchaseItemRequest) (*entity.Purchase, error) {
+	uID := userID.Hex()
+	user, err := uc.userRepository.GetByID(&uID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	itemID := req.ItemID.Hex()
+	item, err := uc.shopRepository.GetShopItem(&itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil || !item.IsActive {
+		return nil, errors.New("item is not for sale")
+	}
+	if err = uc.checkItem(user, item); err != nil {
+		return nil, err
+	}
+
+	purchase := &entity.Purchase{
+		UserID:         userID,
+		ItemID:         item.ID,
+		IdempotencyKey: req.IdempotencyKey,
+		Price:          item.Price,
+		Status:         entity.PURCHASE_STATUS_PENDING,
+	}
+	created, err := uc.shopRepository.CreatePurchase(purchase)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		// A concurrent request with the same key got there first.
+		return uc.shopRepository.GetPurchase(userID, req.IdempotencyKey)
+	}
+
+	// The spend is recorded together with the balance change, so a spend that
+	// failed took no coins.
+	if _, err = uc.applyEntry(purchaseEntry(purchase, entity.WALLET_ENTRY_SPEND, entity.WALLET_SOURCE_PURCHASE)); err != nil {
+		return uc.failPurchase(purchase, err.Error())
+	}
+	given, err := uc.giveItem(user, item)
+	if err != nil || !given {
+		if err == nil {
+			err = errors.New("item could not be given")
+		}
+		return uc.refundPurchase(purchase, err.Error())
+	}
+
+	purchase.Status = entity.PURCHASE_STATUS_COMPLETED
+	if _, err = uc.shopRepository.SetPurchaseStatus(purchase.ID, purchase.Status, ""); err != nil {
+		return nil, err
+	}
+	return purchase, nil
+}
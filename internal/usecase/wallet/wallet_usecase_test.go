@@ -0,0 +1,271 @@
+package wallet
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"vietcard-backend/internal/delivery/http/request"
+	"vietcard-backend/internal/domain/entity"
+	"vietcard-backend/internal/domain/interface/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeWalletRepository applies an entry and its balance change together, like
+// the transaction of the real repository.
+type fakeWalletRepository struct {
+	repository.WalletRepository
+	balances map[primitive.ObjectID]int
+	entries  map[string]entity.LedgerEntry
+	failNext error
+}
+
+func newFakeWalletRepository() *fakeWalletRepository {
+	return &fakeWalletRepository{balances: map[primitive.ObjectID]int{}, entries: map[string]entity.LedgerEntry{}}
+}
+
+func (wr *fakeWalletRepository) ApplyLedgerEntry(entry *entity.LedgerEntry) (bool, bool, error) {
+	if err := wr.failNext; err != nil {
+		wr.failNext = nil
+		return false, false, err
+	}
+	key := entry.UserID.Hex() + "/" + entry.IdempotencyKey
+	if _, exists := wr.entries[key]; exists {
+		return false, true, nil
+	}
+	if wr.balances[entry.UserID]+entry.Delta() < 0 {
+		return false, false, nil
+	}
+	entry.ID = primitive.NewObjectID()
+	wr.entries[key] = *entry
+	wr.balances[entry.UserID] += entry.Delta()
+	return true, true, nil
+}
+
+func (wr *fakeWalletRepository) GetLedgerEntry(userID primitive.ObjectID, idempotencyKey string) (*entity.LedgerEntry, error) {
+	entry, exists := wr.entries[userID.Hex()+"/"+idempotencyKey]
+	if !exists {
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+type fakeShopRepository struct {
+	repository.ShopRepository
+	items     map[primitive.ObjectID]*entity.ShopItem
+	purchases map[string]*entity.Purchase
+	owned     map[primitive.ObjectID]bool
+}
+
+func (sr *fakeShopRepository) GetShopItem(itemID *string) (*entity.ShopItem, error) {
+	id, _ := primitive.ObjectIDFromHex(*itemID)
+	return sr.items[id], nil
+}
+
+func (sr *fakeShopRepository) CreatePurchase(purchase *entity.Purchase) (bool, error) {
+	if _, exists := sr.purchases[purchase.IdempotencyKey]; exists {
+		return false, nil
+	}
+	purchase.ID = primitive.NewObjectID()
+	purchase.CreatedAt = time.Now()
+	stored := *purchase
+	sr.purchases[purchase.IdempotencyKey] = &stored
+	return true, nil
+}
+
+func (sr *fakeShopRepository) GetPurchase(userID primitive.ObjectID, idempotencyKey string) (*entity.Purchase, error) {
+	purchase, exists := sr.purchases[idempotencyKey]
+	if !exists {
+		return nil, nil
+	}
+	copied := *purchase
+	return &copied, nil
+}
+
+func (sr *fakeShopRepository) SetPurchaseStatus(purchaseID primitive.ObjectID, status string, failReason string) (bool, error) {
+	for _, purchase := range sr.purchases {
+		if purchase.ID == purchaseID && purchase.Status == entity.PURCHASE_STATUS_PENDING {
+			purchase.Status = status
+			purchase.FailReason = failReason
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (sr *fakeShopRepository) GetUserItem(userID primitive.ObjectID, itemID primitive.ObjectID) (*entity.UserItem, error) {
+	if !sr.owned[itemID] {
+		return nil, nil
+	}
+	return &entity.UserItem{UserID: userID, ItemID: itemID}, nil
+}
+
+func (sr *fakeShopRepository) AddUserItem(item *entity.UserItem) (bool, error) {
+	if sr.owned[item.ItemID] {
+		return false, nil
+	}
+	sr.owned[item.ItemID] = true
+	return true, nil
+}
+
+type fakeUserRepository struct {
+	repository.UserRepository
+	user          *entity.User
+	freezesFilled bool
+}
+
+func (ur *fakeUserRepository) GetByID(id *string) (*entity.User, error) {
+	return ur.user, nil
+}
+
+func (ur *fakeUserRepository) AddStreakFreezes(userID primitive.ObjectID, count int, max int) (bool, error) {
+	if ur.freezesFilled {
+		return false, nil
+	}
+	ur.user.StreakFreezes += count
+	return true, nil
+}
+
+type walletFixture struct {
+	uc      *walletUsecase
+	userID  string
+	wallets *fakeWalletRepository
+	shop    *fakeShopRepository
+	users   *fakeUserRepository
+}
+
+func newWalletFixture(balance int) *walletFixture {
+	user := &entity.User{ID: primitive.NewObjectID()}
+	f := &walletFixture{
+		userID:  user.ID.Hex(),
+		wallets: newFakeWalletRepository(),
+		shop: &fakeShopRepository{
+			items:     map[primitive.ObjectID]*entity.ShopItem{},
+			purchases: map[string]*entity.Purchase{},
+			owned:     map[primitive.ObjectID]bool{},
+		},
+		users: &fakeUserRepository{user: user},
+	}
+	f.wallets.balances[user.ID] = balance
+	f.uc = NewWalletUsecase(f.wallets, f.shop, f.users).(*walletUsecase)
+	return f
+}
+
+func (f *walletFixture) balance() int {
+	return f.wallets.balances[f.users.user.ID]
+}
+
+func (f *walletFixture) addItem(itemType string, price int) *entity.ShopItem {
+	item := &entity.ShopItem{ID: primitive.NewObjectID(), Type: itemType, Price: price, Quantity: 1, IsActive: true}
+	f.shop.items[item.ID] = item
+	return item
+}
+
+func (f *walletFixture) buy(item *entity.ShopItem, key string) (*entity.Purchase, error) {
+	return f.uc.PurchaseItem(&f.userID, &request.PurchaseItemRequest{ItemID: &item.ID, IdempotencyKey: key})
+}
+
+func TestCreditIsPaidOnce(t *testing.T) {
+	f := newWalletFixture(0)
+	questID := primitive.NewObjectID()
+	key := entity.WALLET_SOURCE_QUEST + ":" + questID.Hex()
+
+	// The first try fails before anything is recorded, the retry pays.
+	f.wallets.failNext = errors.New("connection reset")
+	if _, err := f.uc.Credit(&f.userID, 50, entity.WALLET_SOURCE_QUEST, questID, key); err == nil {
+		t.Fatal("Credit() error = nil, want the repository error")
+	}
+	first, err := f.uc.Credit(&f.userID, 50, entity.WALLET_SOURCE_QUEST, questID, key)
+	if err != nil {
+		t.Fatalf("Credit() error = %v", err)
+	}
+	again, err := f.uc.Credit(&f.userID, 50, entity.WALLET_SOURCE_QUEST, questID, key)
+	if err != nil {
+		t.Fatalf("Credit() again error = %v", err)
+	}
+	if again.ID != first.ID {
+		t.Errorf("Credit() again returned entry %s, want the recorded %s", again.ID.Hex(), first.ID.Hex())
+	}
+	if f.balance() != 50 {
+		t.Errorf("balance = %d, want 50", f.balance())
+	}
+}
+
+func TestPurchaseItemChargesOncePerKey(t *testing.T) {
+	f := newWalletFixture(100)
+	item := f.addItem(entity.SHOP_ITEM_COSMETIC, 30)
+
+	purchase, err := f.buy(item, "buy-1")
+	if err != nil {
+		t.Fatalf("PurchaseItem() error = %v", err)
+	}
+	if purchase.Status != entity.PURCHASE_STATUS_COMPLETED || !f.shop.owned[item.ID] {
+		t.Fatalf("purchase %s, item owned %v, want it completed and owned", purchase.Status, f.shop.owned[item.ID])
+	}
+	retried, err := f.buy(item, "buy-1")
+	if err != nil {
+		t.Fatalf("PurchaseItem() retry error = %v", err)
+	}
+	if retried.ID != purchase.ID || f.balance() != 70 {
+		t.Errorf("retry got purchase %s and left %d coins, want %s and 70", retried.ID.Hex(), f.balance(), purchase.ID.Hex())
+	}
+}
+
+func TestPurchaseItemNotEnoughCoins(t *testing.T) {
+	f := newWalletFixture(10)
+	item := f.addItem(entity.SHOP_ITEM_COSMETIC, 30)
+	for i := 0; i < 2; i++ {
+		if _, err := f.buy(item, "buy-1"); err == nil {
+			t.Fatal("PurchaseItem() error = nil, want not enough coins")
+		}
+	}
+	if f.balance() != 10 || f.shop.owned[item.ID] {
+		t.Errorf("balance = %d, owned = %v, want the 10 coins kept and no item", f.balance(), f.shop.owned[item.ID])
+	}
+	if len(f.wallets.entries) != 0 {
+		t.Errorf("recorded %d ledger entries for a spend that wasn't covered", len(f.wallets.entries))
+	}
+}
+
+func TestPurchaseItemRefundsWhenItemCantBeGiven(t *testing.T) {
+	f := newWalletFixture(100)
+	item := f.addItem(entity.SHOP_ITEM_STREAK_FREEZE, 40)
+	f.users.freezesFilled = true
+
+	if _, err := f.buy(item, "buy-1"); err == nil {
+		t.Fatal("PurchaseItem() error = nil, want the item not given")
+	}
+	if status := f.shop.purchases["buy-1"].Status; status != entity.PURCHASE_STATUS_FAILED {
+		t.Errorf("purchase status = %s, want failed", status)
+	}
+	if f.balance() != 100 {
+		t.Errorf("balance = %d, want the 100 coins refunded", f.balance())
+	}
+	// A retry reports the failure without charging again.
+	if _, err := f.buy(item, "buy-1"); err == nil || f.balance() != 100 {
+		t.Errorf("retry error = %v, balance = %d, want the failure and 100 coins", err, f.balance())
+	}
+}
+
+func TestPurchaseItemSettlesInterruptedPurchase(t *testing.T) {
+	for _, charged := range []bool{true, false} {
+		f := newWalletFixture(100)
+		item := f.addItem(entity.SHOP_ITEM_STREAK_FREEZE, 40)
+		purchase := &entity.Purchase{UserID: f.users.user.ID, ItemID: item.ID, IdempotencyKey: "buy-1", Price: item.Price, Status: entity.PURCHASE_STATUS_PENDING}
+		f.shop.CreatePurchase(purchase)
+		f.shop.purchases["buy-1"].CreatedAt = time.Now().Add(-(entity.PENDING_PURCHASE_TIMEOUT_MINUTE + 1) * time.Minute)
+		if charged {
+			if _, err := f.uc.applyEntry(purchaseEntry(purchase, entity.WALLET_ENTRY_SPEND, entity.WALLET_SOURCE_PURCHASE)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		settled, err := f.buy(item, "buy-1")
+		if err == nil || settled.Status != entity.PURCHASE_STATUS_FAILED {
+			t.Errorf("charged %v: PurchaseItem() = %v, %v, want the purchase failed", charged, settled, err)
+		}
+		if f.balance() != 100 {
+			t.Errorf("charged %v: balance = %d, want 100", charged, f.balance())
+		}
+	}
+}
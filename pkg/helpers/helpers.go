@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"strings"
 	"time"
 	"vietcard-backend/internal/domain/entity"
 	"vietcard-backend/pkg/timeutil"
@@ -14,28 +15,45 @@ func FilterReviewCards(rawCards *[]entity.Card, maxNewCards int, maxReviewCards
 	var cards []entity.Card
 	for _, card := range *rawCards {
 		reviewTime := timeutil.TruncateToDay(card.NextReview)
-		if reviewTime.After(curTime) {
+		if card.IsHidden || reviewTime.After(curTime) {
 			continue
 		}
-		if card.NumReviews == 0 {
+		card.CardType = card.ReviewType()
+		switch card.CardType {
+		case entity.CARD_TYPE_NEW:
 			if numBlueCards < maxNewCards {
 				numBlueCards++
-				card.CardType = 0
 				cards = append(cards, card)
 			}
-		} else if card.Sm2N == 0 || card.NumReviews == 1 {
+		case entity.CARD_TYPE_LEARNING:
 			if numRedCards < maxReviewCards {
 				numRedCards++
-				card.CardType = 1
 				cards = append(cards, card)
 			}
-		} else {
+		default:
 			if numGreenCards < maxReviewCards {
 				numGreenCards++
-				card.CardType = 2
 				cards = append(cards, card)
 			}
 		}
 	}
 	return &cards, numBlueCards, numRedCards, numGreenCards
 }
+
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool)
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
@@ -0,0 +1,54 @@
+package pagination
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DEFAULT_LIMIT = 20
+	MAX_LIMIT     = 100
+)
+
+// Cursor marks the last item of a page as (sort value, _id) so the next
+// page can resume right after it.
+type Cursor struct {
+	Value interface{}        `json:"v"`
+	ID    primitive.ObjectID `json:"id"`
+}
+
+func EncodeCursor(value interface{}, id primitive.ObjectID) string {
+	b, err := json.Marshal(Cursor{Value: value, ID: id})
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	var cursor Cursor
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err = dec.Decode(&cursor); err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	return &cursor, nil
+}
+
+func NormalizeLimit(limit int) int {
+	if limit <= 0 {
+		return DEFAULT_LIMIT
+	}
+	if limit > MAX_LIMIT {
+		return MAX_LIMIT
+	}
+	return limit
+}
@@ -0,0 +1,14 @@
+package randutil
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+func RandomToken(nBytes int) (string, error) {
+	b := make([]byte, nBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
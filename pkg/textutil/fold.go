@@ -0,0 +1,81 @@
+package textutil
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// foldRune lowercases r and strips its Vietnamese diacritics, e.g. 'Ệ' -> 'e'
+// and 'Đ' -> 'd'. Standalone combining marks fold to 0 and must be dropped.
+func foldRune(r rune) rune {
+	if unicode.Is(unicode.Mn, r) {
+		return 0
+	}
+	switch r {
+	case 'đ', 'Đ':
+		return 'd'
+	}
+	if r < unicode.MaxASCII {
+		return unicode.ToLower(r)
+	}
+	for _, base := range norm.NFD.String(string(r)) {
+		return unicode.ToLower(base)
+	}
+	return unicode.ToLower(r)
+}
+
+func isMark(r rune) bool {
+	return unicode.Is(unicode.Mn, r)
+}
+
+// Fold returns s lowercased and without diacritics so that "Điện Biên Phủ"
+// and "dien bien phu" compare equal.
+func Fold(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if f := foldRune(r); f != 0 {
+			b.WriteRune(f)
+		}
+	}
+	return b.String()
+}
+
+// FoldWithOffsets folds s like Fold and also returns, for every folded rune,
+// the index of the rune of s it came from.
+func FoldWithOffsets(s string) ([]rune, []int) {
+	orig := []rune(s)
+	folded := make([]rune, 0, len(orig))
+	offsets := make([]int, 0, len(orig))
+	for i, r := range orig {
+		if f := foldRune(r); f != 0 {
+			folded = append(folded, f)
+			offsets = append(offsets, i)
+		}
+	}
+	return folded, offsets
+}
+
+// TextSearch returns the terms of the query for a MongoDB $text search. Only
+// letters and digits are kept, so a user can't negate terms with "-" or
+// quote phrases.
+func TextSearch(query string) string {
+	return strings.Join(Terms(query), " ")
+}
+
+// Terms splits a folded query into distinct search terms.
+func Terms(query string) []string {
+	seen := make(map[string]bool)
+	terms := []string{}
+	for _, term := range strings.FieldsFunc(Fold(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
@@ -0,0 +1,24 @@
+package textutil
+
+import "testing"
+
+func TestTextSearch(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"diacritics", "Điện Biên Phủ", "dien bien phu"},
+		{"negation", "hue -hoi an", "hue hoi an"},
+		{"phrase", `"ha noi" pho`, "ha noi pho"},
+		{"duplicates", "Huế hue HUE", "hue"},
+		{"only operators", `- "" -`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TextSearch(tt.query); got != tt.want {
+				t.Errorf("TextSearch(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
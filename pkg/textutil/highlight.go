@@ -0,0 +1,74 @@
+package textutil
+
+import "unicode"
+
+const SNIPPET_CONTEXT = 40
+
+// Highlight finds whole-word, diacritic-insensitive occurrences of terms in
+// text and returns a snippet around the first one together with the
+// [start, end) rune ranges of every match inside that snippet.
+func Highlight(text string, terms []string) (string, [][2]int, bool) {
+	orig := []rune(text)
+	folded, offsets := FoldWithOffsets(text)
+
+	matches := [][2]int{}
+	for _, term := range terms {
+		t := []rune(term)
+		if len(t) == 0 {
+			continue
+		}
+		for i := 0; i+len(t) <= len(folded); i++ {
+			if !hasRunesAt(folded, t, i) || !isBoundary(folded, i-1) || !isBoundary(folded, i+len(t)) {
+				continue
+			}
+			start := offsets[i]
+			end := offsets[i+len(t)-1] + 1
+			for end < len(orig) && isMark(orig[end]) {
+				end++
+			}
+			matches = append(matches, [2]int{start, end})
+		}
+	}
+	if len(matches) == 0 {
+		return "", nil, false
+	}
+
+	first := matches[0]
+	for _, m := range matches {
+		if m[0] < first[0] {
+			first = m
+		}
+	}
+	from := first[0] - SNIPPET_CONTEXT
+	if from < 0 {
+		from = 0
+	}
+	to := first[1] + SNIPPET_CONTEXT
+	if to > len(orig) {
+		to = len(orig)
+	}
+
+	ranges := [][2]int{}
+	for _, m := range matches {
+		if m[0] >= from && m[1] <= to {
+			ranges = append(ranges, [2]int{m[0] - from, m[1] - from})
+		}
+	}
+	return string(orig[from:to]), ranges, true
+}
+
+func hasRunesAt(s []rune, t []rune, i int) bool {
+	for j := range t {
+		if s[i+j] != t[j] {
+			return false
+		}
+	}
+	return true
+}
+
+func isBoundary(s []rune, i int) bool {
+	if i < 0 || i >= len(s) {
+		return true
+	}
+	return !unicode.IsLetter(s[i]) && !unicode.IsDigit(s[i])
+}
@@ -6,3 +6,11 @@ func TruncateToDay(t time.Time) time.Time {
 	t = t.Local()
 	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
 }
+
+// StartOfWeek returns Monday 00:00 UTC of the week containing t. Weeks are
+// kept in UTC so every instance agrees on when a week ends.
+func StartOfWeek(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
@@ -9,21 +9,18 @@ import (
 )
 
 type JwtCustomClaims struct {
-	Name string `json:"user_name"`
-	ID   string `json:"user_id"`
+	Name      string `json:"user_name"`
+	ID        string `json:"user_id"`
+	SessionID string `json:"sid"`
 	jwt.RegisteredClaims
 }
 
-type JwtCustomRefreshClaims struct {
-	ID string `json:"user_id"`
-	jwt.RegisteredClaims
-}
-
-func CreateAccessToken(user *entity.User, secret *string, expireHours int) (accessToken string, err error) {
+func CreateAccessToken(user *entity.User, session *entity.Session, secret *string, expireHours int) (accessToken string, err error) {
 	exp := time.Now().Add(time.Hour * time.Duration(expireHours))
 	claims := &JwtCustomClaims{
-		Name: user.Name,
-		ID:   user.ID.Hex(),
+		Name:      user.Name,
+		ID:        user.ID.Hex(),
+		SessionID: session.ID.Hex(),
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(exp),
 		},
@@ -36,52 +33,20 @@ func CreateAccessToken(user *entity.User, secret *string, expireHours int) (acce
 	return t, err
 }
 
-func CreateRefreshToken(user *entity.User, secret *string, expireHours int) (refreshToken string, err error) {
-	exp := time.Now().Add(time.Hour * time.Duration(expireHours))
-	claimsRefresh := &JwtCustomRefreshClaims{
-		ID: user.ID.Hex(),
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(exp),
-		},
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claimsRefresh)
-	rt, err := token.SignedString([]byte(*secret))
-	if err != nil {
-		return "", err
-	}
-	return rt, err
-}
-
-func IsAuthorized(requestToken *string, secret *string) (bool, error) {
-	_, err := jwt.Parse(*requestToken, func(token *jwt.Token) (interface{}, error) {
+// ExtractClaimsFromToken returns the claims of a valid access token.
+func ExtractClaimsFromToken(requestToken *string, secret *string) (*JwtCustomClaims, error) {
+	claims := &JwtCustomClaims{}
+	token, err := jwt.ParseWithClaims(*requestToken, claims, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
 		}
 		return []byte(*secret), nil
 	})
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	return true, nil
-}
-
-func ExtractIDFromToken(requestToken *string, secret *string) (string, error) {
-	token, err := jwt.Parse(*requestToken, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(*secret), nil
-	})
-
-	if err != nil {
-		return "", err
-	}
-
-	claims, ok := token.Claims.(jwt.MapClaims)
-
-	if !ok && !token.Valid {
-		return "", fmt.Errorf("Invalid Token")
+	if !token.Valid {
+		return nil, fmt.Errorf("Invalid Token")
 	}
-
-	return claims["user_id"].(string), nil
+	return claims, nil
 }